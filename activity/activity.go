@@ -0,0 +1,85 @@
+// Package implementing methods: flickr.activity.*
+package activity
+
+import (
+	"regexp"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// Flickr timeframes are expressed as a number of days or hours, e.g. "2d" or "12h"
+var timeframeRegexp = regexp.MustCompile(`^[0-9]+[dh]$`)
+
+// Something that happened on an item, e.g. a comment or a fave
+type Event struct {
+	// Might contain "comment", "fave", "note", "tag", etc
+	Type      string `xml:"type,attr"`
+	User      string `xml:"user,attr"`
+	Username  string `xml:"username,attr"`
+	DateAdded string `xml:"dateadded,attr"`
+	// Comment or note text, empty for faves
+	Text string `xml:",chardata"`
+}
+
+type Item struct {
+	Type      string  `xml:"type,attr"`
+	Id        string  `xml:"id,attr"`
+	Owner     string  `xml:"owner,attr"`
+	OwnerName string  `xml:"ownername,attr"`
+	Secret    string  `xml:"secret,attr"`
+	Server    string  `xml:"server,attr"`
+	Farm      string  `xml:"farm,attr"`
+	Comments  int     `xml:"comments,attr"`
+	Views     int     `xml:"views,attr"`
+	Faves     int     `xml:"faves,attr"`
+	Media     string  `xml:"media,attr"`
+	Title     string  `xml:"title"`
+	Events    []Event `xml:"activity>event"`
+}
+
+type ActivityResponse struct {
+	flickr.BasicResponse
+	Items struct {
+		Page    int    `xml:"page,attr"`
+		Pages   int    `xml:"pages,attr"`
+		PerPage int    `xml:"perpage,attr"`
+		Total   int    `xml:"total,attr"`
+		Items   []Item `xml:"item"`
+	} `xml:"items"`
+}
+
+// Returns a list of recent activity on photos belonging to the calling user.
+// timeframe is a number of days or hours, e.g. "2d" or "12h", pass "" to let
+// Flickr choose the period since the last request.
+// This method requires authentication with 'read' permission.
+func UserPhotos(client *flickr.FlickrClient, timeframe string, page, perPage int) (*ActivityResponse, error) {
+	if timeframe != "" && !timeframeRegexp.MatchString(timeframe) {
+		return nil, flickErr.NewError(flickErr.ArgumentError, "timeframe must be a number of days or hours, e.g. 2d or 12h: "+timeframe)
+	}
+
+	client.Init()
+	client.Args.Set("method", "flickr.activity.userPhotos")
+	if timeframe != "" {
+		client.Args.Set("timeframe", timeframe)
+	}
+	flickr.SetPageArgs(client, page, perPage)
+	client.OAuthSign()
+
+	response := &ActivityResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Returns a list of recent activity on photos commented on by the calling user.
+// This method requires authentication with 'read' permission.
+func UserComments(client *flickr.FlickrClient, page, perPage int) (*ActivityResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.activity.userComments")
+	flickr.SetPageArgs(client, page, perPage)
+	client.OAuthSign()
+
+	response := &ActivityResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
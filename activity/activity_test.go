@@ -0,0 +1,82 @@
+package activity
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+var body = `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <items page="1" pages="1" perpage="50" total="2">
+    <item type="photo" id="395" owner="12037949754@N01" ownername="Bees" secret="abc" server="1" farm="1" comments="1" views="10" faves="1" media="photo">
+      <title>tempo</title>
+      <activity>
+        <event type="comment" user="12037949754@N01" username="Bees" dateadded="1144086424">yay</event>
+        <event type="fave" user="12037949755@N01" username="Wasps" dateadded="1144086500" />
+      </activity>
+    </item>
+    <item type="photo" id="396" owner="12037949754@N01" ownername="Bees" comments="0" views="0" faves="0" media="video">
+      <title>a video</title>
+    </item>
+  </items>
+</rsp>`
+
+func TestUserPhotos(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, body, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := UserPhotos(fclient, "12h", 1, 50)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("timeframe"), "12h")
+	flickr.Expect(t, fclient.Args.Get("per_page"), "50")
+	flickr.Expect(t, resp.Items.Total, 2)
+	flickr.Expect(t, len(resp.Items.Items), 2)
+
+	item := resp.Items.Items[0]
+	flickr.Expect(t, item.Id, "395")
+	flickr.Expect(t, item.Title, "tempo")
+	flickr.Expect(t, item.Faves, 1)
+	flickr.Expect(t, len(item.Events), 2)
+	flickr.Expect(t, item.Events[0].Type, "comment")
+	flickr.Expect(t, item.Events[0].Username, "Bees")
+	flickr.Expect(t, item.Events[0].DateAdded, "1144086424")
+	flickr.Expect(t, item.Events[0].Text, "yay")
+	flickr.Expect(t, item.Events[1].Type, "fave")
+	flickr.Expect(t, item.Events[1].Text, "")
+
+	flickr.Expect(t, len(resp.Items.Items[1].Events), 0)
+	flickr.Expect(t, resp.Items.Items[1].Media, "video")
+
+	_, err = UserPhotos(fclient, "", 0, 0)
+	flickr.Expect(t, err, nil)
+	_, found := fclient.Args["timeframe"]
+	flickr.Expect(t, found, false)
+}
+
+func TestUserPhotosInvalidTimeframe(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	for _, tf := range []string{"2", "d", "2w", "-1d", "1d2h", " 2d"} {
+		resp, err := UserPhotos(fclient, tf, 0, 0)
+		flickr.Expect(t, resp == nil, true)
+		ferr, ok := err.(*flickErr.Error)
+		flickr.Expect(t, ok, true)
+		flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	}
+}
+
+func TestUserComments(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, body, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := UserComments(fclient, 2, 10)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.activity.userComments")
+	flickr.Expect(t, fclient.Args.Get("page"), "2")
+	flickr.Expect(t, len(resp.Items.Items), 2)
+}
@@ -3,8 +3,13 @@ package oauth
 
 import (
 	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
 )
 
+// Error code Flickr returns for tokens that are invalid, revoked or, for legacy ones,
+// already exchanged
+const invalidAuthTokenCode = 98
+
 // Response type representing data returned by CheckToken
 type CheckTokenResponse struct {
 	flickr.BasicResponse
@@ -38,3 +43,92 @@ func CheckToken(client *flickr.FlickrClient, oauthToken string) (*CheckTokenResp
 	err := flickr.DoGet(client, response)
 	return response, err
 }
+
+// Permissions granted to a token, as checked by CheckClientToken
+type TokenInfo struct {
+	Token string
+	// "read", "write" or "delete"
+	Perms    string
+	UserNsid string
+	Username string
+	Fullname string
+}
+
+// Rank of permissions, each one including the previous ones
+var permsLevels = map[string]int{"read": 1, "write": 2, "delete": 3}
+
+// Tell whether the token grants perm ("read", "write" or "delete"): delete permission
+// includes write, which includes read
+func (i *TokenInfo) Allows(perm string) bool {
+	level, ok := permsLevels[perm]
+	return ok && permsLevels[i.Perms] >= level
+}
+
+// Check the OAuth token held by the client with flickr.auth.oauth.checkToken, returning
+// the permissions it grants and its owner. Unlike FlickrClient.WhoAmI, it tells the
+// scope of the token, use TokenInfo.Allows to verify it.
+// An InvalidTokenError is returned when Flickr rejects the token, invalid or revoked.
+// This method does not require user authentication, but the request must be api-signed.
+func CheckClientToken(client *flickr.FlickrClient) (*TokenInfo, error) {
+	if client.OAuthToken == "" {
+		return nil, flickErr.NewError(flickErr.ArgumentError, "the client holds no OAuth token")
+	}
+
+	response, err := CheckToken(client, client.OAuthToken)
+	if err != nil {
+		if response.ErrorCode() == invalidAuthTokenCode {
+			return nil, flickErr.NewError(flickErr.InvalidTokenError, response.ErrorMsg())
+		}
+		return nil, err
+	}
+
+	return &TokenInfo{
+		Token:    response.OAuth.Token,
+		Perms:    response.OAuth.Perms,
+		UserNsid: response.OAuth.User.ID,
+		Username: response.OAuth.User.Username,
+		Fullname: response.OAuth.User.Fullname,
+	}, nil
+}
+
+type getAccessTokenResponse struct {
+	flickr.BasicResponse
+	Auth struct {
+		AccessToken struct {
+			Token  string `xml:"oauth_token,attr"`
+			Secret string `xml:"oauth_token_secret,attr"`
+		} `xml:"access_token"`
+	} `xml:"auth"`
+}
+
+// Exchange an auth token of the legacy, frob based, authentication for an OAuth access
+// token with the same permissions, the migration path provided by Flickr. The legacy
+// token stops working once exchanged. Flickr doesn't return the owner of the token,
+// use CheckToken to get it.
+// An InvalidTokenError is returned when Flickr rejects authToken, because it is invalid
+// or was already exchanged.
+// This method does not require user authentication, but the request must be api-signed.
+func ExchangeLegacyToken(client *flickr.FlickrClient, authToken string) (*flickr.OAuthToken, error) {
+	if authToken == "" {
+		return nil, flickErr.NewError(flickErr.ArgumentError, "no legacy auth token to exchange")
+	}
+
+	client.Init()
+	client.Args.Set("method", "flickr.auth.oauth.getAccessToken")
+	client.Args.Set("auth_token", authToken)
+	client.ApiSign()
+
+	response := &getAccessTokenResponse{}
+	err := flickr.DoGet(client, response)
+	if err != nil {
+		if response.ErrorCode() == invalidAuthTokenCode {
+			return nil, flickErr.NewError(flickErr.InvalidTokenError, response.ErrorMsg())
+		}
+		return nil, err
+	}
+
+	return &flickr.OAuthToken{
+		OAuthToken:       response.Auth.AccessToken.Token,
+		OAuthTokenSecret: response.Auth.AccessToken.Secret,
+	}, nil
+}
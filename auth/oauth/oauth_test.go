@@ -62,3 +62,98 @@ func TestCheckTokenKo(t *testing.T) {
 	flickr.Expect(t, resp.HasErrors(), true)
 	flickr.Expect(t, resp.ErrorCode(), 98)
 }
+
+func TestExchangeLegacyToken(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <auth>
+    <access_token oauth_token="72157607082540144-8d5d7ea7696629bf" oauth_token_secret="f38bf58b2d95bc8b" />
+  </auth>
+</rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	tok, err := ExchangeLegacyToken(fclient, "legacy-token")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.auth.oauth.getAccessToken")
+	flickr.Expect(t, fclient.Args.Get("auth_token"), "legacy-token")
+	flickr.Expect(t, fclient.Args.Get("api_sig") != "", true)
+	flickr.Expect(t, tok.OAuthToken, "72157607082540144-8d5d7ea7696629bf")
+	flickr.Expect(t, tok.OAuthTokenSecret, "f38bf58b2d95bc8b")
+}
+
+func TestExchangeLegacyTokenKo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="fail"><err code="98" msg="Invalid auth token" /></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	tok, err := ExchangeLegacyToken(fclient, "legacy-token")
+	flickr.Expect(t, tok == nil, true)
+	ee, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ee.ErrorCode, flickErr.InvalidTokenError)
+
+	server, client = flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="fail"><err code="100" msg="Invalid API Key" /></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err = ExchangeLegacyToken(fclient, "legacy-token")
+	ee, ok = err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ee.ErrorCode, flickErr.ApiError)
+
+	_, err = ExchangeLegacyToken(fclient, "")
+	ee, ok = err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ee.ErrorCode, flickErr.ArgumentError)
+}
+
+func TestCheckClientToken(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+	<rsp stat="ok">
+	<oauth>
+		<token>12345678901234567-12abc345def67890</token>
+		<perms>write</perms>
+		<user nsid="12345678@N00" username="Massimiliano Pippi" fullname="Masci" />
+	</oauth>
+	</rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+	fclient.OAuthToken = "12345678901234567-12abc345def67890"
+
+	info, err := CheckClientToken(fclient)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("oauth_token"), "12345678901234567-12abc345def67890")
+	flickr.Expect(t, info.Perms, "write")
+	flickr.Expect(t, info.UserNsid, "12345678@N00")
+	flickr.Expect(t, info.Username, "Massimiliano Pippi")
+	flickr.Expect(t, info.Allows("read"), true)
+	flickr.Expect(t, info.Allows("write"), true)
+	flickr.Expect(t, info.Allows("delete"), false)
+	flickr.Expect(t, info.Allows("admin"), false)
+}
+
+func TestCheckClientTokenKo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	_, err := CheckClientToken(fclient)
+	ee, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ee.ErrorCode, flickErr.ArgumentError)
+
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="fail"><err code="98" msg="Invalid token" /></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+	fclient.OAuthToken = "revoked"
+
+	info, err := CheckClientToken(fclient)
+	flickr.Expect(t, info == nil, true)
+	ee, ok = err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ee.ErrorCode, flickErr.InvalidTokenError)
+}
@@ -1,6 +1,7 @@
 package flickr
 
 import (
+	"crypto/subtle"
 	"io/ioutil"
 	"net/url"
 	"strconv"
@@ -84,9 +85,48 @@ func ParseOAuthToken(response string) (*OAuthToken, error) {
 	return ret, nil
 }
 
+// Type representing the parameters Flickr adds to the callback url once
+// the user has been asked to authorize the application
+type Callback struct {
+	// Request token being authorized
+	OAuthToken string
+	// Verifier to be exchanged along with the request token for an access token
+	OAuthVerifier string
+	// OAuth failing reason in case of errors, e.g. "permission_denied"
+	OAuthProblem string
+}
+
+// Extract a Callback from the url Flickr redirected the user to
+func ParseCallback(rawurl string) (*Callback, error) {
+	u, err := url.Parse(strings.TrimSpace(rawurl))
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &Callback{}
+
+	oauth_problem := val.Get("oauth_problem")
+	if oauth_problem != "" {
+		ret.OAuthProblem = oauth_problem
+		return ret, flickErr.NewError(flickErr.CallbackError, oauth_problem)
+	}
+
+	ret.OAuthToken = val.Get("oauth_token")
+	ret.OAuthVerifier = val.Get("oauth_verifier")
+
+	return ret, nil
+}
+
 // Retrieve a request token: this is the first step to get a fully functional
 // access token from Flickr
 func GetRequestToken(client *FlickrClient) (*RequestToken, error) {
+	// each step of the flow signs its own args only
+	client.ClearArgs()
 	client.EndpointUrl = REQUEST_TOKEN_URL
 	client.SetOAuthDefaults()
 	client.Args.Set("oauth_consumer_key", client.ApiKey)
@@ -120,9 +160,55 @@ func GetAuthorizeUrl(client *FlickrClient, reqToken *RequestToken) (string, erro
 	return client.GetUrl(), nil
 }
 
+// Return the URL users need to reach to grant perms ("read", "write" or "delete") to
+// our application, along with a value to persist in the user session, e.g. in a
+// cookie, and to check the callback with CheckCallbackState.
+// state is a random value the application keeps for the session to prevent CSRF. It is
+// not sent to Flickr: Flickr drops unknown params of the authorize URL and only sends
+// back the request token, so the state is bound to the request token in the returned
+// value instead. The request token secret is not part of it: keep it server-side,
+// keyed by reqToken.OauthToken, until GetAccessToken is called.
+func GetAuthorizeUrlWithState(client *FlickrClient, reqToken *RequestToken, perms, state string) (authURL string, sessionValue string, err error) {
+	if err := checkPerms(perms); err != nil {
+		return "", "", err
+	}
+	if state == "" {
+		return "", "", flickErr.NewError(flickErr.ArgumentError, "empty state")
+	}
+
+	if _, err := GetAuthorizeUrl(client, reqToken); err != nil {
+		return "", "", err
+	}
+	client.Args.Set("perms", perms)
+
+	session := url.Values{}
+	session.Set("state", state)
+	session.Set("oauth_token", reqToken.OauthToken)
+	return client.GetUrl(), session.Encode(), nil
+}
+
+// Check that the callback Flickr redirected the user to belongs to the authorization
+// started with GetAuthorizeUrlWithState: sessionValue must hold the state of the user
+// session and the request token of the callback. A CallbackError is returned otherwise.
+func CheckCallbackState(sessionValue, state string, cb *Callback) error {
+	session, err := url.ParseQuery(sessionValue)
+	if err != nil {
+		return flickErr.NewError(flickErr.CallbackError, "invalid session value")
+	}
+	if state == "" || subtle.ConstantTimeCompare([]byte(session.Get("state")), []byte(state)) != 1 {
+		return flickErr.NewError(flickErr.CallbackError, "state mismatch")
+	}
+	if cb.OAuthToken == "" || session.Get("oauth_token") != cb.OAuthToken {
+		return flickErr.NewError(flickErr.CallbackError, "request token mismatch")
+	}
+	return nil
+}
+
 // Get an access token providing an OAuth verifier provided by Flickr once the user
 // authorizes your application
 func GetAccessToken(client *FlickrClient, reqToken *RequestToken, oauthVerifier string) (*OAuthToken, error) {
+	// the oauth_callback of the request token step must not be signed here
+	client.ClearArgs()
 	client.EndpointUrl = ACCESS_TOKEN_URL
 	client.SetOAuthDefaults()
 	client.Args.Set("oauth_verifier", oauthVerifier)
@@ -151,3 +237,78 @@ func GetAccessToken(client *FlickrClient, reqToken *RequestToken, oauthVerifier
 
 	return accessTok, err
 }
+
+// Check perms is a permission Flickr can grant
+func checkPerms(perms string) error {
+	if perms != "read" && perms != "write" && perms != "delete" {
+		return flickErr.NewError(flickErr.ArgumentError, "perms must be read, write or delete")
+	}
+	return nil
+}
+
+// Perform the whole OAuth flow for applications without a web server (e.g. CLI apps):
+// a request token is retrieved with the "oob" callback, prompt is called with the URL
+// the user must visit to grant perms ("read", "write" or "delete") and must return the
+// verifier code Flickr shows, which is finally exchanged for an access token.
+// The access token is set on the client and returned.
+func InteractiveAuth(client *FlickrClient, perms string, prompt func(authURL string) (verifier string, err error)) (*OAuthToken, error) {
+	if err := checkPerms(perms); err != nil {
+		return nil, err
+	}
+
+	client.ClearArgs()
+	reqTok, err := GetRequestToken(client)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := GetAuthorizeUrl(client, reqTok); err != nil {
+		return nil, err
+	}
+	// GetAuthorizeUrl always asks for delete permission
+	client.Args.Set("perms", perms)
+	authURL := client.GetUrl()
+
+	verifier, err := prompt(authURL)
+	if err != nil {
+		return nil, err
+	}
+
+	client.ClearArgs()
+	return GetAccessToken(client, reqTok, strings.TrimSpace(verifier))
+}
+
+// Response of flickr.test.login, used by WhoAmI
+type loginResponse struct {
+	BasicResponse
+	User struct {
+		ID       string `xml:"id,attr"`
+		Username string `xml:"username"`
+	} `xml:"user"`
+}
+
+// Check the OAuth token held by the client is still valid by calling flickr.test.login,
+// and return it along with the Flickr ID and username of its owner.
+// An InvalidTokenError is returned when Flickr rejects the token (error code 98).
+// This method requires authentication with 'read' permission.
+func (c *FlickrClient) WhoAmI() (*OAuthToken, error) {
+	c.Init()
+	c.Args.Set("method", "flickr.test.login")
+	c.OAuthSign()
+
+	response := &loginResponse{}
+	err := DoGet(c, response)
+	if err != nil {
+		if response.ErrorCode() == 98 {
+			return nil, flickErr.NewError(flickErr.InvalidTokenError, response.ErrorMsg())
+		}
+		return nil, err
+	}
+
+	return &OAuthToken{
+		OAuthToken:       c.OAuthToken,
+		OAuthTokenSecret: c.OAuthTokenSecret,
+		UserNsid:         response.User.ID,
+		Username:         response.User.Username,
+	}, nil
+}
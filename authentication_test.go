@@ -1,6 +1,11 @@
 package flickr
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 	"testing"
 
 	flickErr "gopkg.in/masci/flickr.v2/error"
@@ -59,6 +64,36 @@ func TestGetAuthorizeUrl(t *testing.T) {
 	Expect(t, url, "https://www.flickr.com/services/oauth/authorize?oauth_token=token&perms=delete")
 }
 
+func TestGetAuthorizeUrlWithState(t *testing.T) {
+	client := GetTestClient()
+	tok := &RequestToken{true, "token", "token_secret", ""}
+	authURL, session, err := GetAuthorizeUrlWithState(client, tok, "write", "s3cr3t")
+	Expect(t, err, nil)
+	Expect(t, authURL, "https://www.flickr.com/services/oauth/authorize?oauth_token=token&perms=write")
+	Expect(t, strings.Contains(session, "token_secret"), false)
+
+	cb := &Callback{OAuthToken: "token", OAuthVerifier: "verifier"}
+	Expect(t, CheckCallbackState(session, "s3cr3t", cb), nil)
+	for _, err := range []error{
+		CheckCallbackState(session, "other", cb),
+		CheckCallbackState(session, "", cb),
+		CheckCallbackState(session, "s3cr3t", &Callback{OAuthToken: "forged"}),
+		CheckCallbackState("", "s3cr3t", cb),
+		CheckCallbackState("%%%", "s3cr3t", cb),
+	} {
+		ee, ok := err.(*flickErr.Error)
+		Expect(t, ok, true)
+		Expect(t, ee.ErrorCode, flickErr.CallbackError)
+	}
+
+	for _, args := range [][2]string{{"admin", "s3cr3t"}, {"read", ""}} {
+		_, _, err = GetAuthorizeUrlWithState(client, tok, args[0], args[1])
+		ee, ok := err.(*flickErr.Error)
+		Expect(t, ok, true)
+		Expect(t, ee.ErrorCode, flickErr.ArgumentError)
+	}
+}
+
 func TestParseOAuthToken(t *testing.T) {
 	response := "fullname=Jamal%20Fanaian" +
 		"&oauth_token=72157626318069415-087bfc7b5816092c" +
@@ -118,3 +153,147 @@ func TestGetAccessToken(t *testing.T) {
 	Expect(t, fclient.OAuthToken, "72157626318069415-087bfc7b5816092c")
 	Expect(t, fclient.OAuthTokenSecret, "a202d1f853ec69de")
 }
+
+func TestParseCallback(t *testing.T) {
+	cb, err := ParseCallback("https://example.com/callback?oauth_token=72157654304937659-8eedcda57d9d57e3&oauth_verifier=5d1b96a26b494074")
+	Expect(t, err, nil)
+	Expect(t, *cb, Callback{"72157654304937659-8eedcda57d9d57e3", "5d1b96a26b494074", ""})
+
+	cb, err = ParseCallback("https://example.com/callback?oauth_problem=permission_denied")
+	ee, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ee.ErrorCode, flickErr.CallbackError)
+	Expect(t, cb.OAuthProblem, "permission_denied")
+	Expect(t, cb.OAuthToken, "")
+
+	_, err = ParseCallback("https://example.com/callback?notA%%%ValidQuery")
+	if err == nil {
+		t.Error("Parsing an invalid query string should rise an error")
+	}
+}
+
+func TestWhoAmI(t *testing.T) {
+	fclient := GetTestClient()
+	fclient.OAuthToken = "token"
+	server, client := FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <user id="23148015@N00">
+    <username>Massimiliano Pippi</username>
+  </user>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	tok, err := fclient.WhoAmI()
+	Expect(t, err, nil)
+	Expect(t, fclient.Args.Get("method"), "flickr.test.login")
+	Expect(t, tok.OAuthToken, "token")
+	Expect(t, tok.UserNsid, "23148015@N00")
+	Expect(t, tok.Username, "Massimiliano Pippi")
+}
+
+func TestWhoAmIKo(t *testing.T) {
+	fclient := GetTestClient()
+	server, client := FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="fail"><err code="98" msg="Invalid auth token" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	tok, err := fclient.WhoAmI()
+	Expect(t, tok == nil, true)
+	ee, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ee.ErrorCode, flickErr.InvalidTokenError)
+
+	server, client = FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="fail"><err code="99" msg="Insufficient permissions" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err = fclient.WhoAmI()
+	ee, ok = err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ee.ErrorCode, flickErr.ApiError)
+}
+
+func TestInteractiveAuth(t *testing.T) {
+	var verifier string
+	server, fclient := FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/services/oauth/request_token":
+			Expect(t, r.URL.Query().Get("oauth_callback"), "oob")
+			fmt.Fprint(w, "oauth_callback_confirmed=true&oauth_token=reqtoken&oauth_token_secret=reqsecret")
+		case "/services/oauth/access_token":
+			verifier = r.URL.Query().Get("oauth_verifier")
+			Expect(t, r.URL.Query().Get("oauth_token"), "reqtoken")
+			fmt.Fprint(w, "fullname=Jamal%20Fanaian&oauth_token=acctoken&oauth_token_secret=accsecret&user_nsid=21207597%40N07&username=jamalfanaian")
+		default:
+			t.Error("unexpected request to", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	var prompted string
+	tok, err := InteractiveAuth(fclient, "write", func(authURL string) (string, error) {
+		prompted = authURL
+		return " 123-456-789\n", nil
+	})
+	Expect(t, err, nil)
+	Expect(t, prompted, "https://www.flickr.com/services/oauth/authorize?oauth_token=reqtoken&perms=write")
+	Expect(t, verifier, "123-456-789")
+	Expect(t, tok.OAuthToken, "acctoken")
+	Expect(t, fclient.OAuthToken, "acctoken")
+	Expect(t, fclient.OAuthTokenSecret, "accsecret")
+	Expect(t, fclient.Id, "21207597@N07")
+}
+
+func TestInteractiveAuthKo(t *testing.T) {
+	_, err := InteractiveAuth(GetTestClient(), "admin", nil)
+	ee, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ee.ErrorCode, flickErr.ArgumentError)
+
+	server, client := FlickrMock(200, "oauth_callback_confirmed=true&oauth_token=reqtoken&oauth_token_secret=reqsecret", "")
+	defer server.Close()
+	fclient := GetTestClient()
+	fclient.HTTPClient = client
+	promptErr := errors.New("no verifier")
+	_, err = InteractiveAuth(fclient, "read", func(string) (string, error) { return "", promptErr })
+	Expect(t, err, promptErr)
+}
+
+func TestTokenFlowArgs(t *testing.T) {
+	var queries []url.Values
+	server, fclient := FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		if strings.HasSuffix(r.URL.Path, "request_token") {
+			fmt.Fprint(w, "oauth_callback_confirmed=true&oauth_token=token&oauth_token_secret=secret")
+			return
+		}
+		fmt.Fprint(w, "oauth_token=access&oauth_token_secret=accesssecret&user_nsid=21207597%40N07")
+	}))
+	defer server.Close()
+	fclient.Args.Set("method", "flickr.photos.getInfo")
+
+	tok, err := GetRequestToken(fclient)
+	Expect(t, err, nil)
+	_, err = GetAccessToken(fclient, tok, "verifier")
+	Expect(t, err, nil)
+
+	Expect(t, len(queries), 2)
+	Expect(t, queries[0].Get("oauth_callback"), "oob")
+	_, found := queries[0]["method"]
+	Expect(t, found, false)
+	_, found = queries[1]["oauth_callback"]
+	Expect(t, found, false)
+	Expect(t, queries[1].Get("oauth_verifier"), "verifier")
+
+	// API calls drop the args of the token steps
+	fclient.Args.Set("oauth_callback", "oob")
+	fclient.Args.Set("method", "flickr.test.login")
+	fclient.OAuthSign()
+	_, found = fclient.Args["oauth_callback"]
+	Expect(t, found, false)
+	_, found = fclient.Args["oauth_verifier"]
+	Expect(t, found, false)
+}
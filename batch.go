@@ -0,0 +1,108 @@
+package flickr
+
+import (
+	"net/url"
+	"sync"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// Number of calls performed concurrently by Batch
+const batchWorkers = 4
+
+// A single API method call to be performed by Batch
+type Call struct {
+	// API method name, dotted notation (e.g. flickr.photos.getInfo)
+	Method string
+	// Method arguments, "method" and signing args are added by Batch
+	Args url.Values
+	// Whether the call must be OAuth signed or just api signed
+	Authenticate bool
+	// Response to unmarshal results into, a BasicResponse is used if nil
+	Response FlickrResponse
+}
+
+// Outcome of a Call performed by Batch
+type Result struct {
+	Call     Call
+	Response FlickrResponse
+	Err      error
+}
+
+// Return a copy of the client sharing credentials, settings, HTTP client
+// and cache but holding its own Args, so that it can be used concurrently.
+func (c *FlickrClient) copy() *FlickrClient {
+	ret := *c
+	ret.Args = url.Values{}
+	ret.signedWith = notSigned
+	return &ret
+}
+
+// Perform several GET calls concurrently and return their results in the same order.
+// Flickr has no multi-call endpoint, so each call is a separate request; at most
+// batchWorkers requests are in flight at the same time.
+// The rate limit is checked before dispatching each call: once the quota is exhausted
+// the calls left are not sent and get a *RateLimitError in their Result.
+// The returned error is only set when calls are invalid, in which case nothing is sent:
+// errors of single calls are reported in the Err field of their Result.
+func Batch(client *FlickrClient, calls []Call) ([]Result, error) {
+	for _, call := range calls {
+		if call.Method == "" {
+			return nil, flickErr.NewError(flickErr.ArgumentError, "batch call without method")
+		}
+	}
+
+	results := make([]Result, len(calls))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	for i := 0; i < batchWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indexes {
+				results[idx] = doCall(client.copy(), calls[idx])
+			}
+		}()
+	}
+
+	for idx := range calls {
+		if err := client.CheckRateLimit(1); err != nil {
+			for ; idx < len(calls); idx++ {
+				response := calls[idx].Response
+				if response == nil {
+					response = &BasicResponse{}
+				}
+				results[idx] = Result{Call: calls[idx], Response: response, Err: err}
+			}
+			break
+		}
+		indexes <- idx
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results, nil
+}
+
+// Perform a single Call with the given client
+func doCall(client *FlickrClient, call Call) Result {
+	client.Init()
+	for k, v := range call.Args {
+		client.Args[k] = v
+	}
+	client.Args.Set("method", call.Method)
+	if call.Authenticate {
+		client.OAuthSign()
+	} else {
+		client.ApiSign()
+	}
+
+	response := call.Response
+	if response == nil {
+		response = &BasicResponse{}
+	}
+	err := DoGet(client, response)
+
+	return Result{Call: call, Response: response, Err: err}
+}
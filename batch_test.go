@@ -0,0 +1,87 @@
+package flickr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestBatch(t *testing.T) {
+	server, fclient := FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("photo_id")
+		if id == "bad" {
+			fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"><err code="1" msg="Photo not found" /></rsp>`)
+			return
+		}
+		// echo back photo id and whether the call was OAuth signed
+		signed := r.URL.Query().Get("oauth_signature") != ""
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><foo>%s-%v</foo></rsp>`, id, signed)
+	}))
+	defer server.Close()
+
+	calls := []Call{}
+	for i := 0; i < 10; i++ {
+		calls = append(calls, Call{
+			Method:       "flickr.photos.getInfo",
+			Args:         url.Values{"photo_id": {fmt.Sprintf("%d", i)}},
+			Authenticate: i%2 == 0,
+			Response:     &FooResponse{},
+		})
+	}
+	calls = append(calls, Call{Method: "flickr.photos.getInfo", Args: url.Values{"photo_id": {"bad"}}})
+
+	results, err := Batch(fclient, calls)
+	Expect(t, err, nil)
+	Expect(t, len(results), 11)
+	for i := 0; i < 10; i++ {
+		Expect(t, results[i].Err, nil)
+		Expect(t, results[i].Call.Args.Get("photo_id"), fmt.Sprintf("%d", i))
+		Expect(t, results[i].Response.(*FooResponse).Foo, fmt.Sprintf("%d-%v", i, i%2 == 0))
+	}
+
+	last := results[10]
+	_, ok := last.Err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, last.Response.ErrorCode(), 1)
+
+	// the client passed to Batch is left untouched
+	Expect(t, len(fclient.Args), 0)
+}
+
+func TestBatchInvalid(t *testing.T) {
+	results, err := Batch(GetTestClient(), []Call{{Method: "flickr.test.echo"}, {}})
+	Expect(t, results == nil, true)
+	ferr, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+}
+
+func TestBatchRateLimit(t *testing.T) {
+	hits := 0
+	server, fclient := FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`)
+	}))
+	defer server.Close()
+	fclient.rateLimit.record(http.Header{
+		"X-Ratelimit-Limit":     {"3600"},
+		"X-Ratelimit-Remaining": {"0"},
+		"X-Ratelimit-Reset":     {strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)},
+	})
+
+	results, err := Batch(fclient, []Call{{Method: "flickr.test.echo"}, {Method: "flickr.test.login"}})
+	Expect(t, err, nil)
+	Expect(t, len(results), 2)
+	for i, res := range results {
+		Expect(t, errors.Is(res.Err, ErrRateLimited), true)
+		Expect(t, res.Response.ErrorCode(), 0)
+		Expect(t, res.Call.Method, []string{"flickr.test.echo", "flickr.test.login"}[i])
+	}
+	Expect(t, hits, 0)
+}
@@ -0,0 +1,56 @@
+// Package implementing methods: flickr.blogs.*
+package blogs
+
+import (
+	"gopkg.in/masci/flickr.v2"
+)
+
+type Blog struct {
+	Id            string `xml:"id,attr"`
+	Name          string `xml:"name,attr"`
+	NeedsPassword bool   `xml:"needspassword,attr"`
+	Url           string `xml:"url,attr"`
+	Service       string `xml:"service,attr"`
+}
+
+type BlogsResponse struct {
+	flickr.BasicResponse
+	Blogs []Blog `xml:"blogs>blog"`
+}
+
+// Get a list of configured blogs for the calling user.
+// service optionally restricts the list to blogs on a given service, pass "" to get them all.
+// This method requires authentication with 'read' permission.
+func GetList(client *flickr.FlickrClient, service string) (*BlogsResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.blogs.getList")
+	if service != "" {
+		client.Args.Set("service", service)
+	}
+	client.OAuthSign()
+
+	response := &BlogsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Post a photo to a blog. blogPassword is only needed by blogs with NeedsPassword set,
+// pass "" otherwise.
+// This method requires authentication with 'write' permission.
+func PostPhoto(client *flickr.FlickrClient, blogId, photoId, title, description, blogPassword string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.blogs.postPhoto")
+	client.Args.Set("blog_id", blogId)
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("title", title)
+	client.Args.Set("description", description)
+	if blogPassword != "" {
+		client.Args.Set("blog_password", blogPassword)
+	}
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
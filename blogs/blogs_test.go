@@ -0,0 +1,61 @@
+package blogs
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestGetList(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <blogs>
+    <blog id="73" name="Bloxus test" needspassword="0" url="http://remote.bloxus.com/" service="beta.blogger.com" />
+    <blog id="74" name="Manila Test" needspassword="1" url="http://flickrtest1.userland.com/" />
+  </blogs>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetList(fclient, "")
+	flickr.Expect(t, err, nil)
+	_, found := fclient.Args["service"]
+	flickr.Expect(t, found, false)
+	flickr.Expect(t, len(resp.Blogs), 2)
+	flickr.Expect(t, resp.Blogs[0].Id, "73")
+	flickr.Expect(t, resp.Blogs[0].Name, "Bloxus test")
+	flickr.Expect(t, resp.Blogs[0].NeedsPassword, false)
+	flickr.Expect(t, resp.Blogs[0].Service, "beta.blogger.com")
+	flickr.Expect(t, resp.Blogs[1].NeedsPassword, true)
+	flickr.Expect(t, resp.Blogs[1].Url, "http://flickrtest1.userland.com/")
+}
+
+func TestPostPhoto(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := PostPhoto(fclient, "73", "123456", "A title", "A description", "")
+	flickr.Expect(t, err, nil)
+	_, found := fclient.Args["blog_password"]
+	flickr.Expect(t, found, false)
+
+	_, err = PostPhoto(fclient, "74", "123456", "A title", "A description", "s3cr3t")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("blog_password"), "s3cr3t")
+}
+
+func TestPostPhotoKo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"><err code="3" msg="Password needed" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := PostPhoto(fclient, "74", "123456", "A title", "A description", "")
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.ErrorCode(), 3)
+}
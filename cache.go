@@ -0,0 +1,168 @@
+package flickr
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Args changing at every request, they must not be part of cache keys
+var volatileArgs = []string{"oauth_nonce", "oauth_timestamp", "oauth_signature", "api_sig"}
+
+type cacheEntry struct {
+	body    []byte
+	expires time.Time
+	// validators sent by Flickr along with the body, if any
+	etag         string
+	lastModified string
+}
+
+// Tell whether the entry can be revalidated with a conditional request
+func (e *cacheEntry) hasValidators() bool {
+	return e.etag != "" || e.lastModified != ""
+}
+
+// Make req a conditional request, answered with 304 Not Modified if the body
+// stored in the entry is still up to date
+func (e *cacheEntry) setConditionalHeaders(req *http.Request) {
+	if e.etag != "" {
+		req.Header.Set("If-None-Match", e.etag)
+	}
+	if e.lastModified != "" {
+		req.Header.Set("If-Modified-Since", e.lastModified)
+	}
+}
+
+// Maximum number of responses kept by the cache, the ones expiring first are evicted
+// to make room for new ones
+const maxCacheEntries = 1000
+
+// An in-memory store for response bodies, safe for concurrent use
+type responseCache struct {
+	sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		entries: map[string]cacheEntry{},
+	}
+}
+
+// Return the body stored for key, if any and not expired. Expired entries are
+// removed, unless they can be revalidated.
+func (c *responseCache) get(key string) ([]byte, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	entry, found := c.entries[key]
+	if !found {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		if !entry.hasValidators() {
+			delete(c.entries, key)
+		}
+		return nil, false
+	}
+	return entry.body, true
+}
+
+// Return the expired entry stored for key, if it can be revalidated
+func (c *responseCache) stale(key string) (*cacheEntry, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	entry, found := c.entries[key]
+	if !found || !entry.hasValidators() {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// Store body for key, along with the ETag and Last-Modified values found in header
+func (c *responseCache) set(key string, body []byte, header http.Header) {
+	c.Lock()
+	defer c.Unlock()
+
+	if _, found := c.entries[key]; !found && len(c.entries) >= maxCacheEntries {
+		c.evict()
+	}
+	c.entries[key] = cacheEntry{
+		body:         body,
+		expires:      time.Now().Add(c.ttl),
+		etag:         header.Get("ETag"),
+		lastModified: header.Get("Last-Modified"),
+	}
+}
+
+// Store again entry for key, for another ttl
+func (c *responseCache) refresh(key string, entry *cacheEntry) {
+	c.Lock()
+	defer c.Unlock()
+
+	entry.expires = time.Now().Add(c.ttl)
+	c.entries[key] = *entry
+}
+
+// Make room for a new entry: remove the expired entries, revalidable ones included,
+// or the one expiring first if none is expired. The cache must be locked.
+func (c *responseCache) evict() {
+	now := time.Now()
+	oldest := ""
+	for key, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, key)
+			continue
+		}
+		if oldest == "" || entry.expires.Before(c.entries[oldest].expires) {
+			oldest = key
+		}
+	}
+	if len(c.entries) >= maxCacheEntries {
+		delete(c.entries, oldest)
+	}
+}
+
+func (c *responseCache) clear() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.entries = map[string]cacheEntry{}
+}
+
+// Enable caching of successful GET responses for the given amount of time,
+// pass a ttl <= 0 to disable it. Writes (POST requests) are never cached.
+// When Flickr sends an ETag or Last-Modified header along with a response, expired
+// entries are revalidated with a conditional request and served again on 304 Not Modified.
+// At most 1000 responses are kept, the ones expiring first make room for new ones.
+func (c *FlickrClient) SetCache(ttl time.Duration) {
+	if ttl <= 0 {
+		c.cache = nil
+		return
+	}
+	c.cache = newResponseCache(ttl)
+}
+
+// Remove all the responses stored in the cache
+func (c *FlickrClient) ClearCache() {
+	if c.cache != nil {
+		c.cache.clear()
+	}
+}
+
+// Compute the cache key for the current request: endpoint plus args, leaving out
+// nonce, timestamp and signatures so that signing again doesn't change the key.
+func (c *FlickrClient) cacheKey() string {
+	args := url.Values{}
+	for k, v := range c.Args {
+		args[k] = v
+	}
+	for _, k := range volatileArgs {
+		args.Del(k)
+	}
+	return c.EndpointUrl + "?" + args.Encode()
+}
@@ -0,0 +1,182 @@
+package flickr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	hits := 0
+	body := `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><foo>Foo!</foo></rsp>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	fclient := GetTestClient()
+	fclient.EndpointUrl = server.URL
+	fclient.Args.Set("method", "flickr.test.echo")
+	fclient.OAuthSign()
+
+	// no cache by default
+	DoGet(fclient, &FooResponse{})
+	DoGet(fclient, &FooResponse{})
+	Expect(t, hits, 2)
+
+	fclient.SetCache(time.Minute)
+	hits = 0
+	resp := &FooResponse{}
+	Expect(t, DoGet(fclient, resp), nil)
+	Expect(t, resp.Foo, "Foo!")
+	// nonce and timestamp change, the cached response must be used anyway
+	resp = &FooResponse{}
+	Expect(t, DoGet(fclient, resp), nil)
+	Expect(t, resp.Foo, "Foo!")
+	Expect(t, hits, 1)
+
+	// different args, different key
+	fclient.Args.Set("foo", "bar")
+	DoGet(fclient, &FooResponse{})
+	Expect(t, hits, 2)
+
+	// writes are never cached
+	DoPost(fclient, &FooResponse{})
+	DoPost(fclient, &FooResponse{})
+	Expect(t, hits, 4)
+
+	fclient.ClearCache()
+	DoGet(fclient, &FooResponse{})
+	Expect(t, hits, 5)
+
+	fclient.SetCache(0)
+	DoGet(fclient, &FooResponse{})
+	Expect(t, hits, 6)
+}
+
+func TestCacheFailures(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"><err code="1" msg="ko" /></rsp>`))
+	}))
+	defer server.Close()
+
+	fclient := GetTestClient()
+	fclient.EndpointUrl = server.URL
+	fclient.SetCache(time.Minute)
+	DoGet(fclient, &FooResponse{})
+	err := DoGet(fclient, &FooResponse{})
+	Expect(t, err != nil, true)
+	Expect(t, hits, 2)
+}
+
+func TestCacheExpiration(t *testing.T) {
+	c := newResponseCache(time.Millisecond)
+	c.set("key", []byte("body"), http.Header{})
+	body, found := c.get("key")
+	Expect(t, found, true)
+	Expect(t, string(body), "body")
+
+	time.Sleep(5 * time.Millisecond)
+	_, found = c.get("key")
+	Expect(t, found, false)
+	Expect(t, len(c.entries), 0)
+}
+
+func TestCacheConditionalRequests(t *testing.T) {
+	hits, notModified := 0, 0
+	var ifNoneMatch, ifModifiedSince string
+	body := `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><foo>Foo!</foo></rsp>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		ifNoneMatch = r.Header.Get("If-None-Match")
+		ifModifiedSince = r.Header.Get("If-Modified-Since")
+		if ifNoneMatch == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Wed, 14 Oct 2026 10:00:00 GMT")
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	fclient := GetTestClient()
+	fclient.EndpointUrl = server.URL
+	fclient.Args.Set("method", "flickr.test.echo")
+	fclient.OAuthSign()
+	fclient.SetCache(10 * time.Millisecond)
+
+	Expect(t, DoGet(fclient, &FooResponse{}), nil)
+	Expect(t, ifNoneMatch, "")
+	Expect(t, hits, 1)
+
+	time.Sleep(20 * time.Millisecond)
+	resp := &FooResponse{}
+	Expect(t, DoGet(fclient, resp), nil)
+	Expect(t, resp.Foo, "Foo!")
+	Expect(t, hits, 2)
+	Expect(t, notModified, 1)
+	Expect(t, ifModifiedSince, "Wed, 14 Oct 2026 10:00:00 GMT")
+
+	// the 304 made the entry fresh again
+	Expect(t, DoGet(fclient, &FooResponse{}), nil)
+	Expect(t, hits, 2)
+}
+
+func TestCacheNoValidators(t *testing.T) {
+	hits := 0
+	var conditional bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		conditional = r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != ""
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><foo>Foo!</foo></rsp>`))
+	}))
+	defer server.Close()
+
+	fclient := GetTestClient()
+	fclient.EndpointUrl = server.URL
+	fclient.Args.Set("method", "flickr.test.echo")
+	fclient.OAuthSign()
+	fclient.SetCache(10 * time.Millisecond)
+
+	Expect(t, DoGet(fclient, &FooResponse{}), nil)
+	time.Sleep(20 * time.Millisecond)
+	resp := &FooResponse{}
+	Expect(t, DoGet(fclient, resp), nil)
+	Expect(t, resp.Foo, "Foo!")
+	Expect(t, hits, 2)
+	Expect(t, conditional, false)
+}
+
+func TestCacheEviction(t *testing.T) {
+	cache := newResponseCache(time.Minute)
+	for i := 0; i < maxCacheEntries; i++ {
+		cache.set(fmt.Sprintf("key%d", i), []byte("body"), http.Header{"Etag": {"v1"}})
+	}
+	Expect(t, len(cache.entries), maxCacheEntries)
+	first := cache.entries["key0"]
+	first.expires = first.expires.Add(-time.Second)
+	cache.entries["key0"] = first
+
+	// the entry expiring first makes room
+	cache.set("new", []byte("body"), http.Header{})
+	Expect(t, len(cache.entries), maxCacheEntries)
+	_, found := cache.get("key0")
+	Expect(t, found, false)
+	_, found = cache.get("new")
+	Expect(t, found, true)
+
+	// expired entries are all removed, even if they could be revalidated
+	for key, entry := range cache.entries {
+		entry.expires = time.Now().Add(-time.Second)
+		cache.entries[key] = entry
+	}
+	cache.set("newer", []byte("body"), http.Header{})
+	Expect(t, len(cache.entries), 1)
+}
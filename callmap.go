@@ -0,0 +1,107 @@
+package flickr
+
+import (
+	"encoding/xml"
+	"net/url"
+	"strconv"
+	"strings"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// Key holding the text content of elements in trees returned by CallMap, the same
+// Flickr uses in its JSON format
+const ContentKey = "_content"
+
+// Response decoded into a generic tree, see CallMap
+type mapResponse struct {
+	BasicResponse
+	tree map[string]interface{}
+}
+
+func (r *mapResponse) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	tree, err := decodeTree(d, start)
+	if err != nil {
+		return err
+	}
+
+	r.tree = tree
+	r.Status, _ = tree["stat"].(string)
+	if e, ok := tree["err"].(map[string]interface{}); ok {
+		code, _ := e["code"].(string)
+		r.Error.Code, _ = strconv.Atoi(code)
+		r.Error.Message, _ = e["msg"].(string)
+	}
+	return nil
+}
+
+// Decode the element opened by start into a map holding its attributes, its children
+// by name (a slice when the name is repeated) and its text under ContentKey
+func decodeTree(d *xml.Decoder, start xml.StartElement) (map[string]interface{}, error) {
+	node := map[string]interface{}{}
+	for _, attr := range start.Attr {
+		node[attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			child, err := decodeTree(d, t)
+			if err != nil {
+				return nil, err
+			}
+			name := t.Name.Local
+			switch prev := node[name].(type) {
+			case nil:
+				node[name] = child
+			case []interface{}:
+				node[name] = append(prev, child)
+			default:
+				node[name] = []interface{}{prev, child}
+			}
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			if s := strings.TrimSpace(text.String()); s != "" {
+				node[ContentKey] = s
+			}
+			return node, nil
+		}
+	}
+}
+
+// Call any API method, typed wrapper or not, and return the rsp element decoded into
+// a generic tree: attributes and child elements are map keys, repeated elements are
+// []interface{} and text content is under ContentKey. The shape of the tree depends
+// on the method and is not stable: Flickr may add attributes and elements anytime.
+// The request is signed with OAuthSign if the client holds an access token, with
+// ApiSign otherwise; Flickr errors are returned as for typed wrappers.
+// Client Args are left untouched.
+func (c *FlickrClient) CallMap(method string, args url.Values) (map[string]interface{}, error) {
+	if method == "" {
+		return nil, flickErr.NewError(flickErr.ArgumentError, "no method to call")
+	}
+
+	req := c.copy()
+	req.Init()
+	for k, v := range args {
+		req.Args[k] = append([]string(nil), v...)
+	}
+	req.Args.Set("method", method)
+	if c.OAuthToken != "" {
+		req.OAuthSign()
+	} else {
+		req.ApiSign()
+	}
+
+	response := &mapResponse{}
+	if err := DoGet(req, response); err != nil {
+		return nil, err
+	}
+	return response.tree, nil
+}
@@ -0,0 +1,71 @@
+package flickr
+
+import (
+	"net/url"
+	"testing"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestCallMap(t *testing.T) {
+	fclient := GetTestClient()
+	server, client := FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <person nsid="12037949754@N01" ispro="0">
+    <username>bees</username>
+    <photos>
+      <count>449</count>
+    </photos>
+  </person>
+  <tag>a</tag>
+  <tag>b</tag>
+  <tag>c</tag>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	fclient.Args.Set("foo", "bar")
+
+	tree, err := fclient.CallMap("flickr.people.getInfo", url.Values{"user_id": {"12037949754@N01"}})
+	Expect(t, err, nil)
+	Expect(t, tree["stat"], "ok")
+	person := tree["person"].(map[string]interface{})
+	Expect(t, person["nsid"], "12037949754@N01")
+	Expect(t, person["username"].(map[string]interface{})[ContentKey], "bees")
+	photos := person["photos"].(map[string]interface{})
+	Expect(t, photos["count"].(map[string]interface{})[ContentKey], "449")
+	_, found := person[ContentKey]
+	Expect(t, found, false)
+	tags := tree["tag"].([]interface{})
+	Expect(t, len(tags), 3)
+	Expect(t, tags[2].(map[string]interface{})[ContentKey], "c")
+	// client Args are left untouched
+	Expect(t, fclient.Args.Get("foo"), "bar")
+}
+
+func TestCallMapKo(t *testing.T) {
+	fclient := GetTestClient()
+	server, client := FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="fail"><err code="1" msg="User not found" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	tree, err := fclient.CallMap("flickr.people.getInfo", nil)
+	Expect(t, tree == nil, true)
+	ferr, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.ErrorCode, flickErr.ApiError)
+	Expect(t, ferr.Message, "Flickr API returned an error: User not found")
+
+	server, client = FlickrMock(200, "oauth_problem=signature_invalid", "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	_, err = fclient.CallMap("flickr.people.getInfo", nil)
+	ferr, ok = err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.ErrorCode, flickErr.ApiError)
+
+	_, err = fclient.CallMap("", nil)
+	ferr, ok = err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+}
@@ -0,0 +1,64 @@
+// Package implementing methods: flickr.cameras.*
+package cameras
+
+import (
+	"gopkg.in/masci/flickr.v2"
+)
+
+type Brand struct {
+	Id   string `xml:"id,attr"`
+	Name string `xml:"name,attr"`
+}
+
+type BrandsResponse struct {
+	flickr.BasicResponse
+	Brands []Brand `xml:"brands>brand"`
+}
+
+type Camera struct {
+	Id      string `xml:"id,attr"`
+	Name    string `xml:"name"`
+	Details struct {
+		Megapixels    string `xml:"megapixels"`
+		LcdScreenSize string `xml:"lcd_screen_size"`
+		MemoryType    string `xml:"memory_type"`
+	} `xml:"details"`
+	// Urls of the camera pictures, empty when not available
+	Images struct {
+		Small string `xml:"small"`
+		Large string `xml:"large"`
+	} `xml:"images"`
+}
+
+type ModelsResponse struct {
+	flickr.BasicResponse
+	Cameras struct {
+		Brand   string   `xml:"brand,attr"`
+		Cameras []Camera `xml:"camera"`
+	} `xml:"cameras"`
+}
+
+// Returns all the brands of cameras that Flickr knows about.
+// This method does not require authentication.
+func GetBrands(client *flickr.FlickrClient) (*BrandsResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.cameras.getBrands")
+	client.ApiSign()
+
+	response := &BrandsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Retrieve all the models for a given camera brand.
+// This method does not require authentication.
+func GetBrandModels(client *flickr.FlickrClient, brandId string) (*ModelsResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.cameras.getBrandModels")
+	client.Args.Set("brand", brandId)
+	client.ApiSign()
+
+	response := &ModelsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
@@ -0,0 +1,84 @@
+package cameras
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestGetBrands(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <brands>
+    <brand id="canon" name="Canon" />
+    <brand id="nikon" name="Nikon" />
+    <brand id="apple" name="Apple" />
+  </brands>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetBrands(fclient)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(resp.Brands), 3)
+	flickr.Expect(t, resp.Brands[1].Id, "nikon")
+	flickr.Expect(t, resp.Brands[1].Name, "Nikon")
+}
+
+func TestGetBrandModels(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <cameras brand="apple">
+    <camera id="iphone_9000">
+      <name>iPhone 9000</name>
+      <details>
+        <megapixels>22.0</megapixels>
+        <lcd_screen_size>40.5</lcd_screen_size>
+        <memory_type>Flash</memory_type>
+      </details>
+      <images>
+        <small>http://farm3.staticflickr.com/1234/cameras/123456_model_small_123456.jpg</small>
+        <large>http://farm3.staticflickr.com/1234/cameras/123456_model_large_123456.jpg</large>
+      </images>
+    </camera>
+    <camera id="iphone_8000">
+      <name>iPhone 8000</name>
+    </camera>
+  </cameras>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetBrandModels(fclient, "apple")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("brand"), "apple")
+	flickr.Expect(t, resp.Cameras.Brand, "apple")
+	flickr.Expect(t, len(resp.Cameras.Cameras), 2)
+
+	cam := resp.Cameras.Cameras[0]
+	flickr.Expect(t, cam.Id, "iphone_9000")
+	flickr.Expect(t, cam.Name, "iPhone 9000")
+	flickr.Expect(t, cam.Details.Megapixels, "22.0")
+	flickr.Expect(t, cam.Details.MemoryType, "Flash")
+	flickr.Expect(t, cam.Images.Small, "http://farm3.staticflickr.com/1234/cameras/123456_model_small_123456.jpg")
+	flickr.Expect(t, cam.Images.Large, "http://farm3.staticflickr.com/1234/cameras/123456_model_large_123456.jpg")
+	flickr.Expect(t, resp.Cameras.Cameras[1].Images.Small, "")
+}
+
+func TestGetBrandModelsKo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="fail">
+  <err code="1" msg="Brand not found" />
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetBrandModels(fclient, "foo")
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.ErrorCode(), 1)
+}
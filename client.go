@@ -2,10 +2,6 @@ package flickr
 
 import (
 	"bytes"
-	"crypto/hmac"
-	"crypto/md5"
-	"crypto/sha1"
-	"encoding/base64"
 	"fmt"
 	"math/rand"
 	"net/http"
@@ -13,6 +9,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
 )
 
 // Generate a random string of 8 chars, needed for OAuth signature
@@ -27,14 +25,31 @@ func generateNonce() string {
 	return string(b)
 }
 
+// How the request held by a FlickrClient was signed
+type signMethod int
+
+const (
+	notSigned signMethod = iota
+	oauthSigned
+	apiSigned
+)
+
 // An utility type to wrap all resources and data needed to complete requests
 // to the Flickr API
 type FlickrClient struct {
 	// Flickr application api key
 	ApiKey string
-	// Flickr application api secret
+	// Flickr application api secret, not needed when Signer is set
 	ApiSecret string
-	// A generic HTTP client to perform GET and POST requests
+	// Compute the signatures of requests, SecretSigner keyed with ApiSecret if nil
+	Signer Signer
+	// OAuth signature method, SIGNATURE_HMAC_SHA1 if empty. SIGNATURE_PLAINTEXT sends
+	// the secrets in clear and bypasses Signer: only use it over TLS, e.g. against a
+	// local mock in tests.
+	SignatureMethod string
+	// A generic HTTP client to perform GET and POST requests, set its Transport
+	// (or use WithTransport) to hook into every request, uploads included. Its
+	// CheckRedirect is ignored by DoGet and DoPost, which handle redirects themselves.
 	HTTPClient *http.Client
 	// The base url for API endpoints
 	EndpointUrl string
@@ -48,17 +63,85 @@ type FlickrClient struct {
 	OAuthTokenSecret string
 	// User flickr ID
 	Id string
+	// Ask Flickr for gzip compressed responses
+	Compress bool
+	// User-Agent header sent along with requests, DEFAULT_USER_AGENT if empty
+	UserAgent string
+	// DoGet and DoPost refresh OAuth defaults and sign again requests that were
+	// signed with OAuthSign or ApiSign, set to true to send Args untouched.
+	ManualSign bool
+	// Send read methods performed with DoGet as POST requests, with Args in the
+	// request body. Useful when Args exceed URL length limits.
+	ForcePost bool
+	// Check, before sending requests, that Args contain all the arguments required
+	// by the method. Arguments lists are fetched once from flickr.reflection.getMethodInfo,
+	// see Lazy.
+	Validate bool
+	// Source of the time for OAuth timestamps, time.Now if nil
+	TimeFunc func() time.Time
+	// When a request is refused because of its signature and Flickr clock is far
+	// from the local one, shift the timestamps of next requests to match Flickr clock
+	AutoCorrectClock bool
+	// Build and sign requests but don't send them: a *DryRunError holding the request
+	// is returned instead. Cached responses are still served. The body of upload
+	// requests is streamed from a goroutine, read or close it.
+	DryRun bool
+	// Maximum size in bytes of API responses, DEFAULT_MAX_RESPONSE_BYTES if zero,
+	// no limit if negative. Uploads are not affected.
+	MaxResponseBytes int64
+	// Replace bytes that are not valid UTF-8 or not allowed in XML with U+FFFD before
+	// unmarshalling responses, instead of failing the whole call because of a stray
+	// byte in a title or description
+	LenientDecode bool
+	// Number of times DoGet and DoPost send a request again when the network fails
+	// or Flickr replies with a 5xx or 429 status. Each retry is signed again, unless
+	// ManualSign is set. Writes are not idempotent, so DoPost retries only requests
+	// that got no response at all: one that failed in flight may still have been
+	// applied by Flickr. Uploads are never retried.
+	MaxRetries int
+	// Wait before the given retry attempt, starting from 1; exponential backoff with
+	// full jitter if nil, see JitterBackoff. A longer Retry-After header of 503 and
+	// 429 responses takes precedence.
+	RetryBackoff func(attempt int) time.Duration
+	// Called with the method and a description of each anomaly found in responses
+	// that don't make the call fail: unknown stat values, pages beyond the last one,
+	// elements expected by the response type and missing. Cached responses are not
+	// checked again.
+	OnWarning func(method string, warning string)
+	// Observe the outcome and duration of every call, nil to disable
+	Metrics Metrics
+	// the signing process used for the current request
+	signedWith signMethod
+	// cache for GET responses, nil if disabled
+	cache *responseCache
+	// latest rate limit values sent by Flickr
+	rateLimit *rateLimitState
+	// values stored by Lazy, shared by copies of the client
+	lazy *lazyValues
+	// clock skew seen in responses and correction, shared by copies of the client
+	clock *clockState
+	// requests sent by the call observed for Metrics, nil if none
+	callStats *callStats
 }
 
-// Create a Flickr client, apiKey and apiSecret are mandatory
-func NewFlickrClient(apiKey string, apiSecret string) *FlickrClient {
-	return &FlickrClient{
+// Create a Flickr client, apiKey and apiSecret are mandatory.
+// Options are applied in the given order. Unless WithHTTPClient or WithTransport is
+// given, requests go through a transport shared by the clients, see NewTransport.
+func NewFlickrClient(apiKey string, apiSecret string, opts ...ClientOption) *FlickrClient {
+	client := &FlickrClient{
 		ApiKey:     apiKey,
 		ApiSecret:  apiSecret,
-		HTTPClient: &http.Client{},
+		HTTPClient: &http.Client{Transport: sharedTransport()},
 		HTTPVerb:   "GET",
 		Args:       url.Values{},
+		rateLimit:  &rateLimitState{},
+		lazy:       newLazyValues(),
+		clock:      &clockState{},
+	}
+	for _, opt := range opts {
+		opt(client)
 	}
+	return client
 }
 
 // Sign the next request performed by the FlickrClient
@@ -70,21 +153,30 @@ func (c *FlickrClient) Sign(tokenSecret string) {
 
 // Set the mandatory params for an OAuth request
 func (c *FlickrClient) SetOAuthDefaults() {
-	c.Args.Add("oauth_version", "1.0")
-	c.Args.Add("oauth_signature_method", "HMAC-SHA1")
-	c.Args.Add("oauth_nonce", generateNonce())
-	c.Args.Add("oauth_timestamp", fmt.Sprintf("%d", time.Now().Unix()))
+	c.Args.Set("oauth_version", "1.0")
+	c.Args.Set("oauth_signature_method", c.signatureMethod())
+	c.Args.Set("oauth_nonce", generateNonce())
+	c.Args.Set("oauth_timestamp", fmt.Sprintf("%d", c.now().Unix()))
 }
 
+// Args that belong to the token steps of the OAuth flow only, Flickr refuses the
+// signature of API calls carrying them
+var tokenFlowArgs = []string{"oauth_callback", "oauth_verifier"}
+
 // Sign the request with a default set of OAuth parameters, needed to authorize
-// users for certain writing/destructive operations.
+// users for certain writing/destructive operations. Args of the token steps of the
+// OAuth flow left in the client, like oauth_callback, are removed first.
 func (c *FlickrClient) OAuthSign() {
+	for _, arg := range tokenFlowArgs {
+		c.Args.Del(arg)
+	}
 	c.SetOAuthDefaults()
 	c.Args.Set("oauth_token", c.OAuthToken)
 	c.Args.Set("oauth_consumer_key", c.ApiKey)
 	c.Args.Set("api_key", c.ApiKey)
 
 	c.Sign(c.OAuthTokenSecret)
+	c.signedWith = oauthSigned
 }
 
 // Specific signing process for API calls: not the same as OAuth sign, used
@@ -93,7 +185,111 @@ func (c *FlickrClient) ApiSign() {
 	c.Args.Set("api_key", c.ApiKey)
 	// the "api_sig" param must not be included in the signing process
 	c.Args.Del("api_sig")
-	c.Args.Set("api_sig", c.getApiSignature(c.ApiSecret))
+	c.Args.Set("api_sig", c.getApiSignature())
+	c.signedWith = apiSigned
+}
+
+// Sign again the request using the same process of the previous signing, so that
+// nonce and timestamp are fresh and the signature matches the HTTP verb actually used.
+// Requests that were never signed and clients with ManualSign set are left untouched.
+func (c *FlickrClient) resign(verb string) {
+	if c.ManualSign {
+		return
+	}
+
+	switch c.signedWith {
+	case oauthSigned:
+		c.HTTPVerb = verb
+		c.OAuthSign()
+	case apiSigned:
+		c.HTTPVerb = verb
+		c.ApiSign()
+	}
+}
+
+// Return the signed GET URL calling method with args, without performing the request,
+// so that it can be fetched elsewhere (ie. from a browser). The request is signed with
+// OAuthSign if the client holds an access token, with ApiSign otherwise. The URL
+// embeds a nonce and a timestamp: Flickr refuses it once it gets too old.
+// Client Args are left untouched.
+func (c *FlickrClient) BuildSignedURL(method string, args url.Values) (string, error) {
+	if method == "" {
+		return "", flickErr.NewError(flickErr.ArgumentError, "no method to call")
+	}
+	if c.ApiKey == "" || (c.ApiSecret == "" && c.Signer == nil) {
+		return "", flickErr.NewError(flickErr.ArgumentError, "api key and secret are needed to sign requests")
+	}
+
+	req := c.copy()
+	req.EndpointUrl = API_ENDPOINT
+	req.HTTPVerb = "GET"
+	for k, v := range args {
+		req.Args[k] = append([]string(nil), v...)
+	}
+	req.Args.Set("method", method)
+	if c.OAuthToken != "" {
+		req.OAuthSign()
+	} else {
+		req.ApiSign()
+	}
+	return req.GetUrl(), nil
+}
+
+// Same as BuildSignedURL for a URL returning the JSON response wrapped in a call to
+// callback (JSONP), so that a browser can read it from another origin: format and
+// jsoncallback args are set, and signed along with the others, while nojsoncallback is
+// dropped. callback must be a JavaScript identifier, dots allowed.
+// An ArgumentError is returned for invalid callback names.
+func (c *FlickrClient) BuildJSONPURL(method string, args url.Values, callback string) (string, error) {
+	if !isCallbackName(callback) {
+		return "", flickErr.NewError(flickErr.ArgumentError, fmt.Sprintf("invalid callback name %q", callback))
+	}
+
+	jsonArgs := url.Values{}
+	for k, v := range args {
+		jsonArgs[k] = v
+	}
+	jsonArgs.Del("nojsoncallback")
+	jsonArgs.Set("format", "json")
+	jsonArgs.Set("jsoncallback", callback)
+	return c.BuildSignedURL(method, jsonArgs)
+}
+
+// Tell whether name is made of JavaScript identifiers separated by dots
+func isCallbackName(name string) bool {
+	for _, part := range strings.Split(name, ".") {
+		if part == "" {
+			return false
+		}
+		for i, r := range part {
+			switch {
+			case r == '_' || r == '$' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z':
+			case i > 0 && r >= '0' && r <= '9':
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Return the User-Agent to be sent with requests
+func (c *FlickrClient) getUserAgent() string {
+	if c.UserAgent == "" {
+		return DEFAULT_USER_AGENT
+	}
+	return c.UserAgent
+}
+
+// Return the size limit for API responses, 0 meaning no limit
+func (c *FlickrClient) getMaxResponseBytes() int64 {
+	switch {
+	case c.MaxResponseBytes == 0:
+		return DEFAULT_MAX_RESPONSE_BYTES
+	case c.MaxResponseBytes < 0:
+		return 0
+	}
+	return c.MaxResponseBytes
 }
 
 // Evaluate the complete URL to make requests (base url + params)
@@ -104,12 +300,20 @@ func (c *FlickrClient) GetUrl() string {
 // Remove all query params
 func (c *FlickrClient) ClearArgs() {
 	c.Args = url.Values{}
+	c.signedWith = notSigned
 }
 
 // Reset Args and set the default endpoint
 func (c *FlickrClient) Init() {
 	c.ClearArgs()
 	c.EndpointUrl = API_ENDPOINT
+	// clients not created by NewFlickrClient
+	if c.rateLimit == nil {
+		c.rateLimit = &rateLimitState{}
+	}
+	if c.clock == nil {
+		c.clock = &clockState{}
+	}
 }
 
 // Get the base string to compose the signature
@@ -124,22 +328,16 @@ func (c *FlickrClient) getSigningBaseString() string {
 
 // Compute the signature of a signed request
 func (c *FlickrClient) getSignature(token_secret string) string {
-	key := fmt.Sprintf("%s&%s", url.QueryEscape(c.ApiSecret), url.QueryEscape(token_secret))
-	base_string := c.getSigningBaseString()
-
-	mac := hmac.New(sha1.New, []byte(key))
-	mac.Write([]byte(base_string))
-
-	ret := base64.StdEncoding.EncodeToString(mac.Sum(nil))
-
-	return ret
+	if c.signatureMethod() == SIGNATURE_PLAINTEXT {
+		return plaintextSignature(c.ApiSecret, token_secret)
+	}
+	return c.signer().SignOAuth(c.getSigningBaseString(), token_secret)
 }
 
-// Sign API requests. This method differs from the signing process needed for
-// OAuth authenticated requests.
-func (c *FlickrClient) getApiSignature(token_secret string) string {
+// Get the base string to compose the signature of API requests: args in alphabetical
+// order, each key followed by its value
+func (c *FlickrClient) getApiSigningBaseString() string {
 	var buf bytes.Buffer
-	buf.WriteString(token_secret)
 
 	keys := make([]string, 0, len(c.Args))
 	for k := range c.Args {
@@ -154,8 +352,11 @@ func (c *FlickrClient) getApiSignature(token_secret string) string {
 		buf.WriteString(arg)
 	}
 
-	base := buf.String()
+	return buf.String()
+}
 
-	data := []byte(base)
-	return fmt.Sprintf("%x", md5.Sum(data))
+// Sign API requests. This method differs from the signing process needed for
+// OAuth authenticated requests.
+func (c *FlickrClient) getApiSignature() string {
+	return c.signer().SignAPI(c.getApiSigningBaseString())
 }
@@ -1,7 +1,11 @@
 package flickr
 
 import (
+	"net/url"
+	"strings"
 	"testing"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
 )
 
 func TestGetSigningBaseString(t *testing.T) {
@@ -90,3 +94,141 @@ func TestInit(t *testing.T) {
 	Expect(t, len(client.Args), 0)
 	Expect(t, client.EndpointUrl != "", true)
 }
+
+func TestSetOAuthDefaultsTwice(t *testing.T) {
+	c := GetTestClient()
+	c.SetOAuthDefaults()
+	c.SetOAuthDefaults()
+	Expect(t, len(c.Args["oauth_nonce"]), 1)
+	Expect(t, len(c.Args["oauth_timestamp"]), 1)
+}
+
+func TestResign(t *testing.T) {
+	c := GetTestClient()
+	c.Args.Set("method", "flickr.test.login")
+	c.OAuthSign()
+	c.Args.Set("oauth_nonce", "stale")
+	signature := c.Args.Get("oauth_signature")
+
+	c.resign("POST")
+	Expect(t, c.HTTPVerb, "POST")
+	Expect(t, c.Args.Get("oauth_nonce") != "stale", true)
+	Expect(t, len(c.Args["oauth_nonce"]), 1)
+	Expect(t, c.Args.Get("oauth_signature") != signature, true)
+
+	// the request is api signed again
+	c = NewFlickrClient("1234567890", "SECRET")
+	c.Args.Set("foo", "1")
+	c.ApiSign()
+	c.Args.Set("foo", "2")
+	c.resign("GET")
+	expected := NewFlickrClient("1234567890", "SECRET")
+	expected.Args.Set("foo", "2")
+	expected.ApiSign()
+	Expect(t, c.Args.Get("api_sig"), expected.Args.Get("api_sig"))
+
+	// unsigned requests stay unsigned
+	c = GetTestClient()
+	c.Init()
+	c.resign("GET")
+	Expect(t, c.Args.Get("oauth_signature"), "")
+	Expect(t, c.Args.Get("api_sig"), "")
+
+	// opt out
+	c = GetTestClient()
+	c.OAuthSign()
+	c.ManualSign = true
+	c.Args.Set("oauth_nonce", "stale")
+	c.resign("POST")
+	Expect(t, c.HTTPVerb, "GET")
+	Expect(t, c.Args.Get("oauth_nonce"), "stale")
+}
+
+// Parse a signed URL into a client ready to verify its signature
+func parseSignedURL(t *testing.T, signed string) *FlickrClient {
+	u, err := url.Parse(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ret := NewFlickrClient("apikey", "apisecret")
+	ret.EndpointUrl = u.Scheme + "://" + u.Host + u.Path
+	ret.Args = u.Query()
+	return ret
+}
+
+func TestBuildSignedURL(t *testing.T) {
+	fclient := NewFlickrClient("apikey", "apisecret")
+	fclient.Args.Set("foo", "bar")
+
+	// api_sig only without an access token
+	signed, err := fclient.BuildSignedURL("flickr.photos.getRecent", url.Values{"per_page": {"10"}})
+	Expect(t, err, nil)
+	Expect(t, strings.HasPrefix(signed, API_ENDPOINT+"?"), true)
+	check := parseSignedURL(t, signed)
+	Expect(t, check.Args.Get("method"), "flickr.photos.getRecent")
+	Expect(t, check.Args.Get("per_page"), "10")
+	Expect(t, check.Args.Get("api_key"), "apikey")
+	sig := check.Args.Get("api_sig")
+	check.Args.Del("api_sig")
+	Expect(t, sig, check.getApiSignature())
+	_, found := check.Args["oauth_signature"]
+	Expect(t, found, false)
+
+	// OAuth with an access token
+	fclient.OAuthToken = "token"
+	fclient.OAuthTokenSecret = "tokensecret"
+	signed, err = fclient.BuildSignedURL("flickr.photos.getInfo", url.Values{"photo_id": {"123"}})
+	Expect(t, err, nil)
+	check = parseSignedURL(t, signed)
+	Expect(t, check.Args.Get("oauth_token"), "token")
+	sig = check.Args.Get("oauth_signature")
+	check.Args.Del("oauth_signature")
+	Expect(t, strings.HasPrefix(check.getSigningBaseString(), "GET&"), true)
+	Expect(t, sig, check.getSignature("tokensecret"))
+
+	// client Args are left untouched
+	Expect(t, len(fclient.Args), 1)
+	Expect(t, fclient.Args.Get("foo"), "bar")
+
+	_, err = fclient.BuildSignedURL("", nil)
+	Expect(t, err != nil, true)
+	_, err = NewFlickrClient("", "").BuildSignedURL("flickr.photos.getRecent", nil)
+	Expect(t, err != nil, true)
+}
+
+func TestBuildJSONPURL(t *testing.T) {
+	fclient := NewFlickrClient("apikey", "apisecret")
+	args := url.Values{"per_page": {"10"}, "nojsoncallback": {"1"}}
+
+	signed, err := fclient.BuildJSONPURL("flickr.photos.getRecent", args, "widget.render_1")
+	Expect(t, err, nil)
+	check := parseSignedURL(t, signed)
+	Expect(t, check.Args.Get("format"), "json")
+	Expect(t, check.Args.Get("jsoncallback"), "widget.render_1")
+	_, found := check.Args["nojsoncallback"]
+	Expect(t, found, false)
+	// jsoncallback is signed
+	sig := check.Args.Get("api_sig")
+	check.Args.Del("api_sig")
+	Expect(t, sig, check.getApiSignature())
+	// args are left untouched
+	Expect(t, args.Get("nojsoncallback"), "1")
+	Expect(t, len(args), 2)
+
+	fclient.OAuthToken = "token"
+	fclient.OAuthTokenSecret = "tokensecret"
+	signed, err = fclient.BuildJSONPURL("flickr.photos.getInfo", url.Values{"photo_id": {"123"}}, "cb")
+	Expect(t, err, nil)
+	check = parseSignedURL(t, signed)
+	sig = check.Args.Get("oauth_signature")
+	check.Args.Del("oauth_signature")
+	Expect(t, strings.Contains(check.getSigningBaseString(), "jsoncallback%3Dcb"), true)
+	Expect(t, sig, check.getSignature("tokensecret"))
+
+	for _, name := range []string{"", "a.", "1cb", "cb()", "alert(1);cb", "a b"} {
+		_, err = fclient.BuildJSONPURL("flickr.photos.getInfo", nil, name)
+		ee, ok := err.(*flickErr.Error)
+		Expect(t, ok, true)
+		Expect(t, ee.ErrorCode, flickErr.ArgumentError)
+	}
+}
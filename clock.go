@@ -0,0 +1,104 @@
+package flickr
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// Differences between the local clock and Flickr one smaller than this are not
+// considered a clock skew
+const maxClockSkew = 5 * time.Minute
+
+// Flickr error code for invalid signatures
+const invalidSignatureCode = 96
+
+// Clock values of a client, safe for concurrent use so that copies of the client
+// made by Batch share them
+type clockState struct {
+	sync.Mutex
+	// difference between Flickr clock, as seen in the latest response, and the
+	// uncorrected local one
+	skew time.Duration
+	// added to the local clock by AutoCorrectClock
+	offset time.Duration
+}
+
+// Return the clock state of the client, created on first use for clients not
+// created by NewFlickrClient
+func (c *FlickrClient) clockState() *clockState {
+	if c.clock == nil {
+		c.clock = &clockState{}
+	}
+	return c.clock
+}
+
+// Return the local time: TimeFunc, or the system time if not set
+func (c *FlickrClient) localNow() time.Time {
+	if c.TimeFunc != nil {
+		return c.TimeFunc()
+	}
+	return time.Now()
+}
+
+// Return the time used for OAuth timestamps: the local time shifted by the offset
+// corrected by AutoCorrectClock
+func (c *FlickrClient) now() time.Time {
+	s := c.clock
+	if s == nil {
+		return c.localNow()
+	}
+	s.Lock()
+	defer s.Unlock()
+	return c.localNow().Add(s.offset)
+}
+
+// Store the difference between the Date header of a Flickr response and the local
+// clock, missing or invalid headers are ignored
+func (c *FlickrClient) recordClockSkew(header http.Header) {
+	date, err := http.ParseTime(header.Get("Date"))
+	if err != nil {
+		return
+	}
+	s := c.clockState()
+	s.Lock()
+	defer s.Unlock()
+	s.skew = date.Sub(c.localNow())
+}
+
+// Tell whether the failure of the current request is about its signature: either
+// Flickr invalid signature error or a raw OAuth problem (timestamp refused, ...)
+func isSignatureError(r FlickrResponse) bool {
+	return r.ErrorCode() == invalidSignatureCode ||
+		(r.ErrorCode() == -1 && strings.Contains(r.ErrorMsg(), "oauth_problem="))
+}
+
+// When a request failed because of its signature and the last response shows the
+// local clock is skewed, return an error with ClockSkewError code instead of err:
+// Flickr refuses timestamps too far from its own time. With AutoCorrectClock set,
+// the skew is also added to the timestamps of the next requests.
+func (c *FlickrClient) checkClockSkew(r FlickrResponse, err error) error {
+	if err == nil || !isSignatureError(r) {
+		return err
+	}
+	s := c.clockState()
+	s.Lock()
+	// skew left once the current correction is applied; setting the offset rather
+	// than adding to it keeps concurrent corrections from piling up
+	skew := s.skew - s.offset
+	if skew > -maxClockSkew && skew < maxClockSkew {
+		s.Unlock()
+		return err
+	}
+	if c.AutoCorrectClock {
+		s.offset = s.skew
+	}
+	s.Unlock()
+
+	return flickErr.NewError(flickErr.ClockSkewError,
+		fmt.Sprintf("local clock is %s off, adjust system time or set TimeFunc", skew.Round(time.Second)))
+}
@@ -0,0 +1,101 @@
+package flickr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestClockSkew(t *testing.T) {
+	var timestamp string
+	skew := time.Hour
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timestamp = r.URL.Query().Get("oauth_timestamp")
+		w.Header().Set("Date", time.Now().Add(skew).UTC().Format(http.TimeFormat))
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"><err code="96" msg="Invalid signature" /></rsp>`))
+	}))
+	defer server.Close()
+
+	fclient := GetTestClient()
+	fclient.EndpointUrl = server.URL
+	fclient.Args.Set("method", "flickr.test.login")
+	fclient.OAuthSign()
+
+	err := DoGet(fclient, &FooResponse{})
+	ferr, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.ErrorCode, flickErr.ClockSkewError)
+
+	// not corrected without AutoCorrectClock
+	before := time.Now().Unix()
+	DoGet(fclient, &FooResponse{})
+	ts, _ := strconv.ParseInt(timestamp, 10, 64)
+	Expect(t, ts-before < 60, true)
+
+	fclient.AutoCorrectClock = true
+	DoGet(fclient, &FooResponse{})
+	DoGet(fclient, &FooResponse{})
+	ts, _ = strconv.ParseInt(timestamp, 10, 64)
+	Expect(t, ts-before > 3500, true)
+
+	// with clocks in sync, signature errors are reported as they are
+	skew = 0
+	fclient.AutoCorrectClock = false
+	fclient.clock.offset = 0
+	err = DoGet(fclient, &FooResponse{})
+	ferr, ok = err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.ErrorCode, flickErr.ApiError)
+}
+
+func TestTimeFunc(t *testing.T) {
+	fclient := GetTestClient()
+	fclient.TimeFunc = func() time.Time { return time.Unix(1500000000, 0) }
+	fclient.OAuthSign()
+	Expect(t, fclient.Args.Get("oauth_timestamp"), "1500000000")
+}
+
+func TestClockSkewAuthRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("oauth_problem=timestamp_refused"))
+	}))
+	defer server.Close()
+
+	fclient := GetTestClient()
+	fclient.EndpointUrl = server.URL
+	fclient.Args.Set("method", "flickr.test.login")
+	fclient.OAuthSign()
+
+	err := DoGet(fclient, &FooResponse{})
+	ferr, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.ErrorCode, flickErr.ClockSkewError)
+}
+
+func TestClockSkewShared(t *testing.T) {
+	server, fclient := FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"><err code="96" msg="Invalid signature" /></rsp>`))
+	}))
+	defer server.Close()
+	fclient.AutoCorrectClock = true
+
+	calls := []Call{}
+	for i := 0; i < 8; i++ {
+		calls = append(calls, Call{Method: "flickr.test.login", Authenticate: true})
+	}
+	results, err := Batch(fclient, calls)
+	Expect(t, err, nil)
+	for _, res := range results {
+		Expect(t, res.Err != nil, true)
+	}
+	// the correction made by the copies of the client is seen by the client, once
+	offset := fclient.now().Sub(time.Now())
+	Expect(t, offset > 50*time.Minute && offset < 70*time.Minute, true)
+}
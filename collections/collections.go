@@ -0,0 +1,87 @@
+// Package implementing methods: flickr.collections.*
+package collections
+
+import (
+	"gopkg.in/masci/flickr.v2"
+)
+
+// A photoset belonging to a collection
+type Set struct {
+	Id          string `xml:"id,attr"`
+	Title       string `xml:"title,attr"`
+	Description string `xml:"description,attr"`
+}
+
+// A node of the collections tree, it can contain either sets or other collections
+type Collection struct {
+	Id          string       `xml:"id,attr"`
+	Title       string       `xml:"title,attr"`
+	Description string       `xml:"description,attr"`
+	IconLarge   string       `xml:"iconlarge,attr"`
+	IconSmall   string       `xml:"iconsmall,attr"`
+	Collections []Collection `xml:"collection"`
+	Sets        []Set        `xml:"set"`
+}
+
+type CollectionTreeResponse struct {
+	flickr.BasicResponse
+	Collections []Collection `xml:"collections>collection"`
+}
+
+type CollectionInfo struct {
+	Id          string `xml:"id,attr"`
+	ChildCount  int    `xml:"child_count,attr"`
+	DateCreate  int    `xml:"datecreate,attr"`
+	IconLarge   string `xml:"iconlarge,attr"`
+	IconSmall   string `xml:"iconsmall,attr"`
+	Server      string `xml:"server,attr"`
+	Secret      string `xml:"secret,attr"`
+	Title       string `xml:"title"`
+	Description string `xml:"description"`
+	IconPhotos  []struct {
+		Id     string `xml:"id,attr"`
+		Owner  string `xml:"owner,attr"`
+		Secret string `xml:"secret,attr"`
+		Server string `xml:"server,attr"`
+		Farm   string `xml:"farm,attr"`
+		Title  string `xml:"title,attr"`
+	} `xml:"iconphotos>photo"`
+}
+
+type CollectionInfoResponse struct {
+	flickr.BasicResponse
+	Collection CollectionInfo `xml:"collection"`
+}
+
+// Returns a tree (or sub tree) of collections belonging to a given user.
+// Both collectionId and userId are optional and may be set to "": in that case the
+// root of the tree and the calling user are assumed.
+// This method does not require authentication.
+func GetTree(client *flickr.FlickrClient, collectionId, userId string) (*CollectionTreeResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.collections.getTree")
+	if collectionId != "" {
+		client.Args.Set("collection_id", collectionId)
+	}
+	if userId != "" {
+		client.Args.Set("user_id", userId)
+	}
+	client.ApiSign()
+
+	response := &CollectionTreeResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Returns information for a single collection.
+// This method requires authentication with 'read' permission.
+func GetInfo(client *flickr.FlickrClient, collectionId string) (*CollectionInfoResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.collections.getInfo")
+	client.Args.Set("collection_id", collectionId)
+	client.OAuthSign()
+
+	response := &CollectionInfoResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
@@ -0,0 +1,102 @@
+package collections
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestGetTree(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <collections>
+    <collection id="12-72157594586579649" title="All My Photos" description="Everything" iconlarge="http://farm1.static.flickr.com/cols/large.jpg" iconsmall="http://farm1.static.flickr.com/cols/small.jpg">
+      <collection id="12-72157594586579650" title="Travels" description="">
+        <collection id="12-72157594586579651" title="Europe" description="Old continent">
+          <set id="72157594586579649" title="Paris" description="Paris by night" />
+          <set id="72157594586579650" title="Rome" description="" />
+        </collection>
+      </collection>
+      <set id="72157594586579651" title="Family" description="" />
+    </collection>
+    <collection id="12-72157594586579652" title="Work" description="" />
+  </collections>
+</rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetTree(fclient, "", "12037949754@N01")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(resp.Collections), 2)
+
+	root := resp.Collections[0]
+	flickr.Expect(t, root.Id, "12-72157594586579649")
+	flickr.Expect(t, root.Title, "All My Photos")
+	flickr.Expect(t, root.Description, "Everything")
+	flickr.Expect(t, root.IconSmall, "http://farm1.static.flickr.com/cols/small.jpg")
+	flickr.Expect(t, len(root.Collections), 1)
+	flickr.Expect(t, len(root.Sets), 1)
+	flickr.Expect(t, root.Sets[0].Title, "Family")
+
+	travels := root.Collections[0]
+	flickr.Expect(t, travels.Title, "Travels")
+	flickr.Expect(t, len(travels.Sets), 0)
+	flickr.Expect(t, len(travels.Collections), 1)
+
+	europe := travels.Collections[0]
+	flickr.Expect(t, europe.Title, "Europe")
+	flickr.Expect(t, len(europe.Collections), 0)
+	flickr.Expect(t, len(europe.Sets), 2)
+	flickr.Expect(t, europe.Sets[0].Id, "72157594586579649")
+	flickr.Expect(t, europe.Sets[0].Description, "Paris by night")
+	flickr.Expect(t, europe.Sets[1].Title, "Rome")
+
+	flickr.Expect(t, resp.Collections[1].Title, "Work")
+
+	flickr.Expect(t, fclient.Args.Get("user_id"), "12037949754@N01")
+	flickr.Expect(t, fclient.Args.Get("collection_id"), "")
+}
+
+func TestGetInfo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <collection id="12-72157594586579649" child_count="6" datecreate="1173812218" iconlarge="http://farm1.static.flickr.com/cols/large.jpg" iconsmall="http://farm1.static.flickr.com/cols/small.jpg" server="1" secret="abcdef">
+    <title>All My Photos</title>
+    <description>Photography</description>
+    <iconphotos>
+      <photo id="15" owner="12037949754@N01" secret="aaa" server="1" farm="2" title="in the kitchen" />
+      <photo id="16" owner="12037949754@N01" secret="bbb" server="1" farm="2" title="in the garden" />
+    </iconphotos>
+  </collection>
+</rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetInfo(fclient, "12-72157594586579649")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Collection.Id, "12-72157594586579649")
+	flickr.Expect(t, resp.Collection.ChildCount, 6)
+	flickr.Expect(t, resp.Collection.DateCreate, 1173812218)
+	flickr.Expect(t, resp.Collection.Title, "All My Photos")
+	flickr.Expect(t, resp.Collection.Description, "Photography")
+	flickr.Expect(t, len(resp.Collection.IconPhotos), 2)
+	flickr.Expect(t, resp.Collection.IconPhotos[1].Title, "in the garden")
+}
+
+func TestGetInfoKo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="fail">
+  <err code="1" msg="Collection not found" />
+</rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetInfo(fclient, "12-000")
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.HasErrors(), true)
+}
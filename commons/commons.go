@@ -0,0 +1,46 @@
+// Package implementing methods: flickr.commons.*
+package commons
+
+import (
+	"gopkg.in/masci/flickr.v2"
+)
+
+type InstitutionUrl struct {
+	// One of "site", "license" or "flickr"
+	Type string `xml:"type,attr"`
+	Url  string `xml:",chardata"`
+}
+
+type Institution struct {
+	Nsid       string           `xml:"nsid,attr"`
+	DateLaunch string           `xml:"date_launch,attr"`
+	Name       string           `xml:"name"`
+	Urls       []InstitutionUrl `xml:"urls>url"`
+}
+
+// Return the url of the given type ("site", "license" or "flickr"), empty if missing
+func (i *Institution) Url(urlType string) string {
+	for _, u := range i.Urls {
+		if u.Type == urlType {
+			return u.Url
+		}
+	}
+	return ""
+}
+
+type InstitutionsResponse struct {
+	flickr.BasicResponse
+	Institutions []Institution `xml:"institutions>institution"`
+}
+
+// Retrieves a list of the current Commons institutions.
+// This method does not require authentication.
+func GetInstitutions(client *flickr.FlickrClient) (*InstitutionsResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.commons.getInstitutions")
+	client.ApiSign()
+
+	response := &InstitutionsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
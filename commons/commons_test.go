@@ -0,0 +1,51 @@
+package commons
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+func TestGetInstitutions(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <institutions>
+    <institution nsid="123456@N01" date_launch="1232000000">
+      <name>Institution</name>
+      <urls>
+        <url type="site">http://example.com/</url>
+        <url type="license">http://example.com/commons/license</url>
+        <url type="flickr">http://flickr.com/photos/institution</url>
+      </urls>
+    </institution>
+    <institution nsid="654321@N01" date_launch="1232500000">
+      <name>Another Institution</name>
+      <urls>
+        <url type="site">http://example.org/</url>
+      </urls>
+    </institution>
+  </institutions>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetInstitutions(fclient)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("api_sig") != "", true)
+	flickr.Expect(t, fclient.Args.Get("oauth_signature"), "")
+	flickr.Expect(t, len(resp.Institutions), 2)
+
+	inst := resp.Institutions[0]
+	flickr.Expect(t, inst.Nsid, "123456@N01")
+	flickr.Expect(t, inst.DateLaunch, "1232000000")
+	flickr.Expect(t, inst.Name, "Institution")
+	flickr.Expect(t, len(inst.Urls), 3)
+	flickr.Expect(t, inst.Url("site"), "http://example.com/")
+	flickr.Expect(t, inst.Url("license"), "http://example.com/commons/license")
+	flickr.Expect(t, inst.Url("flickr"), "http://flickr.com/photos/institution")
+
+	inst = resp.Institutions[1]
+	flickr.Expect(t, inst.Url("site"), "http://example.org/")
+	flickr.Expect(t, inst.Url("flickr"), "")
+}
@@ -0,0 +1,39 @@
+package flickr
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	// Layout of the dates Flickr uses for date_taken ("mysql datetime")
+	TakenDateLayout = "2006-01-02 15:04:05"
+	// Flickr returns this value when the date taken is unknown
+	unknownTakenDate = "0000-00-00 00:00:00"
+)
+
+// Parse a date expressed as unix seconds (date_upload, posted, lastupdate, etc).
+// An empty string yields a zero time.Time and no error.
+func ParseUnixDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	secs, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(secs, 0).UTC(), nil
+}
+
+// Parse a date in the format used for date_taken. Flickr doesn't provide any
+// timezone information so the result is expressed in UTC. Unknown dates
+// (empty or "0000-00-00 00:00:00") yield a zero time.Time and no error.
+func ParseTakenDate(s string) (time.Time, error) {
+	if s == "" || s == unknownTakenDate {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(TakenDateLayout, s)
+}
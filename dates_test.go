@@ -0,0 +1,45 @@
+package flickr
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseUnixDate(t *testing.T) {
+	cases := []struct {
+		in       string
+		expected time.Time
+		fails    bool
+	}{
+		{"1361132046", time.Date(2013, 2, 17, 20, 14, 6, 0, time.UTC), false},
+		{"0", time.Unix(0, 0).UTC(), false},
+		{"", time.Time{}, false},
+		{"yesterday", time.Time{}, true},
+	}
+
+	for _, c := range cases {
+		ret, err := ParseUnixDate(c.in)
+		Expect(t, err != nil, c.fails)
+		Expect(t, ret.Equal(c.expected), true)
+	}
+}
+
+func TestParseTakenDate(t *testing.T) {
+	cases := []struct {
+		in       string
+		expected time.Time
+		fails    bool
+	}{
+		{"2013-02-17 20:14:06", time.Date(2013, 2, 17, 20, 14, 6, 0, time.UTC), false},
+		{"0000-00-00 00:00:00", time.Time{}, false},
+		{"", time.Time{}, false},
+		{"2013-02-17", time.Time{}, true},
+		{"1361132046", time.Time{}, true},
+	}
+
+	for _, c := range cases {
+		ret, err := ParseTakenDate(c.in)
+		Expect(t, err != nil, c.fails)
+		Expect(t, ret.Equal(c.expected), true)
+	}
+}
@@ -0,0 +1,24 @@
+package flickr
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Wrapped by the errors returned instead of sending requests when FlickrClient.DryRun
+// is set, check it with errors.Is
+var ErrDryRun = errors.New("dry run, request not sent")
+
+// Error returned in dry-run mode, holding the request exactly as it would have been
+// sent: signed, with headers set. Get it with errors.As.
+type DryRunError struct {
+	Request *http.Request
+}
+
+func (e *DryRunError) Error() string {
+	return ErrDryRun.Error() + ": " + e.Request.Method + " " + e.Request.URL.String()
+}
+
+func (e *DryRunError) Unwrap() error {
+	return ErrDryRun
+}
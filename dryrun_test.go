@@ -0,0 +1,66 @@
+package flickr
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	var sent *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = r
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`))
+	}))
+	defer server.Close()
+
+	fclient := GetTestClient()
+	fclient.EndpointUrl = server.URL
+	fclient.Args.Set("method", "flickr.test.echo")
+	fclient.UserAgent = "myapp/1.0"
+	fclient.ApiSign()
+	fclient.DryRun = true
+
+	err := DoGet(fclient, &FooResponse{})
+	Expect(t, errors.Is(err, ErrDryRun), true)
+	var dryRun *DryRunError
+	Expect(t, errors.As(err, &dryRun), true)
+	Expect(t, sent == nil, true)
+	Expect(t, dryRun.Request.Method, "GET")
+	Expect(t, dryRun.Request.Header.Get("User-Agent"), "myapp/1.0")
+	Expect(t, dryRun.Request.URL.Query().Get("api_sig"), fclient.Args.Get("api_sig"))
+
+	// the same request is sent for real, ApiSign signatures don't change
+	fclient.DryRun = false
+	Expect(t, DoGet(fclient, &FooResponse{}), nil)
+	Expect(t, sent.URL.RawQuery, dryRun.Request.URL.RawQuery)
+}
+
+func TestDryRunPost(t *testing.T) {
+	fclient := GetTestClient()
+	fclient.Args.Set("photo_id", "123")
+	fclient.DryRun = true
+
+	err := DoPost(fclient, &FooResponse{})
+	var dryRun *DryRunError
+	Expect(t, errors.As(err, &dryRun), true)
+	Expect(t, dryRun.Request.Method, "POST")
+	Expect(t, strings.HasPrefix(dryRun.Request.Header.Get("Content-Type"), "multipart/form-data"), true)
+	body, _ := ioutil.ReadAll(dryRun.Request.Body)
+	Expect(t, strings.Contains(string(body), `name="photo_id"`), true)
+}
+
+func TestDryRunUpload(t *testing.T) {
+	fclient := GetTestClient()
+	fclient.DryRun = true
+
+	_, err := UploadReader(fclient, strings.NewReader("photo"), "gopher.jpg", nil)
+	var dryRun *DryRunError
+	Expect(t, errors.As(err, &dryRun), true)
+	Expect(t, dryRun.Request.URL.String(), UPLOAD_ENDPOINT)
+	body, _ := ioutil.ReadAll(dryRun.Request.Body)
+	Expect(t, strings.Contains(string(body), "photo"), true)
+}
@@ -6,15 +6,33 @@ package error
 // along with the HTTP Response
 
 const (
-	ApiError          = 10
-	RequestTokenError = 20
-	OAuthTokenError   = 30
+	ApiError              = 10
+	RequestTokenError     = 20
+	OAuthTokenError       = 30
+	ArgumentError         = 40
+	CallbackError         = 50
+	InvalidTokenError     = 60
+	ResponseTooLargeError = 70
+	ClockSkewError        = 80
+	UnexpectedStatusError = 90
+	AuthRejectedError     = 100 // same code Flickr uses for "Invalid API Key"
+	MaintenanceError      = 105 // same code Flickr uses for "Service currently unavailable"
+	RedirectError         = 110
 )
 
 var errors = map[int]string{
-	ApiError:          "Flickr API returned an error: ",
-	RequestTokenError: "An error occurred during token request: ",
-	OAuthTokenError:   "An error occurred while getting the OAuth token: ",
+	ApiError:              "Flickr API returned an error: ",
+	RequestTokenError:     "An error occurred during token request: ",
+	OAuthTokenError:       "An error occurred while getting the OAuth token: ",
+	ArgumentError:         "Invalid argument: ",
+	CallbackError:         "Authorization was not granted: ",
+	InvalidTokenError:     "OAuth token is not valid anymore: ",
+	ResponseTooLargeError: "Response body exceeds the size limit: ",
+	ClockSkewError:        "Local clock is not in sync with Flickr: ",
+	UnexpectedStatusError: "Flickr returned an unexpected status: ",
+	AuthRejectedError:     "Flickr rejected the credentials of the request: ",
+	MaintenanceError:      "Flickr API is currently unavailable: ",
+	RedirectError:         "Flickr redirected a request that can't be followed: ",
 }
 
 type Error struct {
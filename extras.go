@@ -0,0 +1,62 @@
+package flickr
+
+import (
+	"strings"
+)
+
+// Extras builds the comma separated value of the "extras" argument accepted by
+// list methods (search, getPhotos, etc), so that field names are checked at compile time:
+//
+//	extras := flickr.NewExtras().DateUpload().OwnerName().URLOriginal().String()
+type Extras struct {
+	fields []string
+}
+
+// Create an empty Extras builder
+func NewExtras() *Extras {
+	return &Extras{}
+}
+
+// Add a field to the list, duplicates are ignored
+func (e *Extras) add(field string) *Extras {
+	for _, f := range e.fields {
+		if f == field {
+			return e
+		}
+	}
+	e.fields = append(e.fields, field)
+	return e
+}
+
+// Return the value to be used for the "extras" argument
+func (e *Extras) String() string {
+	return strings.Join(e.fields, ",")
+}
+
+func (e *Extras) Description() *Extras    { return e.add("description") }
+func (e *Extras) License() *Extras        { return e.add("license") }
+func (e *Extras) DateUpload() *Extras     { return e.add("date_upload") }
+func (e *Extras) DateTaken() *Extras      { return e.add("date_taken") }
+func (e *Extras) OwnerName() *Extras      { return e.add("owner_name") }
+func (e *Extras) IconServer() *Extras     { return e.add("icon_server") }
+func (e *Extras) OriginalFormat() *Extras { return e.add("original_format") }
+func (e *Extras) LastUpdate() *Extras     { return e.add("last_update") }
+func (e *Extras) Geo() *Extras            { return e.add("geo") }
+func (e *Extras) Tags() *Extras           { return e.add("tags") }
+func (e *Extras) MachineTags() *Extras    { return e.add("machine_tags") }
+func (e *Extras) OriginalDims() *Extras   { return e.add("o_dims") }
+func (e *Extras) Views() *Extras          { return e.add("views") }
+func (e *Extras) Media() *Extras          { return e.add("media") }
+func (e *Extras) PathAlias() *Extras      { return e.add("path_alias") }
+
+// Photo urls, each one also adds width and height of the given size to the response
+func (e *Extras) URLSquare() *Extras      { return e.add("url_sq") }
+func (e *Extras) URLLargeSquare() *Extras { return e.add("url_q") }
+func (e *Extras) URLThumbnail() *Extras   { return e.add("url_t") }
+func (e *Extras) URLSmall() *Extras       { return e.add("url_s") }
+func (e *Extras) URLSmall320() *Extras    { return e.add("url_n") }
+func (e *Extras) URLMedium() *Extras      { return e.add("url_m") }
+func (e *Extras) URLMedium640() *Extras   { return e.add("url_z") }
+func (e *Extras) URLMedium800() *Extras   { return e.add("url_c") }
+func (e *Extras) URLLarge() *Extras       { return e.add("url_l") }
+func (e *Extras) URLOriginal() *Extras    { return e.add("url_o") }
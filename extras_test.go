@@ -0,0 +1,25 @@
+package flickr
+
+import (
+	"testing"
+)
+
+func TestExtras(t *testing.T) {
+	Expect(t, NewExtras().String(), "")
+
+	e := NewExtras().DateUpload().OwnerName().URLOriginal()
+	Expect(t, e.String(), "date_upload,owner_name,url_o")
+
+	// duplicates are dropped
+	e.DateUpload().Geo()
+	Expect(t, e.String(), "date_upload,owner_name,url_o,geo")
+
+	all := NewExtras().Description().License().DateUpload().DateTaken().OwnerName().
+		IconServer().OriginalFormat().LastUpdate().Geo().Tags().MachineTags().
+		OriginalDims().Views().Media().PathAlias().URLSquare().URLLargeSquare().
+		URLThumbnail().URLSmall().URLSmall320().URLMedium().URLMedium640().
+		URLMedium800().URLLarge().URLOriginal()
+	Expect(t, all.String(), "description,license,date_upload,date_taken,owner_name,"+
+		"icon_server,original_format,last_update,geo,tags,machine_tags,o_dims,views,"+
+		"media,path_alias,url_sq,url_q,url_t,url_s,url_n,url_m,url_z,url_c,url_l,url_o")
+}
@@ -4,6 +4,10 @@ package flickr
 import (
 	"bytes"
 	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
 )
 
 const (
@@ -12,49 +16,226 @@ const (
 	AUTHORIZE_URL     = "https://www.flickr.com/services/oauth/authorize"
 	REQUEST_TOKEN_URL = "https://www.flickr.com/services/oauth/request_token"
 	ACCESS_TOKEN_URL  = "https://www.flickr.com/services/oauth/access_token"
+	// Base url of the servers hosting photo files
+	STATIC_URL = "https://live.staticflickr.com"
+	// Base url of short photo URLs, see EncodeShortURL
+	SHORT_URL = "https://flic.kr/p/"
+	// User-Agent sent when FlickrClient.UserAgent is empty
+	DEFAULT_USER_AGENT = "flickr.go/v2"
+	// Number of results per page asked by list methods when none is given
+	DEFAULT_PER_PAGE = 100
+	// Size limit for API responses when FlickrClient.MaxResponseBytes is zero
+	DEFAULT_MAX_RESPONSE_BYTES = 16 << 20
 )
 
 // Perform a GET request to the Flickr API with the configured FlickrClient passed as first
 // parameter. Results will be unmarshalled to fill in a FlickrResponse struct passed as
 // second parameter.
-func DoGet(client *FlickrClient, r FlickrResponse) error {
-	res, err := client.HTTPClient.Get(client.GetUrl())
+// Requests previously signed with OAuthSign or ApiSign are signed again before being sent,
+// unless client.ManualSign is set.
+// When a cache was set with SetCache, successful responses are served from there.
+// If client.ForcePost is set, the request is performed with DoPost instead and the
+// cache is not used. Failed attempts are retried as set by client.MaxRetries.
+// Redirected requests are signed again for the new location before following, only
+// redirects to https flickr.com endpoints are followed.
+// If client.Validate is set, missing required arguments make the
+// call fail before sending the request.
+func DoGet(client *FlickrClient, r FlickrResponse) (err error) {
+	if client.ForcePost {
+		return DoPost(client, r)
+	}
+	defer client.observeCall(r)(&err)
+
+	if err := validateArgs(client); err != nil {
+		return err
+	}
+	client.resign("GET")
+
+	var key string
+	var stale *cacheEntry
+	if client.cache != nil {
+		key = client.cacheKey()
+		if body, found := client.cache.get(key); found {
+			return decodeApiBody(body, r, client.LenientDecode)
+		}
+		stale, _ = client.cache.stale(key)
+	}
+
+	res, err := sendWithRetry(client, "GET", func() (*http.Request, error) {
+		req, err := http.NewRequest("GET", client.GetUrl(), nil)
+		if err == nil && stale != nil {
+			stale.setConditionalHeaders(req)
+		}
+		return req, err
+	})
+	if err != nil {
+		return err
+	}
+	if stale != nil && res.StatusCode == http.StatusNotModified {
+		res.Body.Close()
+		client.cache.refresh(key, stale)
+		return decodeApiBody(stale.body, r, client.LenientDecode)
+	}
+
+	body, err := readResponseBody(res, client.getMaxResponseBytes())
 	if err != nil {
 		return err
 	}
 
-	return parseApiResponse(res, r)
+	err = client.checkClockSkew(r, decodeResponse(res, body, r, client.LenientDecode))
+	client.reportWarnings(body, r)
+	if err == nil && client.cache != nil {
+		client.cache.set(key, body, res.Header)
+	}
+	return err
+}
+
+// Perform a GET request signed with the api key and secret only (see ApiSign), for read
+// methods that don't need user authorization. Any oauth_* param left in client Args is
+// removed before signing, so no access token is needed nor sent.
+func DoGetSigned(client *FlickrClient, r FlickrResponse) error {
+	for k := range client.Args {
+		if strings.HasPrefix(k, "oauth_") {
+			client.Args.Del(k)
+		}
+	}
+	client.ApiSign()
+	return DoGet(client, r)
 }
 
 // Perform a POST request to the Flickr API with the configured FlickrClient, the
 // request body and the body content type. Results will be unmarshalled in a FlickrResponse
-// struct.
-func DoPostBody(client *FlickrClient, body *bytes.Buffer, bodyType string, r FlickrResponse) error {
-	res, err := client.HTTPClient.Post(client.EndpointUrl, bodyType, body)
+// struct. The body can't be signed again, so the request is sent once regardless of
+// client.MaxRetries.
+func DoPostBody(client *FlickrClient, body *bytes.Buffer, bodyType string, r FlickrResponse) (err error) {
+	defer client.observeCall(r)(&err)
+	return postBody(client, r, func() (*http.Request, error) {
+		return newPostRequest(client, body, bodyType)
+	}, false)
+}
+
+// Build a POST request to the client endpoint
+func newPostRequest(client *FlickrClient, body *bytes.Buffer, bodyType string) (*http.Request, error) {
+	req, err := http.NewRequest("POST", client.EndpointUrl, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", bodyType)
+	return req, nil
+}
+
+// Send the POST request built by newRequest, retrying it if retry is set, and
+// unmarshal the response in r
+func postBody(client *FlickrClient, r FlickrResponse, newRequest func() (*http.Request, error), retry bool) error {
+	var res *http.Response
+	var err error
+	if retry {
+		res, err = sendWithRetry(client, "POST", newRequest)
+	} else {
+		res, err = sendFollowingRedirects(client, "POST", newRequest)
+	}
+	if err != nil {
+		return err
+	}
+
+	resBody, err := readResponseBody(res, client.getMaxResponseBytes())
 	if err != nil {
 		return err
 	}
 
-	return parseApiResponse(res, r)
+	err = client.checkClockSkew(r, decodeResponse(res, resBody, r, client.LenientDecode))
+	client.reportWarnings(resBody, r)
+	return err
 }
 
-// Perform a POST request to the Flickr API with the configured FlickrClient,
-// dumping client Args into the request Body.
-func DoPost(client *FlickrClient, r FlickrResponse) error {
-	// instance an empty request body
-	body := &bytes.Buffer{}
-	// multipart writer to fill the body
-	writer := multipart.NewWriter(body)
-	// dump params
-	for key, val := range client.Args {
-		_ = writer.WriteField(key, val[0])
-	}
-	err := writer.Close()
+// Set the headers common to every request and send it with the client's HTTPClient,
+// unless client.DryRun is set. Redirects are not followed, the response is returned
+// as is: see sendFollowingRedirects.
+func sendRequest(client *FlickrClient, req *http.Request) (*http.Response, error) {
+	req.Header.Set("User-Agent", client.getUserAgent())
+	if client.Compress {
+		// setting the header explicitly disables the transparent decoding
+		// performed by http.Transport, readResponseBody takes care of it
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+
+	if client.DryRun {
+		return nil, &DryRunError{Request: req}
+	}
+
+	var start time.Time
+	if client.callStats != nil {
+		start = time.Now()
+	}
+	httpClient := *client.HTTPClient
+	httpClient.CheckRedirect = noFollow
+	res, err := httpClient.Do(req)
+	if client.callStats != nil {
+		client.callStats.attempts++
+		client.callStats.http += time.Since(start)
+	}
 	if err != nil {
+		return nil, err
+	}
+	client.rateLimit.record(res.Header)
+	client.recordClockSkew(res.Header)
+
+	return res, nil
+}
+
+// Perform a POST request to the Flickr API with the configured FlickrClient,
+// dumping client Args into the request Body. As for DoGet, the request is signed again
+// unless client.ManualSign is set. Attempts that got no response are retried as set by
+// client.MaxRetries, error statuses are not: the write may have been applied.
+// Redirects are not followed: they fail with a RedirectError.
+func DoPost(client *FlickrClient, r FlickrResponse) (err error) {
+	defer client.observeCall(r)(&err)
+
+	if err := validateArgs(client); err != nil {
 		return err
 	}
-	// evaluate the content type and the boundary
-	contentType := writer.FormDataContentType()
+	client.resign("POST")
+
+	return postBody(client, r, func() (*http.Request, error) {
+		// instance an empty request body
+		body := &bytes.Buffer{}
+		// multipart writer to fill the body
+		writer := multipart.NewWriter(body)
+		// dump params
+		for key, val := range client.Args {
+			_ = writer.WriteField(key, val[0])
+		}
+		err := writer.Close()
+		if err != nil {
+			return nil, err
+		}
+		// evaluate the content type and the boundary
+		return newPostRequest(client, body, writer.FormDataContentType())
+	}, true)
+}
+
+// Return a copy of the client whose Args are the client ones merged with extra, values in
+// extra taking precedence. The copy is signed the same way the client was.
+func withArgs(client *FlickrClient, extra url.Values) *FlickrClient {
+	ret := client.copy()
+	for k, v := range client.Args {
+		ret.Args[k] = append([]string(nil), v...)
+	}
+	for k, v := range extra {
+		ret.Args[k] = append([]string(nil), v...)
+	}
+	ret.signedWith = client.signedWith
+	return ret
+}
+
+// Same as DoGet, but the extra args are merged onto a copy of the client for this call
+// only: client.Args is left untouched.
+func DoGetWithArgs(client *FlickrClient, extra url.Values, r FlickrResponse) error {
+	return DoGet(withArgs(client, extra), r)
+}
 
-	return DoPostBody(client, body, contentType, r)
+// Same as DoPost, but the extra args are merged onto a copy of the client for this call
+// only: client.Args is left untouched.
+func DoPostWithArgs(client *FlickrClient, extra url.Values, r FlickrResponse) error {
+	return DoPost(withArgs(client, extra), r)
 }
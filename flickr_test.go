@@ -2,7 +2,16 @@ package flickr
 
 import (
 	"bytes"
+	"compress/gzip"
+	"crypto/md5"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
 )
 
 func TestDoGet(t *testing.T) {
@@ -36,3 +45,170 @@ func TestDoPost(t *testing.T) {
 	params := []string{"fooArg"}
 	AssertParamsInBody(t, fclient, params)
 }
+
+func TestDoGetCompressed(t *testing.T) {
+	bodyStr := `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><foo>Foo!</foo></rsp>`
+	var acceptEncoding string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		if acceptEncoding != "gzip" {
+			w.Write([]byte(bodyStr))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(bodyStr))
+		gz.Close()
+	}))
+	defer server.Close()
+
+	fclient := GetTestClient()
+	fclient.EndpointUrl = server.URL
+	fclient.Compress = true
+	resp := &FooResponse{}
+	err := DoGet(fclient, resp)
+	Expect(t, err, nil)
+	Expect(t, acceptEncoding, "gzip")
+	Expect(t, resp.Foo, "Foo!")
+
+	// non gzip responses must still parse
+	server, client := FlickrMock(200, bodyStr, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	resp = &FooResponse{}
+	err = DoGet(fclient, resp)
+	Expect(t, err, nil)
+	Expect(t, resp.Foo, "Foo!")
+}
+
+func TestUserAgent(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`))
+	}))
+	defer server.Close()
+
+	fclient := GetTestClient()
+	fclient.EndpointUrl = server.URL
+	err := DoGet(fclient, &FooResponse{})
+	Expect(t, err, nil)
+	Expect(t, userAgent, DEFAULT_USER_AGENT)
+
+	fclient.UserAgent = "myapp/1.0"
+	err = DoPost(fclient, &FooResponse{})
+	Expect(t, err, nil)
+	Expect(t, userAgent, "myapp/1.0")
+}
+
+func TestDoGetServiceUnavailable(t *testing.T) {
+	fclient := GetTestClient()
+	server, client := FlickrMock(503, "<html>down for maintenance</html>", "text/html")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	err := DoGet(fclient, &FooResponse{})
+	ferr, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.ErrorCode, flickErr.MaintenanceError)
+}
+
+func TestDoGetMaxResponseBytes(t *testing.T) {
+	fclient := GetTestClient()
+	server, client := FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok"><foo>`+strings.Repeat("x", 1024)+`</foo></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	fclient.MaxResponseBytes = 512
+
+	err := DoGet(fclient, &FooResponse{})
+	ferr, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.ErrorCode, flickErr.ResponseTooLargeError)
+
+	fclient.MaxResponseBytes = -1
+	err = DoGet(fclient, &FooResponse{})
+	Expect(t, err, nil)
+}
+
+func TestDoGetForcePost(t *testing.T) {
+	var method, photoId string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		photoId = r.FormValue("photo_id")
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`))
+	}))
+	defer server.Close()
+
+	fclient := GetTestClient()
+	fclient.Args.Set("photo_id", "123")
+	fclient.OAuthSign()
+	fclient.EndpointUrl = server.URL
+	fclient.ForcePost = true
+
+	err := DoGet(fclient, &FooResponse{})
+	Expect(t, err, nil)
+	Expect(t, method, "POST")
+	Expect(t, photoId, "123")
+	Expect(t, fclient.HTTPVerb, "POST")
+	Expect(t, strings.HasPrefix(fclient.getSigningBaseString(), "POST&"), true)
+}
+
+func TestDoGetSigned(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`))
+	}))
+	defer server.Close()
+
+	fclient := GetTestClient()
+	fclient.Args.Set("method", "flickr.commons.getInstitutions")
+	// leftovers of a previous OAuth signing
+	fclient.OAuthSign()
+	fclient.EndpointUrl = server.URL
+
+	err := DoGetSigned(fclient, &FooResponse{})
+	Expect(t, err, nil)
+	for k := range query {
+		Expect(t, strings.HasPrefix(k, "oauth_"), false)
+	}
+	Expect(t, query.Get("api_key"), fclient.ApiKey)
+	Expect(t, query.Get("method"), "flickr.commons.getInstitutions")
+	// md5 of secret + sorted key/values
+	base := fclient.ApiSecret + "api_key" + fclient.ApiKey + "methodflickr.commons.getInstitutions"
+	Expect(t, query.Get("api_sig"), fmt.Sprintf("%x", md5.Sum([]byte(base))))
+}
+
+func TestDoWithArgs(t *testing.T) {
+	var received url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseMultipartForm(1024)
+		received = r.Form
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`))
+	}))
+	defer server.Close()
+
+	fclient := GetTestClient()
+	fclient.EndpointUrl = server.URL
+	fclient.Args.Set("method", "flickr.photos.getInfo")
+	fclient.Args.Set("photo_id", "123")
+	fclient.OAuthSign()
+	before := fclient.Args.Encode()
+
+	err := DoGetWithArgs(fclient, url.Values{"photo_id": {"456"}, "secret": {"abc"}}, &FooResponse{})
+	Expect(t, err, nil)
+	Expect(t, received.Get("photo_id"), "456")
+	Expect(t, received.Get("secret"), "abc")
+	Expect(t, received.Get("method"), "flickr.photos.getInfo")
+	// signature covers the extra args
+	Expect(t, received.Get("oauth_signature") != fclient.Args.Get("oauth_signature"), true)
+
+	err = DoPostWithArgs(fclient, url.Values{"photo_id": {"789"}}, &FooResponse{})
+	Expect(t, err, nil)
+	Expect(t, received.Get("photo_id"), "789")
+
+	Expect(t, fclient.Args.Encode(), before)
+	Expect(t, fclient.HTTPVerb, "GET")
+}
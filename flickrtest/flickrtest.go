@@ -0,0 +1,26 @@
+// Package flickrtest provides helpers to test code built on top of the flickr package
+// against a mocked Flickr API.
+package flickrtest
+
+import (
+	"io"
+	"net/http/httptest"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Start a server replying to any request with the given HTTP status and body, and
+// return it along with a client whose requests are all routed to it, whatever the
+// endpoint. The client is signed with the published testing keys of GetTestClient.
+// Callers are responsible for closing the server.
+func NewMockClient(status int, body string) (*httptest.Server, *flickr.FlickrClient) {
+	server, httpClient := flickr.FlickrMock(status, body, "")
+	client := flickr.GetTestClient()
+	client.HTTPClient = httpClient
+	return server, client
+}
+
+// Return a ReadCloser over s, useful to fake the Body of a http.Response
+func NewFakeBody(s string) io.ReadCloser {
+	return flickr.NewFakeBody(s)
+}
@@ -0,0 +1,26 @@
+package flickrtest
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/test"
+)
+
+func TestNewMockClient(t *testing.T) {
+	server, client := NewMockClient(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`)
+	defer server.Close()
+
+	resp, err := test.Null(client)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.HasErrors(), false)
+}
+
+func TestNewFakeBody(t *testing.T) {
+	body := NewFakeBody("foo")
+	content, err := ioutil.ReadAll(body)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, string(content), "foo")
+	flickr.Expect(t, body.Close(), nil)
+}
@@ -0,0 +1,115 @@
+// Package implementing methods: flickr.galleries.*
+package galleries
+
+import (
+	"strings"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+type Gallery struct {
+	Id                 string `xml:"id,attr"`
+	Url                string `xml:"url,attr"`
+	Owner              string `xml:"owner,attr"`
+	DateCreate         string `xml:"date_create,attr"`
+	DateUpdate         string `xml:"date_update,attr"`
+	PrimaryPhotoId     string `xml:"primary_photo_id,attr"`
+	PrimaryPhotoServer string `xml:"primary_photo_server,attr"`
+	PrimaryPhotoFarm   string `xml:"primary_photo_farm,attr"`
+	PrimaryPhotoSecret string `xml:"primary_photo_secret,attr"`
+	CountPhotos        int    `xml:"count_photos,attr"`
+	CountVideos        int    `xml:"count_videos,attr"`
+	Title              string `xml:"title"`
+	Description        string `xml:"description"`
+}
+
+type GalleryListResponse struct {
+	flickr.BasicResponse
+	Galleries struct {
+		UserId    string    `xml:"user_id,attr"`
+		Page      int       `xml:"page,attr"`
+		Pages     int       `xml:"pages,attr"`
+		PerPage   int       `xml:"per_page,attr"`
+		Total     int       `xml:"total,attr"`
+		Galleries []Gallery `xml:"gallery"`
+	} `xml:"galleries"`
+}
+
+// A photo in a gallery, along with the comment the curator left for it
+type GalleryPhoto struct {
+	flickr.Photo
+	HasComment bool   `xml:"has_comment,attr"`
+	Comment    string `xml:"comment"`
+}
+
+type GalleryPhotosResponse struct {
+	flickr.BasicResponse
+	Photos struct {
+		Page    int            `xml:"page,attr"`
+		Pages   int            `xml:"pages,attr"`
+		PerPage int            `xml:"perpage,attr"`
+		Total   int            `xml:"total,attr"`
+		Photos  []GalleryPhoto `xml:"photo"`
+	} `xml:"photos"`
+}
+
+// Return the list of galleries created by a user, sorted from newest to oldest.
+// A zero page lets Flickr return the first one, perPage defaults to flickr.DEFAULT_PER_PAGE.
+// This method does not require authentication.
+func GetList(client *flickr.FlickrClient, userId string, page, perPage int) (*GalleryListResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.galleries.getList")
+	client.Args.Set("user_id", userId)
+	flickr.SetPageArgs(client, page, perPage)
+	client.ApiSign()
+
+	response := &GalleryListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Return the list of photos for a gallery, extras is a comma separated list of
+// extra fields to fetch for each photo (see flickr.Extras).
+// This method does not require authentication.
+func GetPhotos(client *flickr.FlickrClient, galleryId string, extras string) (*GalleryPhotosResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.galleries.getPhotos")
+	client.Args.Set("gallery_id", galleryId)
+	if extras != "" {
+		client.Args.Set("extras", extras)
+	}
+	client.ApiSign()
+
+	response := &GalleryPhotosResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Modify the photos in a gallery: photoIds replaces the current content and
+// ordering of the gallery and must contain primaryPhotoId.
+// This method requires authentication with 'write' permission.
+func EditPhotos(client *flickr.FlickrClient, galleryId, primaryPhotoId string, photoIds []string) (*flickr.BasicResponse, error) {
+	found := false
+	for _, id := range photoIds {
+		if id == primaryPhotoId {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, flickErr.NewError(flickErr.ArgumentError, "primary photo must be part of the gallery photos")
+	}
+
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.galleries.editPhotos")
+	client.Args.Set("gallery_id", galleryId)
+	client.Args.Set("primary_photo_id", primaryPhotoId)
+	client.Args.Set("photo_ids", strings.Join(photoIds, ","))
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
@@ -0,0 +1,90 @@
+package galleries
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestGetList(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <galleries total="1" page="1" pages="1" per_page="100" user_id="34427469121@N01">
+    <gallery id="5704-72157622637971865" url="http://www.flickr.com/photos/george/galleries/72157622637971865"
+      owner="34427469121@N01" date_create="1257711422" date_update="1260360756"
+      primary_photo_id="107391222" primary_photo_server="39" primary_photo_farm="1"
+      primary_photo_secret="ffa" count_photos="16" count_videos="2">
+      <title>I like me some black &amp; white</title>
+      <description>black and white photos</description>
+    </gallery>
+  </galleries>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetList(fclient, "34427469121@N01", 0, 100)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.galleries.getList")
+	flickr.Expect(t, fclient.Args.Get("user_id"), "34427469121@N01")
+	flickr.Expect(t, fclient.Args.Get("per_page"), "100")
+	_, found := fclient.Args["page"]
+	flickr.Expect(t, found, false)
+	flickr.Expect(t, resp.Galleries.Total, 1)
+	g := resp.Galleries.Galleries[0]
+	flickr.Expect(t, g.Id, "5704-72157622637971865")
+	flickr.Expect(t, g.PrimaryPhotoId, "107391222")
+	flickr.Expect(t, g.CountPhotos, 16)
+	flickr.Expect(t, g.CountVideos, 2)
+	flickr.Expect(t, g.Title, "I like me some black & white")
+}
+
+func TestGetPhotos(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photos page="1" pages="1" perpage="500" total="2">
+    <photo id="2822546461" owner="99338564@N00" secret="2dc9b9d0f5" server="3088" farm="4"
+      title="Bliss" ispublic="1" isfriend="0" isfamily="0" is_primary="0" has_comment="1">
+      <comment>best one</comment>
+    </photo>
+    <photo id="2822546462" owner="99338564@N00" secret="3dc9b9d0f5" server="3088" farm="4"
+      title="Wall" ispublic="1" isfriend="0" isfamily="0" is_primary="1" has_comment="0"/>
+  </photos>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPhotos(fclient, "6065-72157617483228192", "views")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("gallery_id"), "6065-72157617483228192")
+	flickr.Expect(t, fclient.Args.Get("extras"), "views")
+	flickr.Expect(t, resp.Photos.Total, 2)
+	flickr.Expect(t, resp.Photos.Photos[0].Id, "2822546461")
+	flickr.Expect(t, resp.Photos.Photos[0].Title, "Bliss")
+	flickr.Expect(t, resp.Photos.Photos[0].HasComment, true)
+	flickr.Expect(t, resp.Photos.Photos[0].Comment, "best one")
+	flickr.Expect(t, resp.Photos.Photos[1].Comment, "")
+}
+
+func TestEditPhotos(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := EditPhotos(fclient, "123", "2", []string{"1", "2", "3"})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.HTTPVerb, "POST")
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.galleries.editPhotos")
+	flickr.Expect(t, fclient.Args.Get("primary_photo_id"), "2")
+	flickr.Expect(t, fclient.Args.Get("photo_ids"), "1,2,3")
+}
+
+func TestEditPhotosKo(t *testing.T) {
+	_, err := EditPhotos(flickr.GetTestClient(), "123", "4", []string{"1", "2", "3"})
+	ferr, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+}
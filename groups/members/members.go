@@ -0,0 +1,52 @@
+// Package implementing methods: flickr.groups.members.*
+package members
+
+import (
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Values of the member type, use them joined by commas in the membertypes argument of GetList
+const (
+	MemberTypeMember    = 2
+	MemberTypeModerator = 3
+	MemberTypeAdmin     = 4
+)
+
+type Member struct {
+	Nsid       string `xml:"nsid,attr"`
+	Username   string `xml:"username,attr"`
+	IconServer string `xml:"iconserver,attr"`
+	IconFarm   string `xml:"iconfarm,attr"`
+	MemberType int    `xml:"membertype,attr"`
+}
+
+type GroupMembersResponse struct {
+	flickr.BasicResponse
+	Members struct {
+		Page    int      `xml:"page,attr"`
+		Pages   int      `xml:"pages,attr"`
+		PerPage int      `xml:"perpage,attr"`
+		Total   int      `xml:"total,attr"`
+		Members []Member `xml:"member"`
+	} `xml:"members"`
+}
+
+// Get a list of the members of a group. membertypes is a comma separated list of member
+// types to return (e.g. "3,4" for moderators and admins), pass "" to get all of them.
+// The calling user must be an administrator of the group, Flickr returns an error
+// otherwise, available through the response ErrorCode().
+// This method requires authentication with 'read' permission.
+func GetList(client *flickr.FlickrClient, groupId, membertypes string, page, perPage int) (*GroupMembersResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.groups.members.getList")
+	client.Args.Set("group_id", groupId)
+	if membertypes != "" {
+		client.Args.Set("membertypes", membertypes)
+	}
+	flickr.SetPageArgs(client, page, perPage)
+	client.OAuthSign()
+
+	response := &GroupMembersResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
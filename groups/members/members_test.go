@@ -0,0 +1,47 @@
+package members
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestGetList(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <members page="1" pages="1" perpage="100" total="2">
+    <member nsid="123456@N01" username="foo" iconserver="1" iconfarm="1" membertype="2" />
+    <member nsid="118210@N07" username="kewlchops666" iconserver="0" iconfarm="0" membertype="4" />
+  </members>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetList(fclient, "34427469792@N01", "2,4", 1, 100)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("membertypes"), "2,4")
+	flickr.Expect(t, fclient.Args.Get("per_page"), "100")
+	flickr.Expect(t, resp.Members.Total, 2)
+	flickr.Expect(t, len(resp.Members.Members), 2)
+	flickr.Expect(t, resp.Members.Members[0].Nsid, "123456@N01")
+	flickr.Expect(t, resp.Members.Members[0].MemberType, MemberTypeMember)
+	flickr.Expect(t, resp.Members.Members[1].Username, "kewlchops666")
+	flickr.Expect(t, resp.Members.Members[1].MemberType, MemberTypeAdmin)
+}
+
+func TestGetListNotAdmin(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="fail"><err code="2" msg="Insufficient permissions" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetList(fclient, "34427469792@N01", "", 0, 0)
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.ErrorCode(), 2)
+	_, found := fclient.Args["membertypes"]
+	flickr.Expect(t, found, false)
+}
@@ -0,0 +1,97 @@
+package flickr
+
+import (
+	"sync"
+)
+
+// Values loaded once and kept for the lifetime of a client, shared by its copies
+type lazyValues struct {
+	sync.Mutex
+	entries map[string]*lazyEntry
+}
+
+// A value of lazyValues, its lock is held while loading so that concurrent callers
+// wait for the first load instead of performing their own
+type lazyEntry struct {
+	sync.Mutex
+	loaded bool
+	value  interface{}
+}
+
+func newLazyValues() *lazyValues {
+	return &lazyValues{entries: map[string]*lazyEntry{}}
+}
+
+// Values of the clients not created by NewFlickrClient
+var defaultLazyValues = newLazyValues()
+
+// Return the entry for key, creating it if needed
+func (v *lazyValues) entry(key string) *lazyEntry {
+	v.Lock()
+	defer v.Unlock()
+
+	e, found := v.entries[key]
+	if !found {
+		e = &lazyEntry{}
+		v.entries[key] = e
+	}
+	return e
+}
+
+func (v *lazyValues) forget(key string) {
+	v.Lock()
+	defer v.Unlock()
+
+	delete(v.entries, key)
+}
+
+func (v *lazyValues) clear() {
+	v.Lock()
+	defer v.Unlock()
+
+	v.entries = map[string]*lazyEntry{}
+}
+
+// Return the values of the client, the process wide ones for clients not created
+// by NewFlickrClient
+func (c *FlickrClient) lazyValues() *lazyValues {
+	if c.lazy == nil {
+		return defaultLazyValues
+	}
+	return c.lazy
+}
+
+// Return the value stored for key, calling load to get it the first time only: next
+// calls, from copies of the client too, get the same value until Forget or ResetCaches
+// are called. It is safe for concurrent use, callers asking for a key being loaded wait
+// for the outcome. Errors are not kept, the next call loads again. Meant for data that
+// hardly ever changes, like method descriptions or license lists, keys should start
+// with the name of the method the data come from. load must not ask for key itself.
+// Clients not created by NewFlickrClient share the same values.
+func (c *FlickrClient) Lazy(key string, load func() (interface{}, error)) (interface{}, error) {
+	e := c.lazyValues().entry(key)
+	e.Lock()
+	defer e.Unlock()
+
+	if !e.loaded {
+		value, err := load()
+		if err != nil {
+			return nil, err
+		}
+		e.value, e.loaded = value, true
+	}
+	return e.value, nil
+}
+
+// Drop the value stored by Lazy for key, the next call loads it again
+func (c *FlickrClient) Forget(key string) {
+	c.lazyValues().forget(key)
+}
+
+// Drop everything the client keeps from previous responses: values stored by Lazy,
+// cached responses and rate limit values. Useful after changing the credentials.
+func (c *FlickrClient) ResetCaches() {
+	c.lazyValues().clear()
+	c.ClearCache()
+	c.rateLimit.clear()
+}
@@ -0,0 +1,78 @@
+package flickr
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLazy(t *testing.T) {
+	client := NewFlickrClient("apikey", "apisecret")
+	var loads int32
+	load := func() (interface{}, error) {
+		atomic.AddInt32(&loads, 1)
+		time.Sleep(10 * time.Millisecond)
+		return "value", nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(c *FlickrClient) {
+			defer wg.Done()
+			v, err := c.Lazy("flickr.test.echo", load)
+			Expect(t, err, nil)
+			Expect(t, v, "value")
+			c.Lazy("flickr.test.other", func() (interface{}, error) { return 1, nil })
+			c.RateLimit()
+		}(client.copy())
+	}
+	wg.Wait()
+	Expect(t, atomic.LoadInt32(&loads), int32(1))
+
+	client.Forget("flickr.test.echo")
+	client.Lazy("flickr.test.echo", load)
+	Expect(t, atomic.LoadInt32(&loads), int32(2))
+
+	// errors are not kept
+	boom := errors.New("boom")
+	_, err := client.Lazy("flickr.test.fail", func() (interface{}, error) { return nil, boom })
+	Expect(t, err, boom)
+	v, err := client.Lazy("flickr.test.fail", func() (interface{}, error) { return "ok", nil })
+	Expect(t, err, nil)
+	Expect(t, v, "ok")
+
+	// values are kept per client
+	other := NewFlickrClient("apikey", "apisecret")
+	other.Lazy("flickr.test.echo", load)
+	Expect(t, atomic.LoadInt32(&loads), int32(3))
+}
+
+func TestResetCaches(t *testing.T) {
+	fclient := NewFlickrClient("apikey", "apisecret")
+	server, client := FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	fclient.SetCache(time.Minute)
+	fclient.rateLimit.record(http.Header{"X-Ratelimit-Limit": {"3600"}})
+	fclient.Lazy("flickr.test.echo", func() (interface{}, error) { return 1, nil })
+	fclient.Init()
+	fclient.Args.Set("method", "flickr.test.echo")
+	Expect(t, DoGet(fclient, &BasicResponse{}), nil)
+	_, cached := fclient.cache.get(fclient.cacheKey())
+	Expect(t, cached, true)
+
+	fclient.ResetCaches()
+	_, cached = fclient.cache.get(fclient.cacheKey())
+	Expect(t, cached, false)
+	limit, _, _ := fclient.RateLimit()
+	Expect(t, limit, 0)
+	v, _ := fclient.Lazy("flickr.test.echo", func() (interface{}, error) { return 2, nil })
+	Expect(t, v, 2)
+
+	// clients not created by NewFlickrClient
+	GetTestClient().ResetCaches()
+}
@@ -0,0 +1,47 @@
+package flickr
+
+import (
+	"fmt"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// Safety level of a photo, as set on upload or with flickr.photos.setSafetyLevel
+type SafetyLevel int
+
+const (
+	// Let Flickr use the user's default, the argument is not sent
+	NoSafetyLevelSpecified SafetyLevel = iota
+	SafetyLevelSafe
+	SafetyLevelModerate
+	SafetyLevelRestricted
+)
+
+// Return an ArgumentError if l is not a valid safety level, NoSafetyLevelSpecified is valid
+func (l SafetyLevel) Validate() error {
+	if l < NoSafetyLevelSpecified || l > SafetyLevelRestricted {
+		return flickErr.NewError(flickErr.ArgumentError,
+			fmt.Sprintf("unknown safety level %d, allowed values are 1 (safe), 2 (moderate), 3 (restricted)", int(l)))
+	}
+	return nil
+}
+
+// Content type of a photo, as set on upload or with flickr.photos.setContentType
+type ContentType int
+
+const (
+	// Let Flickr use the user's default, the argument is not sent
+	NoContentTypeSpecified ContentType = iota
+	ContentTypePhoto
+	ContentTypeScreenshot
+	ContentTypeOther
+)
+
+// Return an ArgumentError if c is not a valid content type, NoContentTypeSpecified is valid
+func (c ContentType) Validate() error {
+	if c < NoContentTypeSpecified || c > ContentTypeOther {
+		return flickErr.NewError(flickErr.ArgumentError,
+			fmt.Sprintf("unknown content type %d, allowed values are 1 (photo), 2 (screenshot), 3 (other)", int(c)))
+	}
+	return nil
+}
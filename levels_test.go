@@ -0,0 +1,29 @@
+package flickr
+
+import (
+	"testing"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestSafetyLevelValidate(t *testing.T) {
+	for _, l := range []SafetyLevel{NoSafetyLevelSpecified, SafetyLevelSafe, SafetyLevelModerate, SafetyLevelRestricted} {
+		Expect(t, l.Validate(), nil)
+	}
+	for _, l := range []SafetyLevel{-1, 4} {
+		ferr, ok := l.Validate().(*flickErr.Error)
+		Expect(t, ok, true)
+		Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	}
+}
+
+func TestContentTypeValidate(t *testing.T) {
+	for _, c := range []ContentType{NoContentTypeSpecified, ContentTypePhoto, ContentTypeScreenshot, ContentTypeOther} {
+		Expect(t, c.Validate(), nil)
+	}
+	for _, c := range []ContentType{-1, 4} {
+		ferr, ok := c.Validate().(*flickErr.Error)
+		Expect(t, ok, true)
+		Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	}
+}
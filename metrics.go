@@ -0,0 +1,100 @@
+package flickr
+
+import (
+	"time"
+)
+
+// Receives the outcome of each call performed with DoGet, DoPost, DoPostBody or
+// DoGetStream, see FlickrClient.Metrics. Calls are observed once, retries included.
+type Metrics interface {
+	// duration is the time spent in the call, err the error it returned
+	ObserveCall(method string, duration time.Duration, err error)
+}
+
+// Metrics also wanting the details of calls: ObserveCallDetails is called instead
+// of ObserveCall
+type DetailedMetrics interface {
+	Metrics
+	ObserveCallDetails(details CallDetails)
+}
+
+// Classification of the outcome of a call, suitable as a metric label
+type CallResult string
+
+const (
+	ResultOK CallResult = "ok"
+	// Flickr replied with an error, see CallDetails.ErrorCode
+	ResultFlickrError CallResult = "flickr-error"
+	// The request could not be sent, or no valid response was received
+	ResultTransportError CallResult = "transport-error"
+	// The call failed before sending any request: invalid arguments, dry run...
+	ResultLocalError CallResult = "local-error"
+)
+
+// Outcome of a call, as passed to DetailedMetrics
+type CallDetails struct {
+	// API method called, dotted notation
+	Method string
+	// Time spent in the call, retries and waits between them included
+	Duration time.Duration
+	// Time spent waiting for HTTP responses, summed over attempts
+	HTTPDuration time.Duration
+	// Number of requests sent, 0 when the response was served from the cache
+	Attempts int
+	Result   CallResult
+	// Error code sent by Flickr when Result is ResultFlickrError
+	ErrorCode int
+	// Error returned by the call
+	Err error
+}
+
+// Requests sent during the call being observed
+type callStats struct {
+	attempts int
+	http     time.Duration
+}
+
+func noObservation(*error) {}
+
+// Start observing a call whose response is unmarshalled in r, if client.Metrics is set.
+// The returned function reports the outcome of the call given its error, defer it.
+func (c *FlickrClient) observeCall(r FlickrResponse) func(*error) {
+	if c.Metrics == nil {
+		return noObservation
+	}
+	method := c.Args.Get("method")
+	start := time.Now()
+	stats := &callStats{}
+	c.callStats = stats
+
+	return func(errp *error) {
+		c.callStats = nil
+		details := CallDetails{
+			Method:       method,
+			Duration:     time.Since(start),
+			HTTPDuration: stats.http,
+			Attempts:     stats.attempts,
+			Err:          *errp,
+		}
+		details.Result, details.ErrorCode = classifyCall(r, *errp, stats.attempts)
+
+		if m, ok := c.Metrics.(DetailedMetrics); ok {
+			m.ObserveCallDetails(details)
+		} else {
+			c.Metrics.ObserveCall(method, details.Duration, *errp)
+		}
+	}
+}
+
+// Classify the outcome of a call, returning the Flickr error code if any
+func classifyCall(r FlickrResponse, err error, attempts int) (CallResult, int) {
+	switch {
+	case err == nil:
+		return ResultOK, 0
+	case r != nil && r.ErrorCode() != 0:
+		return ResultFlickrError, r.ErrorCode()
+	case attempts == 0:
+		return ResultLocalError, 0
+	}
+	return ResultTransportError, 0
+}
@@ -0,0 +1,89 @@
+package flickr
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+type fakeMetrics struct {
+	methods []string
+	errs    []error
+}
+
+func (m *fakeMetrics) ObserveCall(method string, duration time.Duration, err error) {
+	m.methods = append(m.methods, method)
+	m.errs = append(m.errs, err)
+}
+
+type fakeDetailedMetrics struct {
+	fakeMetrics
+	details []CallDetails
+}
+
+func (m *fakeDetailedMetrics) ObserveCallDetails(details CallDetails) {
+	m.details = append(m.details, details)
+}
+
+func TestMetrics(t *testing.T) {
+	fclient := GetTestClient()
+	server, client := FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	m := &fakeMetrics{}
+	fclient.Metrics = m
+
+	fclient.Args.Set("method", "flickr.test.echo")
+	Expect(t, DoGet(fclient, &BasicResponse{}), nil)
+	fclient.ForcePost = true
+	Expect(t, DoGet(fclient, &BasicResponse{}), nil)
+	// observed once, by DoPost
+	Expect(t, len(m.methods), 2)
+	Expect(t, m.methods[1], "flickr.test.echo")
+	Expect(t, m.errs[1], nil)
+	Expect(t, fclient.callStats == nil, true)
+}
+
+func TestMetricsDetails(t *testing.T) {
+	server, fclient, _ := retryServer(1, http.StatusInternalServerError)
+	defer server.Close()
+	fclient.MaxRetries = 1
+	m := &fakeDetailedMetrics{}
+	fclient.Metrics = m
+
+	Expect(t, DoGet(fclient, &BasicResponse{}), nil)
+	Expect(t, len(m.methods), 0)
+	Expect(t, len(m.details), 1)
+	d := m.details[0]
+	Expect(t, d.Method, "flickr.test.null")
+	Expect(t, d.Attempts, 2)
+	Expect(t, d.Result, ResultOK)
+	Expect(t, d.HTTPDuration > 0, true)
+	Expect(t, d.Duration >= d.HTTPDuration, true)
+
+	fclient2 := GetTestClient()
+	s, client := FlickrMock(200, `<rsp stat="fail"><err code="1" msg="Photo not found" /></rsp>`, "")
+	defer s.Close()
+	fclient2.HTTPClient = client
+	fclient2.Metrics = m
+	err := DoPost(fclient2, &BasicResponse{})
+	d = m.details[1]
+	Expect(t, d.Result, ResultFlickrError)
+	Expect(t, d.ErrorCode, 1)
+	Expect(t, d.Err, err)
+
+	fclient2.DryRun = true
+	DoGet(fclient2, &BasicResponse{})
+	Expect(t, m.details[2].Result, ResultLocalError)
+	Expect(t, m.details[2].Attempts, 0)
+
+	server.Close()
+	fclient.MaxRetries = 0
+	err = DoGet(fclient, &BasicResponse{})
+	_, ok := err.(*flickErr.Error)
+	Expect(t, ok, false)
+	Expect(t, m.details[3].Result, ResultTransportError)
+	Expect(t, m.details[3].Attempts, 1)
+}
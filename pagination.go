@@ -0,0 +1,162 @@
+package flickr
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// Pager is implemented by responses of paged list methods
+type Pager interface {
+	FlickrResponse
+	// Number of the page held by the response, starting from 1
+	Page() int
+	// Total number of pages available
+	Pages() int
+}
+
+// Set the pagination arguments of list methods: page is sent only when greater than 0,
+// perPage defaults to DEFAULT_PER_PAGE when it is not.
+func SetPageArgs(client *FlickrClient, page, perPage int) {
+	if page > 0 {
+		client.Args.Set("page", strconv.Itoa(page))
+	}
+	if perPage <= 0 {
+		perPage = DEFAULT_PER_PAGE
+	}
+	client.Args.Set("per_page", strconv.Itoa(perPage))
+}
+
+// Fetch pages from 1 to totalPages calling fetch from a pool of workers goroutines,
+// returning results in page order. The first error stops fetching remaining pages
+// and is returned along with a nil slice.
+// Since Args are stored in the client, fetch must not share a FlickrClient across calls.
+// Workers is the only bound on the number of requests in flight: to stop once the rate
+// limit quota is exhausted, have fetch return the error of FlickrClient.CheckRateLimit
+// before sending its request. A negative totalPages is an ArgumentError.
+func PaginateConcurrent(totalPages, workers int, fetch func(page int) (Pager, error)) ([]Pager, error) {
+	if totalPages < 0 {
+		return nil, flickErr.NewError(flickErr.ArgumentError, fmt.Sprintf("invalid number of pages %d", totalPages))
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]Pager, totalPages)
+	pages := make(chan int)
+	abort := make(chan struct{})
+	var once sync.Once
+	var firstErr error
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range pages {
+				select {
+				case <-abort:
+					continue
+				default:
+				}
+
+				res, err := fetch(page)
+				if err != nil {
+					once.Do(func() {
+						firstErr = err
+						close(abort)
+					})
+					continue
+				}
+				results[page-1] = res
+			}
+		}()
+	}
+
+dispatch:
+	for page := 1; page <= totalPages; page++ {
+		select {
+		case pages <- page:
+		case <-abort:
+			break dispatch
+		}
+	}
+	close(pages)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return results, nil
+}
+
+// Fetch pages in sequence, starting from 1, until the last page reported by the responses
+// is reached or maxPages pages were fetched (0 means no bound). When pages are left
+// unfetched because of maxPages truncated is true, and the pages fetched so far are
+// returned along with a nil error. The first error stops fetching and is returned
+// along with the pages fetched before it.
+func Paginate(maxPages int, fetch func(page int) (Pager, error)) (results []Pager, truncated bool, err error) {
+	for page := 1; ; page++ {
+		if maxPages > 0 && page > maxPages {
+			return results, true, nil
+		}
+		res, err := fetch(page)
+		if err != nil {
+			return results, false, err
+		}
+		results = append(results, res)
+		if page >= res.Pages() {
+			return results, false, nil
+		}
+	}
+}
+
+// Track the ids of the items walked through the pages of a list, to skip the ones
+// repeated on a later page: Flickr may return them twice when its index changes
+// during a long walk. Only the ids of the last Window pages are remembered, so that
+// memory is bounded by Window times the page size; a repeat further away than that
+// goes through. A larger window catches more of them at the cost of memory, Window
+// <= 0 remembers every id, for walks known to be small. Not safe for concurrent use.
+type Dedupe struct {
+	// Number of pages remembered, the current one included
+	Window int
+	// Number of repeated ids skipped so far
+	Skipped int
+	// one set of ids per page, the current one last
+	pages []map[string]struct{}
+}
+
+// Create a Dedupe remembering the ids of the last window pages
+func NewDedupe(window int) *Dedupe {
+	return &Dedupe{Window: window}
+}
+
+// Start a new page, forgetting the ids of the pages that fall outside the window
+func (d *Dedupe) NextPage() {
+	if d.Window <= 0 && len(d.pages) > 0 {
+		return
+	}
+	d.pages = append(d.pages, map[string]struct{}{})
+	if d.Window > 0 && len(d.pages) > d.Window {
+		d.pages[0] = nil
+		d.pages = d.pages[1:]
+	}
+}
+
+// Tell whether id was already seen within the window, counting it in Skipped. Ids not
+// seen yet are recorded in the current page.
+func (d *Dedupe) Seen(id string) bool {
+	if len(d.pages) == 0 {
+		d.NextPage()
+	}
+	for _, page := range d.pages {
+		if _, found := page[id]; found {
+			d.Skipped++
+			return true
+		}
+	}
+	d.pages[len(d.pages)-1][id] = struct{}{}
+	return false
+}
@@ -0,0 +1,168 @@
+package flickr
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+type fooPage struct {
+	BasicResponse
+	page, pages int
+}
+
+func (p *fooPage) Page() int  { return p.page }
+func (p *fooPage) Pages() int { return p.pages }
+
+func TestPaginateConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	fetched := map[int]bool{}
+	fetch := func(page int) (Pager, error) {
+		// later pages complete first
+		time.Sleep(time.Duration(20-page) * time.Millisecond)
+		mu.Lock()
+		fetched[page] = true
+		mu.Unlock()
+		return &fooPage{page: page, pages: 20}, nil
+	}
+
+	results, err := PaginateConcurrent(20, 4, fetch)
+	Expect(t, err, nil)
+	Expect(t, len(results), 20)
+	Expect(t, len(fetched), 20)
+	for i, res := range results {
+		Expect(t, res.Page(), i+1)
+	}
+
+	results, err = PaginateConcurrent(0, 4, fetch)
+	Expect(t, err, nil)
+	Expect(t, len(results), 0)
+
+	results, err = PaginateConcurrent(-1, 4, fetch)
+	ferr, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	Expect(t, results == nil, true)
+}
+
+func TestPaginateConcurrentRateLimit(t *testing.T) {
+	client := NewFlickrClient("apikey", "apisecret")
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "3600")
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+
+	fetched := 0
+	fetch := func(page int) (Pager, error) {
+		if err := client.CheckRateLimit(1); err != nil {
+			return nil, err
+		}
+		fetched++
+		// the quota runs out with the fourth page
+		header.Set("X-RateLimit-Remaining", strconv.Itoa(4-fetched))
+		client.rateLimit.record(header)
+		return &fooPage{page: page, pages: 10}, nil
+	}
+
+	results, err := PaginateConcurrent(10, 1, fetch)
+	Expect(t, errors.Is(err, ErrRateLimited), true)
+	Expect(t, results == nil, true)
+	Expect(t, fetched, 4)
+}
+
+func TestPaginateConcurrentError(t *testing.T) {
+	calls := 0
+	fetch := func(page int) (Pager, error) {
+		calls++
+		if page == 3 {
+			return nil, errors.New("boom")
+		}
+		return &fooPage{page: page, pages: 10}, nil
+	}
+
+	// a single worker fetches pages in sequence, nothing after page 3 is fetched
+	results, err := PaginateConcurrent(10, 1, fetch)
+	Expect(t, err.Error(), "boom")
+	Expect(t, results == nil, true)
+	Expect(t, calls, 3)
+}
+
+func TestSetPageArgs(t *testing.T) {
+	client := GetTestClient()
+	client.Init()
+	SetPageArgs(client, 0, 0)
+	_, found := client.Args["page"]
+	Expect(t, found, false)
+	Expect(t, client.Args.Get("per_page"), "100")
+
+	SetPageArgs(client, 3, 250)
+	Expect(t, client.Args.Get("page"), "3")
+	Expect(t, client.Args.Get("per_page"), "250")
+}
+
+func TestPaginate(t *testing.T) {
+	var fetched []int
+	fetch := func(page int) (Pager, error) {
+		fetched = append(fetched, page)
+		return &fooPage{page: page, pages: 5}, nil
+	}
+
+	results, truncated, err := Paginate(0, fetch)
+	Expect(t, err, nil)
+	Expect(t, truncated, false)
+	Expect(t, len(results), 5)
+	Expect(t, results[4].Page(), 5)
+
+	fetched = nil
+	results, truncated, err = Paginate(2, fetch)
+	Expect(t, err, nil)
+	Expect(t, truncated, true)
+	Expect(t, len(results), 2)
+	Expect(t, len(fetched), 2)
+	Expect(t, fetched[1], 2)
+
+	// bound not reached
+	results, truncated, err = Paginate(5, fetch)
+	Expect(t, truncated, false)
+	Expect(t, len(results), 5)
+
+	results, truncated, err = Paginate(0, func(page int) (Pager, error) {
+		if page == 2 {
+			return nil, errors.New("boom")
+		}
+		return &fooPage{page: page, pages: 5}, nil
+	})
+	Expect(t, err.Error(), "boom")
+	Expect(t, truncated, false)
+	Expect(t, len(results), 1)
+}
+
+func TestDedupe(t *testing.T) {
+	d := NewDedupe(2)
+	d.NextPage()
+	Expect(t, d.Seen("1"), false)
+	Expect(t, d.Seen("2"), false)
+	Expect(t, d.Seen("1"), true)
+	d.NextPage()
+	Expect(t, d.Seen("2"), true)
+	Expect(t, d.Seen("3"), false)
+	// page 1 is out of the window
+	d.NextPage()
+	Expect(t, d.Seen("1"), false)
+	Expect(t, d.Seen("3"), true)
+	Expect(t, d.Skipped, 3)
+	Expect(t, len(d.pages), 2)
+
+	// no window, pages are not needed
+	d = NewDedupe(0)
+	Expect(t, d.Seen("1"), false)
+	for i := 0; i < 10; i++ {
+		d.NextPage()
+	}
+	Expect(t, d.Seen("1"), true)
+	Expect(t, len(d.pages), 1)
+}
@@ -5,105 +5,15 @@ import (
 	"strconv"
 
 	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/photos"
 )
 
 type PhotoList struct {
-	Page    int `xml:"page,attr"`
-	Pages   int `xml:"pages,attr"`
-	PerPage int `xml:"perpage,attr"`
-	Total   int `xml:"total,attr"`
-	Photo   struct {
-		Id       string `xml:"id,attr"`
-		Owner    string `xml:"owner,attr"`
-		Secret   string `xml:"secret,attr"`
-		Server   string `xml:"server,attr"`
-		Farm     string `xml:"farm,attr"`
-		Title    string `xml:"title,attr"`
-		IsPublic bool   `xml:"ispublic,attr"`
-		IsFriend bool   `xml:"isfriend,attr"`
-		IsFamily bool   `xml:"isfamily,attr"`
-
-		// if extras contains "url_o" these are populated
-		UrlO    string `xml:"url_o,attr"`
-		HeightO int    `xml:"height_o,attr"`
-		WidthO  int    `xml:"width_o,attr"`
-
-		Description    string `xml:"description,attr"`
-		License        string `xml:"license,attr"`
-		DateUpload     string `xml:"date_upload,attr"`
-		DateTaken      string `xml:"date_taken,attr"`
-		OwnerName      string `xml:"owner_name,attr"`
-		IconServer     string `xml:"icon_server,attr"`
-		OriginalFormat string `xml:"original_format",attr`
-		LastUpdate     string `xml:"last_udpate",attr`
-
-		// Geo - these attributes are provided when extras contains "geo"
-		Latitude  string `xml:"latitude,attr"`
-		Longitude string `xml:"longitude,attr"`
-		Accuracy  string `xml:"accuracy,attr"`
-		Context   string `xml:"context,attr"`
-
-		// Tags - contains space-separated lists
-		Tags        string `xml:"tags,attr"`
-		MachineTags string `xml:"machine_tags,attr"`
-
-		// Original Dimensions - these attributes are provided
-		// when extras contains "o_dims"
-		OWidth  int `xml:"o_width,attr"`
-		OHeight int `xml:"o_height,attr"`
-
-		Views     int    `xml:"views,attr"`
-		Media     string `xml:"media,attr"`
-		PathAlias string `xml:"path_alias,attr"`
-
-		// Square Urls - these attributes are provided when
-		// extras contains "url_sq"
-		UrlSq    string `xml:"url_sq,attr"`
-		HeightSq int    `xml:"height_sq,attr"`
-		WidthSq  int    `xml:"width_sq,attr"`
-
-		// Thumbnail Urls - these attributes are provided
-		// when extras contains "url_t"
-		UrlT    string `xml:"url_t,attr"`
-		HeightT int    `xml:"height_t,attr"`
-		WidthT  int    `xml:"width_t,attr"`
-
-		// Q Urls - these attributes are provided when
-		// extras contains "url_s"
-		UrlS    string `xml:"url_s,attr"`
-		HeightS int    `xml:"height_s,attr"`
-		WidthS  int    `xml:"width_s,attr"`
-
-		// M Urls - these attributes are provided when
-		// extras contains "url_m"
-		UrlM    string `xml:"url_m,attr"`
-		HeightM int    `xml:"height_m,attr"`
-		WidthM  int    `xml:"width_m,attr"`
-
-		// N Urls - these attributes are provided when
-		// extras contains "url_n"
-		UrlN    string `xml:"url_n,attr"`
-		HeightN int    `xml:"height_n,attr"`
-		WidthN  int    `xml:"width_n,attr"`
-
-		// Z Urls - these attributes are provided when
-		// extras contains "url_z"
-		UrlZ    string `xml:"url_z,attr"`
-		HeightZ int    `xml:"height_z,attr"`
-		WidthZ  int    `xml:"width_z,attr"`
-
-		// C Urls - these attributes are provided when
-		// extras contains "url_c"
-		UrlC    string `xml:"url_c,attr"`
-		HeightC int    `xml:"height_c,attr"`
-		WidthC  int    `xml:"width_c,attr"`
-
-		// L Urls - these attributes are provided when
-		// extras contains "url_l"
-		UrlL    string `xml:"url_l,attr"`
-		HeightL int    `xml:"height_l,attr"`
-		WidthL  int    `xml:"width_l,attr"`
-	}
+	Page    int            `xml:"page,attr"`
+	Pages   int            `xml:"pages,attr"`
+	PerPage int            `xml:"perpage,attr"`
+	Total   int            `xml:"total,attr"`
+	Photo   []flickr.Photo `xml:"photo"`
 }
 
 type PhotoListResponse struct {
@@ -153,7 +63,7 @@ type GetPhotosOptionalArgs struct {
 	ContentType   ContentType       // optional, set to NoneSpecified to ignore
 	PrivacyFilter PrivacyFilterType // optional, set to NoneSpecified to ignore
 	Extras        string            // optional, set to "" to ignore. comma separated string.
-	PerPage       int               // 0 to ignore
+	PerPage       int               // 0 for flickr.DEFAULT_PER_PAGE
 	Page          int               // 0 to ignore
 }
 
@@ -184,12 +94,7 @@ func GetPhotos(client *flickr.FlickrClient,
 	if opts.PrivacyFilter != NoPrivacyFilterSpecified {
 		client.Args.Set("privacy_filter", strconv.Itoa(int(opts.PrivacyFilter)))
 	}
-	if opts.PerPage != 0 {
-		client.Args.Set("per_page", strconv.Itoa(opts.PerPage))
-	}
-	if opts.Page != 0 {
-		client.Args.Set("page", strconv.Itoa(opts.Page))
-	}
+	flickr.SetPageArgs(client, opts.Page, opts.PerPage)
 	if opts.Extras != "" {
 		client.Args.Set("extras", opts.Extras)
 	}
@@ -205,3 +110,118 @@ func GetPhotos(client *flickr.FlickrClient,
 	//	}
 	return response, err
 }
+
+// Returns a list of photos containing a particular Flickr member.
+// ownerId is optional and limits results to photos owned by that member, page and
+// extras are ignored when set to zero values, perPage defaults to flickr.DEFAULT_PER_PAGE.
+// This method does not require authentication, authenticate to also get the private
+// photos the calling user is allowed to see.
+func GetPhotosOf(client *flickr.FlickrClient, authenticate bool, userId, ownerId string, page, perPage int, extras string) (*photos.PhotosResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.people.getPhotosOf")
+	client.Args.Set("user_id", userId)
+	if ownerId != "" {
+		client.Args.Set("owner_id", ownerId)
+	}
+	flickr.SetPageArgs(client, page, perPage)
+	if extras != "" {
+		client.Args.Set("extras", extras)
+	}
+	if authenticate {
+		client.OAuthSign()
+	} else {
+		client.ApiSign()
+	}
+
+	response := &photos.PhotosResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// A group as listed by GetGroups
+type PersonGroup struct {
+	Nsid           string `xml:"nsid,attr"`
+	Name           string `xml:"name,attr"`
+	IconServer     string `xml:"iconserver,attr"`
+	IconFarm       int    `xml:"iconfarm,attr"`
+	Admin          bool   `xml:"admin,attr"`
+	EighteenPlus   bool   `xml:"eighteenplus,attr"`
+	InvitationOnly bool   `xml:"invitation_only,attr"`
+	Members        int    `xml:"members,attr"`
+	// Number of photos in the group pool
+	PoolCount int `xml:"pool_count,attr"`
+	// Only set when asked with the "privacy" extra: 1 private, 2 public by invite, 3 public
+	Privacy int `xml:"privacy,attr"`
+	// Posting limits, only set when asked with the "throttle" extra
+	Throttle struct {
+		Count int    `xml:"count,attr"`
+		Mode  string `xml:"mode,attr"`
+	} `xml:"throttle"`
+}
+
+type PersonGroupsResponse struct {
+	flickr.BasicResponse
+	Groups []PersonGroup `xml:"groups>group"`
+}
+
+// Returns the list of groups a user is a member of. extras is ignored when empty.
+// This method requires authentication with 'read' permission.
+func GetGroups(client *flickr.FlickrClient, userId string, extras string) (*PersonGroupsResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.people.getGroups")
+	client.Args.Set("user_id", userId)
+	if extras != "" {
+		client.Args.Set("extras", extras)
+	}
+	client.OAuthSign()
+
+	response := &PersonGroupsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+type UploadStatusResponse struct {
+	flickr.BasicResponse
+	User struct {
+		Id       string `xml:"id,attr"`
+		IsPro    bool   `xml:"ispro,attr"`
+		Username string `xml:"username"`
+		// Monthly upload bandwidth, in bytes
+		Bandwidth struct {
+			MaxBytes       int64 `xml:"maxbytes,attr"`
+			UsedBytes      int64 `xml:"usedbytes,attr"`
+			RemainingBytes int64 `xml:"remainingbytes,attr"`
+			Unlimited      bool  `xml:"unlimited,attr"`
+		} `xml:"bandwidth"`
+		// Maximum size of a photo, in bytes
+		FileSize struct {
+			MaxBytes int64 `xml:"maxbytes,attr"`
+		} `xml:"filesize"`
+		// Maximum size of a video, in bytes
+		VideoSize struct {
+			MaxBytes int64 `xml:"maxbytes,attr"`
+		} `xml:"videosize"`
+		Sets struct {
+			Created int `xml:"created,attr"`
+			// a number or "lots"
+			Remaining string `xml:"remaining,attr"`
+		} `xml:"sets"`
+		Videos struct {
+			Uploaded int `xml:"uploaded,attr"`
+			// a number or "lots"
+			Remaining string `xml:"remaining,attr"`
+		} `xml:"videos"`
+	} `xml:"user"`
+}
+
+// Returns information for the calling user related to photo uploads.
+// This method requires authentication with 'read' permission.
+func GetUploadStatus(client *flickr.FlickrClient) (*UploadStatusResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.people.getUploadStatus")
+	client.OAuthSign()
+
+	response := &UploadStatusResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
@@ -0,0 +1,108 @@
+package people
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+func TestGetPhotosOf(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photos page="1" pages="1" perpage="100" total="2">
+    <photo id="2636" owner="47058503995@N01" secret="a123456" server="2" farm="1" title="test_04" ispublic="1" isfriend="0" isfamily="0" />
+    <photo id="2635" owner="12037949754@N01" secret="b123456" server="2" farm="1" title="test_03" ispublic="1" isfriend="0" isfamily="0" />
+  </photos>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPhotosOf(fclient, false, "123456@N00", "", 1, 100, "")
+	flickr.Expect(t, err, nil)
+	_, signed := fclient.Args["oauth_signature"]
+	flickr.Expect(t, signed, false)
+	flickr.Expect(t, fclient.Args.Get("api_sig") != "", true)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.people.getPhotosOf")
+	flickr.Expect(t, fclient.Args.Get("user_id"), "123456@N00")
+	flickr.Expect(t, fclient.Args.Get("owner_id"), "")
+	flickr.Expect(t, fclient.Args.Get("per_page"), "100")
+	flickr.Expect(t, len(resp.Photos.Photos), 2)
+	flickr.Expect(t, resp.Photos.Photos[1].Owner, "12037949754@N01")
+
+	_, err = GetPhotosOf(fclient, true, "123456@N00", "", 1, 100, "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("oauth_signature") != "", true)
+}
+
+func TestGetGroups(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <groups>
+    <group nsid="17274427@N00" name="Cream of the Crop" iconfarm="1" iconserver="1" admin="0" eighteenplus="0" invitation_only="0" members="11935" pool_count="12522" privacy="3">
+      <throttle count="2" mode="day" />
+    </group>
+    <group nsid="34427469792@N01" name="FlickrCentral" iconfarm="2" iconserver="45" admin="1" eighteenplus="0" invitation_only="1" members="350" pool_count="41" privacy="1" />
+  </groups>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetGroups(fclient, "123456@N00", "privacy,throttle")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.people.getGroups")
+	flickr.Expect(t, fclient.Args.Get("user_id"), "123456@N00")
+	flickr.Expect(t, fclient.Args.Get("extras"), "privacy,throttle")
+	flickr.Expect(t, len(resp.Groups), 2)
+	g := resp.Groups[0]
+	flickr.Expect(t, g.Nsid, "17274427@N00")
+	flickr.Expect(t, g.Name, "Cream of the Crop")
+	flickr.Expect(t, g.Admin, false)
+	flickr.Expect(t, g.PoolCount, 12522)
+	flickr.Expect(t, g.Privacy, 3)
+	flickr.Expect(t, g.Throttle.Mode, "day")
+	g = resp.Groups[1]
+	flickr.Expect(t, g.Admin, true)
+	flickr.Expect(t, g.InvitationOnly, true)
+	flickr.Expect(t, g.IconServer, "45")
+	flickr.Expect(t, g.IconFarm, 2)
+
+	_, err = GetGroups(fclient, "123456@N00", "")
+	flickr.Expect(t, err, nil)
+	_, found := fclient.Args["extras"]
+	flickr.Expect(t, found, false)
+}
+
+func TestGetUploadStatus(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <user id="12037949754@N01" ispro="1">
+    <username>Bees</username>
+    <bandwidth maxbytes="2147483648" maxkb="2097152" usedbytes="383724" usedkb="374" remainingbytes="2147099924" remainingkb="2096777" />
+    <filesize maxbytes="10485760" maxkb="10240" />
+    <sets created="27" remaining="lots" />
+    <videosize maxbytes="1073741824" maxkb="1048576" />
+    <videos uploaded="5" remaining="lots" />
+  </user>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetUploadStatus(fclient)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.people.getUploadStatus")
+	u := resp.User
+	flickr.Expect(t, u.IsPro, true)
+	flickr.Expect(t, u.Username, "Bees")
+	flickr.Expect(t, u.Bandwidth.MaxBytes, int64(2147483648))
+	flickr.Expect(t, u.Bandwidth.UsedBytes, int64(383724))
+	flickr.Expect(t, u.Bandwidth.RemainingBytes, int64(2147099924))
+	flickr.Expect(t, u.Bandwidth.Unlimited, false)
+	flickr.Expect(t, u.FileSize.MaxBytes, int64(10485760))
+	flickr.Expect(t, u.VideoSize.MaxBytes, int64(1073741824))
+	flickr.Expect(t, u.Sets.Created, 27)
+	flickr.Expect(t, u.Sets.Remaining, "lots")
+	flickr.Expect(t, u.Videos.Uploaded, 5)
+}
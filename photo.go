@@ -0,0 +1,121 @@
+package flickr
+
+import (
+	"time"
+)
+
+// A photo as returned by list methods (search, getPhotos, photosets.getPhotos, etc).
+// Fields other than the basic ones are populated only when requested through
+// the extras argument, see the Extras type.
+type Photo struct {
+	Id        string `xml:"id,attr"`
+	Owner     string `xml:"owner,attr"`
+	Secret    string `xml:"secret,attr"`
+	Server    string `xml:"server,attr"`
+	Farm      string `xml:"farm,attr"`
+	Title     string `xml:"title,attr"`
+	IsPublic  bool   `xml:"ispublic,attr"`
+	IsFriend  bool   `xml:"isfriend,attr"`
+	IsFamily  bool   `xml:"isfamily,attr"`
+	IsPrimary bool   `xml:"isprimary,attr"`
+
+	Description    string `xml:"description"`
+	License        string `xml:"license,attr"`
+	DateUpload     string `xml:"dateupload,attr"`
+	DateTaken      string `xml:"datetaken,attr"`
+	OwnerName      string `xml:"ownername,attr"`
+	IconServer     string `xml:"iconserver,attr"`
+	IconFarm       string `xml:"iconfarm,attr"`
+	OriginalSecret string `xml:"originalsecret,attr"`
+	OriginalFormat string `xml:"originalformat,attr"`
+	LastUpdate     string `xml:"lastupdate,attr"`
+	Views          int    `xml:"views,attr"`
+	Media          string `xml:"media,attr"`
+	MediaStatus    string `xml:"media_status,attr"`
+	PathAlias      string `xml:"pathalias,attr"`
+	// Text relevance score of search results, zero when Flickr doesn't send it
+	Relevance float64 `xml:"relevance,attr"`
+
+	// Geo - these attributes are provided when extras contains "geo"
+	Latitude  string `xml:"latitude,attr"`
+	Longitude string `xml:"longitude,attr"`
+	Accuracy  string `xml:"accuracy,attr"`
+	Context   string `xml:"context,attr"`
+	PlaceId   string `xml:"place_id,attr"`
+	WoeId     string `xml:"woeid,attr"`
+
+	// Tags - contains space-separated lists
+	Tags        string `xml:"tags,attr"`
+	MachineTags string `xml:"machine_tags,attr"`
+
+	// Original Dimensions - these attributes are provided
+	// when extras contains "o_dims"
+	OWidth  int `xml:"o_width,attr"`
+	OHeight int `xml:"o_height,attr"`
+
+	// Urls and dimensions, provided when extras contains the matching "url_*" value
+	UrlSq    string `xml:"url_sq,attr"`
+	HeightSq int    `xml:"height_sq,attr"`
+	WidthSq  int    `xml:"width_sq,attr"`
+	UrlQ     string `xml:"url_q,attr"`
+	HeightQ  int    `xml:"height_q,attr"`
+	WidthQ   int    `xml:"width_q,attr"`
+	UrlT     string `xml:"url_t,attr"`
+	HeightT  int    `xml:"height_t,attr"`
+	WidthT   int    `xml:"width_t,attr"`
+	UrlS     string `xml:"url_s,attr"`
+	HeightS  int    `xml:"height_s,attr"`
+	WidthS   int    `xml:"width_s,attr"`
+	UrlN     string `xml:"url_n,attr"`
+	HeightN  int    `xml:"height_n,attr"`
+	WidthN   int    `xml:"width_n,attr"`
+	UrlM     string `xml:"url_m,attr"`
+	HeightM  int    `xml:"height_m,attr"`
+	WidthM   int    `xml:"width_m,attr"`
+	UrlZ     string `xml:"url_z,attr"`
+	HeightZ  int    `xml:"height_z,attr"`
+	WidthZ   int    `xml:"width_z,attr"`
+	UrlC     string `xml:"url_c,attr"`
+	HeightC  int    `xml:"height_c,attr"`
+	WidthC   int    `xml:"width_c,attr"`
+	UrlL     string `xml:"url_l,attr"`
+	HeightL  int    `xml:"height_l,attr"`
+	WidthL   int    `xml:"width_l,attr"`
+	UrlO     string `xml:"url_o,attr"`
+	HeightO  int    `xml:"height_o,attr"`
+	WidthO   int    `xml:"width_o,attr"`
+}
+
+// Return the upload date as a time.Time, zero if "date_upload" wasn't requested
+func (p *Photo) UploadTime() (time.Time, error) {
+	return ParseUnixDate(p.DateUpload)
+}
+
+// Return the date the photo was taken as a time.Time, zero if unknown or
+// "date_taken" wasn't requested
+func (p *Photo) TakenTime() (time.Time, error) {
+	return ParseTakenDate(p.DateTaken)
+}
+
+// Return the URL of the photo page, see PhotoPageURL. The owner path alias is set
+// only if "path_alias" was requested, without it the owner nsid is used.
+func (p *Photo) Permalink() string {
+	return PhotoPageURL(p.Owner, p.PathAlias, p.Id)
+}
+
+// Return the URLs of the standard sizes of the photo by size suffix, see SizeURLs
+func (p *Photo) SizeURLs() map[string]string {
+	return SizeURLs(p)
+}
+
+// Implements PhotoReferrer, original secret and format are set only if
+// "original_format" was requested
+func (p *Photo) PhotoRef() PhotoRef {
+	return PhotoRef{
+		Id:             p.Id,
+		Secret:         p.Secret,
+		Server:         p.Server,
+		OriginalSecret: p.OriginalSecret,
+		OriginalFormat: p.OriginalFormat,
+	}
+}
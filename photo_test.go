@@ -0,0 +1,69 @@
+package flickr
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func TestPhoto(t *testing.T) {
+	body := `<photo id="2636" owner="47058503995@N01" secret="a123456" server="2" farm="1" title="test_04"
+  ispublic="1" isfriend="0" isfamily="1" license="4" dateupload="1361132046" datetaken="2013-02-17 20:14:06"
+  ownername="Bees" iconserver="12" iconfarm="1" originalsecret="b123456" originalformat="jpg"
+  lastupdate="1361132100" latitude="45.4642" longitude="9.19" accuracy="16" context="0"
+  tags="cat kitten" machine_tags="geo:lat=45" o_width="4000" o_height="3000" views="42" media="photo"
+  pathalias="bees" url_sq="https://example.com/sq.jpg" height_sq="75" width_sq="75"
+  url_m="https://example.com/m.jpg" height_m="375" width_m="500"
+  url_o="https://example.com/o.jpg" height_o="3000" width_o="4000">
+  <description>A cat</description>
+</photo>`
+
+	p := Photo{}
+	err := xml.Unmarshal([]byte(body), &p)
+	Expect(t, err, nil)
+	Expect(t, p.Id, "2636")
+	Expect(t, p.Owner, "47058503995@N01")
+	Expect(t, p.IsPublic, true)
+	Expect(t, p.IsFriend, false)
+	Expect(t, p.IsFamily, true)
+	Expect(t, p.Description, "A cat")
+	Expect(t, p.License, "4")
+	Expect(t, p.OwnerName, "Bees")
+	Expect(t, p.IconServer, "12")
+	Expect(t, p.OriginalSecret, "b123456")
+	Expect(t, p.OriginalFormat, "jpg")
+	Expect(t, p.LastUpdate, "1361132100")
+	Expect(t, p.Latitude, "45.4642")
+	Expect(t, p.Accuracy, "16")
+	Expect(t, p.Tags, "cat kitten")
+	Expect(t, p.MachineTags, "geo:lat=45")
+	Expect(t, p.OWidth, 4000)
+	Expect(t, p.OHeight, 3000)
+	Expect(t, p.Views, 42)
+	Expect(t, p.Media, "photo")
+	Expect(t, p.PathAlias, "bees")
+	Expect(t, p.UrlSq, "https://example.com/sq.jpg")
+	Expect(t, p.WidthSq, 75)
+	Expect(t, p.UrlM, "https://example.com/m.jpg")
+	Expect(t, p.HeightM, 375)
+	Expect(t, p.UrlO, "https://example.com/o.jpg")
+	Expect(t, p.WidthO, 4000)
+	Expect(t, p.UrlL, "")
+
+	uploaded, err := p.UploadTime()
+	Expect(t, err, nil)
+	Expect(t, uploaded.Equal(time.Unix(1361132046, 0)), true)
+	taken, err := p.TakenTime()
+	Expect(t, err, nil)
+	Expect(t, taken.Equal(time.Date(2013, 2, 17, 20, 14, 6, 0, time.UTC)), true)
+}
+
+func TestPhotoPermalink(t *testing.T) {
+	p := Photo{}
+	xml.Unmarshal([]byte(`<photo id="2636" owner="47058503995@N01" secret="a123456" server="2" pathalias="bees" />`), &p)
+	Expect(t, p.Permalink(), "https://www.flickr.com/photos/bees/2636")
+
+	p = Photo{}
+	xml.Unmarshal([]byte(`<photo id="2636" owner="47058503995@N01" secret="a123456" server="2" />`), &p)
+	Expect(t, p.Permalink(), "https://www.flickr.com/photos/47058503995@N01/2636")
+}
@@ -0,0 +1,109 @@
+package photos
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Number of photos whose permissions are fetched by a single flickr.Batch in
+// AuditVisibility and AuditGeoVisibility, the rate limit is checked between batches
+const auditBatchSize = 50
+
+// Errors of the photos AuditVisibility and AuditGeoVisibility couldn't get permissions for, by photo id
+type AuditErrors map[string]error
+
+func (e AuditErrors) Error() string {
+	ids := make([]string, 0, len(e))
+	for id := range e {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return fmt.Sprintf("permissions of %d photos could not be fetched: %s", len(e), strings.Join(ids, ", "))
+}
+
+// Fetch the permissions of many photos, by photo id. Requests are performed with
+// flickr.Batch, so at most 4 of them are in flight at the same time. When the rate
+// limit reported by Flickr (see FlickrClient.RateLimit) is exhausted, remaining photos
+// are not requested.
+// A failure on a photo doesn't stop the audit: the permissions fetched are returned
+// along with an AuditErrors holding the error of each photo left out, nil if none.
+// This method requires authentication with 'read' permission.
+func AuditVisibility(client *flickr.FlickrClient, photoIds []string) (map[string]*PhotoPerms, error) {
+	perms := map[string]*PhotoPerms{}
+	err := audit(client, photoIds, "flickr.photos.getPerms",
+		func() flickr.FlickrResponse { return &PhotoPermsResponse{} },
+		func(id string, res flickr.FlickrResponse) { perms[id] = &res.(*PhotoPermsResponse).Perms })
+	if err != nil {
+		return perms, err
+	}
+	return perms, nil
+}
+
+// Same as AuditVisibility for the permissions of the location of the photos, which
+// are distinct from the photo ones, see geo.GetPerms.
+// This method requires authentication with 'read' permission.
+func AuditGeoVisibility(client *flickr.FlickrClient, photoIds []string) (map[string]*GeoPerms, error) {
+	perms := map[string]*GeoPerms{}
+	err := audit(client, photoIds, "flickr.photos.geo.getPerms",
+		func() flickr.FlickrResponse { return &GeoPermsResponse{} },
+		func(id string, res flickr.FlickrResponse) { perms[id] = &res.(*GeoPermsResponse).Perms })
+	if err != nil {
+		return perms, err
+	}
+	return perms, nil
+}
+
+// Call method for each photo in batches of auditBatchSize, bounded by the rate limit,
+// passing successful responses to keep. The errors of the photos left out are returned,
+// nil if none.
+func audit(client *flickr.FlickrClient, photoIds []string, method string, newResponse func() flickr.FlickrResponse, keep func(id string, res flickr.FlickrResponse)) error {
+	errs := AuditErrors{}
+
+	for len(photoIds) > 0 {
+		if err := client.CheckRateLimit(1); err != nil {
+			for _, id := range photoIds {
+				errs[id] = err
+			}
+			break
+		}
+		n := auditBatchSize
+		if limit, remaining, _ := client.RateLimit(); limit > 0 && remaining < n {
+			n = remaining
+		}
+		if n > len(photoIds) {
+			n = len(photoIds)
+		}
+		batch := photoIds[:n]
+		photoIds = photoIds[n:]
+
+		calls := make([]flickr.Call, len(batch))
+		for i, id := range batch {
+			calls[i] = flickr.Call{
+				Method:       method,
+				Args:         url.Values{"photo_id": {id}},
+				Authenticate: true,
+				Response:     newResponse(),
+			}
+		}
+		results, err := flickr.Batch(client, calls)
+		if err != nil {
+			return err
+		}
+		for i, res := range results {
+			if res.Err != nil {
+				errs[batch[i]] = res.Err
+				continue
+			}
+			keep(batch[i], res.Response)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
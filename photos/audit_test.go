@@ -0,0 +1,90 @@
+package photos
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+func TestAuditVisibility(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	remaining := 1000
+	server, fclient := flickr.FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		remaining--
+		w.Header().Set("X-RateLimit-Limit", "1000")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		mu.Unlock()
+
+		id := r.URL.Query().Get("photo_id")
+		if id == "missing" {
+			fmt.Fprint(w, `<rsp stat="fail"><err code="1" msg="Photo not found" /></rsp>`)
+			return
+		}
+		fmt.Fprintf(w, `<rsp stat="ok"><perms id="%s" ispublic="1" isfriend="0" isfamily="0" permcomment="3" permaddmeta="2" /></rsp>`, id)
+	}))
+	defer server.Close()
+
+	ids := []string{}
+	for i := 0; i < 120; i++ {
+		ids = append(ids, strconv.Itoa(i))
+	}
+	ids = append(ids, "missing")
+
+	perms, err := AuditVisibility(fclient, ids)
+	flickr.Expect(t, requests, 121)
+	flickr.Expect(t, len(perms), 120)
+	flickr.Expect(t, perms["42"].Id, "42")
+	flickr.Expect(t, perms["42"].IsPublic, true)
+	flickr.Expect(t, perms["42"].PermComment, PermEverybody)
+	auditErrs, ok := err.(AuditErrors)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, len(auditErrs), 1)
+	flickr.Expect(t, auditErrs["missing"] != nil, true)
+
+	// the quota runs out in the middle of the audit: concurrent responses may be
+	// recorded out of order, so the exact number of photos audited varies
+	mu.Lock()
+	requests, remaining = 0, 50
+	mu.Unlock()
+	perms, err = AuditVisibility(fclient, ids[:100])
+	auditErrs, ok = err.(AuditErrors)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, len(perms) >= 50 && len(perms) < 100, true)
+	flickr.Expect(t, len(perms)+len(auditErrs), 100)
+	flickr.Expect(t, requests, len(perms))
+}
+
+func TestAuditGeoVisibility(t *testing.T) {
+	server, fclient := flickr.FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flickr.Expect(t, r.URL.Query().Get("method"), "flickr.photos.geo.getPerms")
+		id := r.URL.Query().Get("photo_id")
+		if id == "missing" {
+			fmt.Fprint(w, `<rsp stat="fail"><err code="2" msg="Photo has no location information" /></rsp>`)
+			return
+		}
+		fmt.Fprintf(w, `<rsp stat="ok"><perms id="%s" ispublic="0" iscontact="1" isfriend="0" isfamily="0" /></rsp>`, id)
+	}))
+	defer server.Close()
+
+	perms, err := AuditGeoVisibility(fclient, []string{"1", "2", "missing"})
+	flickr.Expect(t, len(perms), 2)
+	flickr.Expect(t, perms["2"].Id, "2")
+	flickr.Expect(t, perms["2"].IsContact, true)
+	auditErrs, ok := err.(AuditErrors)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, len(auditErrs), 1)
+	flickr.Expect(t, auditErrs["missing"] != nil, true)
+
+	perms, err = AuditGeoVisibility(fclient, []string{"1"})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(perms), 1)
+}
@@ -0,0 +1,151 @@
+// Package implementing methods: flickr.photos.comments.*
+package comments
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/photos"
+)
+
+// A comment left on a photo or on a photoset
+type Comment struct {
+	Id string `xml:"id,attr"`
+	// Nsid of the author
+	Author     string `xml:"author,attr"`
+	AuthorName string `xml:"authorname,attr"`
+	RealName   string `xml:"realname,attr"`
+	// Set when the account of the author was deleted, the comment staying around
+	AuthorIsDeleted bool   `xml:"authorisdeleted,attr"`
+	PathAlias       string `xml:"path_alias,attr"`
+	IconServer      string `xml:"iconserver,attr"`
+	IconFarm        string `xml:"iconfarm,attr"`
+	DateCreate      string `xml:"datecreate,attr"`
+	Permalink       string `xml:"permalink,attr"`
+	Text            string `xml:",chardata"`
+}
+
+// Tell whether the comment was left by ownerNsid, the owner of the photo or photoset
+// commented, which the comment itself doesn't carry
+func (c *Comment) IsOwner(ownerNsid string) bool {
+	return ownerNsid != "" && c.Author == ownerNsid
+}
+
+// Return the creation date as a time.Time
+func (c *Comment) CreatedTime() (time.Time, error) {
+	return flickr.ParseUnixDate(c.DateCreate)
+}
+
+// Return the fragment of the permalink pointing to the comment within the page of the
+// commented photo or photoset (ie. "comment72157600294017589"), empty if unknown
+func (c *Comment) Anchor() string {
+	u, err := url.Parse(c.Permalink)
+	if err != nil {
+		return ""
+	}
+	return u.Fragment
+}
+
+// Response of the methods adding a comment, holding the id of the new comment and,
+// when Flickr sends them, its permalink and other details
+type AddCommentResponse struct {
+	flickr.BasicResponse
+	Comment Comment `xml:"comment"`
+}
+
+// Return the list of photos belonging to your contacts that have been commented on since sinceDate.
+// A zero sinceDate lets Flickr default to the last hour, page and extras are ignored
+// when set to zero values, perPage defaults to flickr.DEFAULT_PER_PAGE.
+// This method requires authentication with 'read' permission.
+func GetRecentForContacts(client *flickr.FlickrClient, sinceDate time.Time, page, perPage int, extras string) (*photos.PhotosResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.photos.comments.getRecentForContacts")
+	if !sinceDate.IsZero() {
+		client.Args.Set("date_lastcomment", strconv.FormatInt(sinceDate.Unix(), 10))
+	}
+	flickr.SetPageArgs(client, page, perPage)
+	if extras != "" {
+		client.Args.Set("extras", extras)
+	}
+	client.OAuthSign()
+
+	response := &photos.PhotosResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Add a comment to a photo as the calling user. Flickr comments are flat: there is no
+// way to reply to a given comment, link to it with the permalink of the new one instead.
+// This method requires authentication with 'write' permission.
+func AddComment(client *flickr.FlickrClient, photoId, text string) (*AddCommentResponse, error) {
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.comments.addComment")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("comment_text", text)
+	client.OAuthSign()
+
+	response := &AddCommentResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+type CommentsResponse struct {
+	flickr.BasicResponse
+	Comments struct {
+		PhotoId string `xml:"photo_id,attr"`
+		// Number of comments on the photo matching the dates, also when only the
+		// most recent ones are kept in Comments
+		Count    int       `xml:"count,attr"`
+		Comments []Comment `xml:"comment"`
+	} `xml:"comments"`
+}
+
+// Sort comments in chronological order, comments created at the same time by id
+func sortComments(comments []Comment) {
+	sort.SliceStable(comments, func(i, j int) bool {
+		di, _ := strconv.ParseInt(comments[i].DateCreate, 10, 64)
+		dj, _ := strconv.ParseInt(comments[j].DateCreate, 10, 64)
+		if di != dj {
+			return di < dj
+		}
+		return comments[i].Id < comments[j].Id
+	})
+}
+
+// Returns the comments for a photo, in chronological order. Flickr doesn't page comments
+// and returns all of them in a single response, minDate and maxDate (ignored if zero)
+// limit them by creation date. If last is greater than 0 only that number of the most recent
+// comments are kept, Comments.Count still holds the number of comments returned by Flickr.
+// This method does not require authentication.
+func GetList(client *flickr.FlickrClient, photoId string, minDate, maxDate time.Time, last int) (*CommentsResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.photos.comments.getList")
+	client.Args.Set("photo_id", photoId)
+	if !minDate.IsZero() {
+		client.Args.Set("min_comment_date", strconv.FormatInt(minDate.Unix(), 10))
+	}
+	if !maxDate.IsZero() {
+		client.Args.Set("max_comment_date", strconv.FormatInt(maxDate.Unix(), 10))
+	}
+	client.ApiSign()
+
+	response := &CommentsResponse{}
+	err := flickr.DoGet(client, response)
+	if err != nil {
+		return response, err
+	}
+
+	comments := response.Comments.Comments
+	if response.Comments.Count == 0 {
+		response.Comments.Count = len(comments)
+	}
+	sortComments(comments)
+	if last > 0 && len(comments) > last {
+		response.Comments.Comments = comments[len(comments)-last:]
+	}
+	return response, nil
+}
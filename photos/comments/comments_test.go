@@ -0,0 +1,120 @@
+package comments
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+func TestGetRecentForContacts(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photos page="1" pages="1" perpage="100" total="1">
+    <photo id="2636" owner="47058503995@N01" secret="a123456" server="2" farm="1" title="test_04" ispublic="1" isfriend="0" isfamily="0" />
+  </photos>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	since := time.Date(2013, 2, 17, 20, 14, 6, 0, time.UTC)
+	resp, err := GetRecentForContacts(fclient, since, 1, 100, "owner_name")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("date_lastcomment"), "1361132046")
+	flickr.Expect(t, fclient.Args.Get("extras"), "owner_name")
+	flickr.Expect(t, resp.Photos.Total, 1)
+	flickr.Expect(t, resp.Photos.Photos[0].Id, "2636")
+
+	_, err = GetRecentForContacts(fclient, time.Time{}, 0, 0, "")
+	flickr.Expect(t, err, nil)
+	_, found := fclient.Args["date_lastcomment"]
+	flickr.Expect(t, found, false)
+}
+
+func TestGetList(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <comments photo_id="109722179">
+    <comment id="6065-109722179-72057594077818641" author="35468159852@N01" authorname="Rev Dan Catt" datecreate="1141841470">Umm, I'm not sure, can I get back to you on that one?</comment>
+    <comment id="6065-109722179-72057594077818640" author="12037949754@N01" authorname="Bees" datecreate="1141841470">Same second</comment>
+    <comment id="6065-109722179-72057594077818639" author="12037949754@N01" authorname="Bees" datecreate="1141831470">First!</comment>
+  </comments>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetList(fclient, "109722179", time.Unix(1141831000, 0), time.Time{}, 0)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.comments.getList")
+	flickr.Expect(t, fclient.Args.Get("min_comment_date"), "1141831000")
+	_, found := fclient.Args["max_comment_date"]
+	flickr.Expect(t, found, false)
+	flickr.Expect(t, resp.Comments.PhotoId, "109722179")
+	flickr.Expect(t, resp.Comments.Count, 3)
+	flickr.Expect(t, resp.Comments.Comments[0].Text, "First!")
+	flickr.Expect(t, resp.Comments.Comments[1].Id, "6065-109722179-72057594077818640")
+	flickr.Expect(t, resp.Comments.Comments[2].AuthorName, "Rev Dan Catt")
+
+	resp, err = GetList(fclient, "109722179", time.Time{}, time.Time{}, 2)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Comments.Count, 3)
+	flickr.Expect(t, len(resp.Comments.Comments), 2)
+	flickr.Expect(t, resp.Comments.Comments[0].Text, "Same second")
+}
+
+func TestGetListAuthors(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <comments photo_id="109722179">
+    <comment id="6065-109722179-72057594077818641" author="35468159852@N01" authorname="Rev Dan Catt" realname="Daniel Catt" authorisdeleted="0" datecreate="1141841470">Thanks!</comment>
+    <comment id="6065-109722179-72057594077818639" author="12037949754@N01" authorname="Bees" authorisdeleted="1" datecreate="1141831470">Nice shot</comment>
+  </comments>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetList(fclient, "109722179", time.Time{}, time.Time{}, 0)
+	flickr.Expect(t, err, nil)
+	deleted, owner := resp.Comments.Comments[0], resp.Comments.Comments[1]
+	flickr.Expect(t, owner.IsOwner("35468159852@N01"), true)
+	flickr.Expect(t, owner.RealName, "Daniel Catt")
+	flickr.Expect(t, owner.AuthorIsDeleted, false)
+	flickr.Expect(t, deleted.IsOwner("35468159852@N01"), false)
+	flickr.Expect(t, deleted.Author, "12037949754@N01")
+	flickr.Expect(t, deleted.AuthorIsDeleted, true)
+	flickr.Expect(t, deleted.RealName, "")
+	flickr.Expect(t, deleted.IsOwner(""), false)
+}
+
+func TestAddComment(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <comment id="6065-109722179-72157600294017589" author="12037949754@N01" authorname="Bees" datecreate="1141841470" permalink="https://www.flickr.com/photos/bees/109722179/#comment72157600294017589">Nice!</comment>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := AddComment(fclient, "109722179", "Nice!")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.HTTPVerb, "POST")
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.comments.addComment")
+	flickr.Expect(t, fclient.Args.Get("photo_id"), "109722179")
+	flickr.Expect(t, fclient.Args.Get("comment_text"), "Nice!")
+	flickr.Expect(t, resp.Comment.Id, "6065-109722179-72157600294017589")
+	flickr.Expect(t, resp.Comment.Permalink, "https://www.flickr.com/photos/bees/109722179/#comment72157600294017589")
+	flickr.Expect(t, resp.Comment.Anchor(), "comment72157600294017589")
+
+	// only the id
+	server, client = flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok"><comment id="97777-109722179-72057594037942602" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	resp, err = AddComment(fclient, "109722179", "Nice!")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Comment.Id, "97777-109722179-72057594037942602")
+	flickr.Expect(t, resp.Comment.Anchor(), "")
+}
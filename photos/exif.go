@@ -0,0 +1,129 @@
+package photos
+
+import (
+	"gopkg.in/masci/flickr.v2"
+)
+
+// A single EXIF/TIFF/GPS tag of a photo
+type ExifTag struct {
+	TagSpace   string `xml:"tagspace,attr"`
+	TagSpaceId int    `xml:"tagspaceid,attr"`
+	Tag        string `xml:"tag,attr"`
+	Label      string `xml:"label,attr"`
+	Raw        string `xml:"raw"`
+	// Human readable value, not always available
+	Clean string `xml:"clean"`
+}
+
+// Return the human readable value if available, the raw one otherwise
+func (t *ExifTag) Value() string {
+	if t.Clean != "" {
+		return t.Clean
+	}
+	return t.Raw
+}
+
+type ExifResponse struct {
+	flickr.BasicResponse
+	Photo struct {
+		Id     string    `xml:"id,attr"`
+		Secret string    `xml:"secret,attr"`
+		Server string    `xml:"server,attr"`
+		Farm   string    `xml:"farm,attr"`
+		Camera string    `xml:"camera,attr"`
+		Exif   []ExifTag `xml:"exif"`
+	} `xml:"photo"`
+	// Whether Flickr may have withheld private tags, like GPS position or serial
+	// numbers, which it only sends to the owner of the photo: a missing tag of a
+	// partial response doesn't mean the photo lacks it. See GetExifWithOwner.
+	Partial bool `xml:"-"`
+}
+
+// Return the first tag with the given name, nil if missing
+func (r *ExifResponse) Tag(name string) *ExifTag {
+	for i := range r.Photo.Exif {
+		if r.Photo.Exif[i].Tag == name {
+			return &r.Photo.Exif[i]
+		}
+	}
+	return nil
+}
+
+// Retrieves a list of EXIF/TIFF/GPS tags for a given photo. The calling user must have
+// permission to view the photo; secret is optional and lets access private photos.
+// The call is authenticated when the client holds an access token. The owner of the
+// photo is unknown here, so the response is always Partial: use GetExifWithOwner to
+// tell the owner gets all the tags.
+// EXIF data hardly ever changes, responses can be cached by enabling the client cache
+// with SetCache.
+// This method does not require authentication.
+func GetExif(client *flickr.FlickrClient, id string, secret string) (*ExifResponse, error) {
+	return GetExifWithOwner(client, id, secret, "")
+}
+
+// Same as GetExif for a photo owned by the user with ownerNsid, as found in PhotoInfo
+// for instance. The response is not Partial when the call is authenticated as that
+// user, i.e. client.Id, set by GetAccessToken, is ownerNsid.
+// This method does not require authentication.
+func GetExifWithOwner(client *flickr.FlickrClient, id, secret, ownerNsid string) (*ExifResponse, error) {
+	authenticate := client.OAuthToken != ""
+
+	client.Init()
+	client.Args.Set("method", "flickr.photos.getExif")
+	client.Args.Set("photo_id", id)
+	if secret != "" {
+		client.Args.Set("secret", secret)
+	}
+	if authenticate {
+		client.OAuthSign()
+	} else {
+		client.ApiSign()
+	}
+
+	response := &ExifResponse{}
+	err := flickr.DoGet(client, response)
+	response.Partial = !authenticate || ownerNsid == "" || client.Id != ownerNsid
+	return response, err
+}
+
+// Summary of the camera settings a photo was taken with, fields are empty when the
+// related tag is missing
+type CameraInfo struct {
+	// EXIF tags Make and Model
+	Make, Model string
+	// EXIF tag LensModel, falling back to Lens
+	LensModel string
+	// EXIF tags FocalLength, FNumber, ExposureTime and ISO
+	FocalLength, FNumber, ExposureTime, ISO string
+}
+
+// Fetch the EXIF tags of a photo with GetExif and extract the camera settings.
+// A zero CameraInfo is returned when the EXIF data of the photo was stripped.
+// Tags are read by name from ExifResponse, use GetExif and ExifResponse.Tag to
+// extract other ones.
+// This method does not require authentication.
+func GetCamera(client *flickr.FlickrClient, id string, secret string) (*CameraInfo, error) {
+	exif, err := GetExif(client, id, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	var value = func(names ...string) string {
+		for _, name := range names {
+			if tag := exif.Tag(name); tag != nil {
+				return tag.Value()
+			}
+		}
+		return ""
+	}
+
+	return &CameraInfo{
+		Make:         value("Make"),
+		Model:        value("Model"),
+		LensModel:    value("LensModel", "Lens"),
+		FocalLength:  value("FocalLength"),
+		FNumber:      value("FNumber"),
+		ExposureTime: value("ExposureTime"),
+		ISO:          value("ISO"),
+	}, nil
+}
@@ -0,0 +1,147 @@
+package photos
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+const exifBody = `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photo id="4424" secret="06b8e43bc7" server="2" farm="1" camera="Canon EOS 400D">
+    <exif tagspace="TIFF" tagspaceid="1" tag="Make" label="Make">
+      <raw>Canon</raw>
+    </exif>
+    <exif tagspace="TIFF" tagspaceid="1" tag="Model" label="Model">
+      <raw>Canon EOS 400D DIGITAL</raw>
+    </exif>
+    <exif tagspace="ExifIFD" tagspaceid="0" tag="ExposureTime" label="Exposure">
+      <raw>1/60</raw>
+      <clean>0.017 sec (1/60)</clean>
+    </exif>
+    <exif tagspace="ExifIFD" tagspaceid="0" tag="FNumber" label="Aperture">
+      <raw>4.0</raw>
+      <clean>f/4.0</clean>
+    </exif>
+    <exif tagspace="ExifIFD" tagspaceid="0" tag="ISO" label="ISO Speed">
+      <raw>400</raw>
+    </exif>
+    <exif tagspace="XMP-aux" tagspaceid="0" tag="Lens" label="Lens">
+      <raw>EF-S18-55mm f/3.5-5.6</raw>
+    </exif>
+  </photo>
+</rsp>`
+
+func TestGetExif(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, exifBody, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetExif(fclient, "4424", "06b8e43bc7")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.getExif")
+	flickr.Expect(t, fclient.Args.Get("secret"), "06b8e43bc7")
+	flickr.Expect(t, resp.Photo.Camera, "Canon EOS 400D")
+	flickr.Expect(t, len(resp.Photo.Exif), 6)
+	flickr.Expect(t, resp.Tag("FNumber").Raw, "4.0")
+	flickr.Expect(t, resp.Tag("FNumber").Value(), "f/4.0")
+	flickr.Expect(t, resp.Tag("Flash") == nil, true)
+
+	_, err = GetExif(fclient, "4424", "")
+	flickr.Expect(t, err, nil)
+	_, found := fclient.Args["secret"]
+	flickr.Expect(t, found, false)
+}
+
+func TestGetExifWithOwner(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photo id="4424" secret="06b8e43bc7" server="2" farm="1" camera="Canon EOS 400D">
+    <exif tagspace="TIFF" tagspaceid="1" tag="Make" label="Make">
+      <raw>Canon</raw>
+    </exif>
+    <exif tagspace="ExifIFD" tagspaceid="0" tag="SerialNumber" label="Serial Number">
+      <raw>1420523906</raw>
+    </exif>
+    <exif tagspace="GPS" tagspaceid="0" tag="GPSLatitude" label="GPS Latitude">
+      <raw>45 deg 27' 50.40" N</raw>
+    </exif>
+  </photo>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	fclient.OAuthToken = "token"
+	fclient.Id = "12037949754@N01"
+
+	resp, err := GetExifWithOwner(fclient, "4424", "", "12037949754@N01")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Partial, false)
+	flickr.Expect(t, fclient.Args.Get("oauth_token"), "token")
+	flickr.Expect(t, resp.Tag("GPSLatitude").Raw, `45 deg 27' 50.40" N`)
+
+	resp, err = GetExifWithOwner(fclient, "4424", "", "98765432@N01")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Partial, true)
+	resp, err = GetExif(fclient, "4424", "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Partial, true)
+
+	// private tags withheld
+	server, client = flickr.FlickrMock(200, exifBody, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	fclient.OAuthToken = ""
+	resp, err = GetExifWithOwner(fclient, "4424", "", "12037949754@N01")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Partial, true)
+	flickr.Expect(t, resp.Tag("GPSLatitude") == nil, true)
+	_, found := fclient.Args["oauth_token"]
+	flickr.Expect(t, found, false)
+}
+
+func TestGetCamera(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, exifBody, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	camera, err := GetCamera(fclient, "4424", "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, *camera, CameraInfo{
+		Make:         "Canon",
+		Model:        "Canon EOS 400D DIGITAL",
+		LensModel:    "EF-S18-55mm f/3.5-5.6",
+		FocalLength:  "",
+		FNumber:      "f/4.0",
+		ExposureTime: "0.017 sec (1/60)",
+		ISO:          "400",
+	})
+}
+
+func TestGetCameraStripped(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok"><photo id="4424" secret="06b8e43bc7" server="2" farm="1"></photo></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	camera, err := GetCamera(fclient, "4424", "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, *camera, CameraInfo{})
+}
+
+func TestGetCameraKo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="fail"><err code="2" msg="Permission denied" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	camera, err := GetCamera(fclient, "4424", "")
+	flickr.Expect(t, camera == nil, true)
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+}
@@ -0,0 +1,114 @@
+package photos
+
+import (
+	"net/url"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// A place in the location hierarchy (neighbourhood, locality, county, region, country)
+type GeoPlace struct {
+	PlaceId string `xml:"place_id,attr"`
+	WoeId   string `xml:"woeid,attr"`
+	Name    string `xml:",chardata"`
+}
+
+// Geo data of a photo, as returned by flickr.photos.geo.getLocation
+type GeoLocation struct {
+	Latitude      float64  `xml:"latitude,attr"`
+	Longitude     float64  `xml:"longitude,attr"`
+	Accuracy      int      `xml:"accuracy,attr"`
+	Context       int      `xml:"context,attr"`
+	PlaceId       string   `xml:"place_id,attr"`
+	WoeId         string   `xml:"woeid,attr"`
+	Neighbourhood GeoPlace `xml:"neighbourhood"`
+	Locality      GeoPlace `xml:"locality"`
+	County        GeoPlace `xml:"county"`
+	Region        GeoPlace `xml:"region"`
+	Country       GeoPlace `xml:"country"`
+}
+
+// Response of flickr.photos.geo.getLocation, see geo.GetLocation. It is defined here
+// for GetFull, since the geo package imports this one.
+type LocationResponse struct {
+	flickr.BasicResponse
+	Photo struct {
+		Id       string      `xml:"id,attr"`
+		Location GeoLocation `xml:"location"`
+	} `xml:"photo"`
+}
+
+// Who can see the location of a photo, as returned by flickr.photos.geo.getPerms.
+// It is defined here for AuditGeoVisibility, since the geo package imports this one.
+type GeoPerms struct {
+	Id        string `xml:"id,attr"`
+	IsPublic  bool   `xml:"ispublic,attr"`
+	IsContact bool   `xml:"iscontact,attr"`
+	IsFriend  bool   `xml:"isfriend,attr"`
+	IsFamily  bool   `xml:"isfamily,attr"`
+}
+
+type GeoPermsResponse struct {
+	flickr.BasicResponse
+	Perms GeoPerms `xml:"perms"`
+}
+
+// Error code of getSizes, getExif and geo.getLocation when the owner doesn't share
+// the data with the caller, or the photo has no location
+const permissionDeniedCode = 2
+
+// Everything about a photo, as assembled by GetFull. Sections the caller is not
+// allowed to see are nil.
+type FullPhoto struct {
+	Info     *PhotoInfo
+	Sizes    []Size
+	Exif     []ExifTag
+	Location *GeoLocation
+}
+
+// Fetch the info, sizes, EXIF data and location of a photo with concurrent calls (see
+// flickr.Batch). Sizes, EXIF data and location calls failing because the owner doesn't
+// share them, or the photo has no location, leave the section nil; any other failure
+// is returned, the first one in that order after the info one. If the rate limit
+// doesn't allow all the calls, a *flickr.RateLimitError is returned without sending
+// any of them. The secret can be empty, as for GetInfo.
+// This method requires authentication with 'read' permission.
+func GetFull(client *flickr.FlickrClient, photoId, secret string) (*FullPhoto, error) {
+	args := url.Values{"photo_id": {photoId}}
+	withSecret := url.Values{"photo_id": {photoId}}
+	if secret != "" {
+		withSecret.Set("secret", secret)
+	}
+	calls := []flickr.Call{
+		{Method: "flickr.photos.getInfo", Args: withSecret, Authenticate: true, Response: &PhotoInfoResponse{}},
+		{Method: "flickr.photos.getSizes", Args: args, Authenticate: true, Response: &SizesResponse{}},
+		{Method: "flickr.photos.getExif", Args: withSecret, Authenticate: true, Response: &ExifResponse{}},
+		{Method: "flickr.photos.geo.getLocation", Args: args, Authenticate: true, Response: &LocationResponse{}},
+	}
+
+	if err := client.CheckRateLimit(len(calls)); err != nil {
+		return nil, err
+	}
+
+	results, err := flickr.Batch(client, calls)
+	if err != nil {
+		return nil, err
+	}
+	for i, res := range results {
+		if res.Err != nil && (i == 0 || res.Response.ErrorCode() != permissionDeniedCode) {
+			return nil, res.Err
+		}
+	}
+
+	full := &FullPhoto{Info: &results[0].Response.(*PhotoInfoResponse).Photo}
+	if results[1].Err == nil {
+		full.Sizes = results[1].Response.(*SizesResponse).Sizes.Sizes
+	}
+	if results[2].Err == nil {
+		full.Exif = results[2].Response.(*ExifResponse).Photo.Exif
+	}
+	if results[3].Err == nil {
+		full.Location = &results[3].Response.(*LocationResponse).Photo.Location
+	}
+	return full, nil
+}
@@ -0,0 +1,115 @@
+package photos
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// Return a client talking to a server replying to each method with the given body
+func fullServer(bodies map[string]string, header http.Header) (*httptest.Server, *flickr.FlickrClient, *[]string) {
+	var mu sync.Mutex
+	var secrets []string
+	server, fclient := flickr.FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		method := r.URL.Query().Get("method")
+		mu.Lock()
+		secrets = append(secrets, method+"="+r.URL.Query().Get("secret"))
+		mu.Unlock()
+		fmt.Fprint(w, bodies[method])
+	}))
+	// create the rate limit state shared by Batch calls
+	fclient.Init()
+	return server, fclient, &secrets
+}
+
+func TestGetFull(t *testing.T) {
+	bodies := map[string]string{
+		"flickr.photos.getInfo": `<rsp stat="ok"><photo id="2733" secret="123456" server="12"><title>orford castle</title></photo></rsp>`,
+		"flickr.photos.getSizes": `<rsp stat="ok"><sizes canblog="1" canprint="1" candownload="1">
+  <size label="Square" width="75" height="75" source="https://live.staticflickr.com/12/2733_123456_s.jpg" media="photo" />
+  <size label="Large" width="1024" height="768" source="https://live.staticflickr.com/12/2733_123456_b.jpg" media="photo" />
+</sizes></rsp>`,
+		"flickr.photos.getExif":         `<rsp stat="ok"><photo id="2733" camera="Canon EOS 5D"><exif tagspace="IFD0" tag="Make" label="Make"><raw>Canon</raw></exif></photo></rsp>`,
+		"flickr.photos.geo.getLocation": `<rsp stat="ok"><photo id="2733"><location latitude="52.09" longitude="1.53" accuracy="16"><locality>Orford</locality></location></photo></rsp>`,
+	}
+	server, fclient, secrets := fullServer(bodies, nil)
+	defer server.Close()
+
+	full, err := GetFull(fclient, "2733", "123456")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, full.Info.Title, "orford castle")
+	flickr.Expect(t, len(full.Sizes), 2)
+	flickr.Expect(t, full.Sizes[1].Label, "Large")
+	flickr.Expect(t, len(full.Exif), 1)
+	flickr.Expect(t, full.Exif[0].Value(), "Canon")
+	flickr.Expect(t, full.Location.Latitude, 52.09)
+	flickr.Expect(t, full.Location.Locality.Name, "Orford")
+	flickr.Expect(t, len(*secrets), 4)
+	for _, s := range *secrets {
+		switch s {
+		case "flickr.photos.getInfo=123456", "flickr.photos.getExif=123456", "flickr.photos.getSizes=", "flickr.photos.geo.getLocation=":
+		default:
+			t.Error("unexpected call", s)
+		}
+	}
+
+	// no geo data, EXIF hidden by the owner
+	bodies["flickr.photos.geo.getLocation"] = `<rsp stat="fail"><err code="2" msg="Photo has no location information." /></rsp>`
+	bodies["flickr.photos.getExif"] = `<rsp stat="fail"><err code="2" msg="Permission denied" /></rsp>`
+	server, fclient, _ = fullServer(bodies, nil)
+	defer server.Close()
+
+	full, err = GetFull(fclient, "2733", "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, full.Info.Id, "2733")
+	flickr.Expect(t, len(full.Sizes), 2)
+	flickr.Expect(t, full.Exif == nil, true)
+	flickr.Expect(t, full.Location == nil, true)
+}
+
+func TestGetFullKo(t *testing.T) {
+	bodies := map[string]string{
+		"flickr.photos.getInfo":         `<rsp stat="ok"><photo id="2733" /></rsp>`,
+		"flickr.photos.getSizes":        `<rsp stat="fail"><err code="98" msg="Invalid auth token" /></rsp>`,
+		"flickr.photos.getExif":         `<rsp stat="ok"><photo id="2733" /></rsp>`,
+		"flickr.photos.geo.getLocation": `<rsp stat="ok"><photo id="2733" /></rsp>`,
+	}
+	server, fclient, _ := fullServer(bodies, nil)
+	defer server.Close()
+
+	full, err := GetFull(fclient, "2733", "")
+	flickr.Expect(t, full == nil, true)
+	ferr, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ferr.Message, "Flickr API returned an error: Invalid auth token")
+
+	// permission gaps on info are hard errors
+	bodies["flickr.photos.getSizes"] = bodies["flickr.photos.getExif"]
+	bodies["flickr.photos.getInfo"] = `<rsp stat="fail"><err code="2" msg="Permission denied" /></rsp>`
+	server, fclient, _ = fullServer(bodies, nil)
+	defer server.Close()
+	_, err = GetFull(fclient, "2733", "")
+	flickr.Expect(t, err != nil, true)
+
+	// rate limit exhausted
+	bodies["flickr.photos.getInfo"] = `<rsp stat="ok"><photo id="2733" /></rsp>`
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "100")
+	header.Set("X-RateLimit-Remaining", "3")
+	server, fclient, secrets := fullServer(bodies, header)
+	defer server.Close()
+	_, err = GetFull(fclient, "2733", "")
+	flickr.Expect(t, err, nil)
+	_, err = GetFull(fclient, "2733", "")
+	flickr.Expect(t, errors.Is(err, flickr.ErrRateLimited), true)
+	flickr.Expect(t, len(*secrets), 4)
+}
@@ -0,0 +1,205 @@
+// Package implementing methods: flickr.photos.geo.*
+package geo
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+	"gopkg.in/masci/flickr.v2/photos"
+)
+
+// A place in the location hierarchy (neighbourhood, locality, county, region, country)
+type Place = photos.GeoPlace
+
+type GeoLocation = photos.GeoLocation
+
+type LocationResponse = photos.LocationResponse
+
+// Who can see the location of a photo
+type GeoPerms = photos.GeoPerms
+
+type GeoPermsResponse = photos.GeoPermsResponse
+
+// Check coordinates are within the ranges accepted by Flickr
+func validateCoords(lat, lon float64) error {
+	if math.IsNaN(lat) || math.IsNaN(lon) || math.IsInf(lat, 0) || math.IsInf(lon, 0) {
+		return flickErr.NewError(flickErr.ArgumentError, fmt.Sprintf("invalid coordinates %v, %v", lat, lon))
+	}
+	if lat < -90 || lat > 90 {
+		return flickErr.NewError(flickErr.ArgumentError, fmt.Sprintf("latitude %v out of range [-90, 90]", lat))
+	}
+	if lon < -180 || lon > 180 {
+		return flickErr.NewError(flickErr.ArgumentError, fmt.Sprintf("longitude %v out of range [-180, 180]", lon))
+	}
+	return nil
+}
+
+// Check accuracy is within the range accepted by Flickr, 0 is accepted only if optional
+func validateAccuracy(accuracy int, optional bool) error {
+	if (accuracy == 0 && optional) || (accuracy >= 1 && accuracy <= 16) {
+		return nil
+	}
+	return flickErr.NewError(flickErr.ArgumentError, fmt.Sprintf("accuracy %d out of range [1, 16]", accuracy))
+}
+
+// Set lat, lon and accuracy args, omitting accuracy when 0
+func setCoordsArgs(client *flickr.FlickrClient, lat, lon float64, accuracy int) {
+	client.Args.Set("lat", strconv.FormatFloat(lat, 'f', -1, 64))
+	client.Args.Set("lon", strconv.FormatFloat(lon, 'f', -1, 64))
+	if accuracy != 0 {
+		client.Args.Set("accuracy", strconv.Itoa(accuracy))
+	}
+}
+
+// Set the geo data (latitude and longitude and, optionally, the accuracy level) for a photo.
+// accuracy ranges from 1 (world level) to 16 (street level), pass 0 to let Flickr default to 16.
+// This method requires authentication with 'write' permission.
+func SetLocation(client *flickr.FlickrClient, photoId string, lat, lon float64, accuracy int) (*flickr.BasicResponse, error) {
+	if err := validateCoords(lat, lon); err != nil {
+		return nil, err
+	}
+	if err := validateAccuracy(accuracy, true); err != nil {
+		return nil, err
+	}
+
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.geo.setLocation")
+	client.Args.Set("photo_id", photoId)
+	setCoordsArgs(client, lat, lon, accuracy)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Get the geo data (latitude and longitude and the accuracy level) for a photo,
+// along with the place hierarchy when Flickr was able to resolve it.
+// This method does not require authentication.
+func GetLocation(client *flickr.FlickrClient, photoId string) (*LocationResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.photos.geo.getLocation")
+	client.Args.Set("photo_id", photoId)
+	client.ApiSign()
+
+	response := &LocationResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Removes the geo data associated with a photo.
+// This method requires authentication with 'write' permission.
+func RemoveLocation(client *flickr.FlickrClient, photoId string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.geo.removeLocation")
+	client.Args.Set("photo_id", photoId)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Set who can see the location of a photo, independently of who can see the photo
+// itself. All the flags are sent, false ones included.
+// This method requires authentication with 'write' permission.
+func SetPerms(client *flickr.FlickrClient, photoId string, isPublic, isContact, isFriend, isFamily bool) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.geo.setPerms")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("is_public", boolString(isPublic))
+	client.Args.Set("is_contact", boolString(isContact))
+	client.Args.Set("is_friend", boolString(isFriend))
+	client.Args.Set("is_family", boolString(isFamily))
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Get who can see the location of a photo, see photos.AuditGeoVisibility to check
+// many photos at once.
+// This method requires authentication with 'read' permission.
+func GetPerms(client *flickr.FlickrClient, photoId string) (*GeoPermsResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.photos.geo.getPerms")
+	client.Args.Set("photo_id", photoId)
+	client.OAuthSign()
+
+	response := &GeoPermsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Flickr expects booleans as "0" or "1"
+func boolString(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// Return a list of the calling user's photos taken at the given location, accuracy
+// defaults to 16 (street level) when 0. Zero values for page and extras let Flickr
+// use its defaults, perPage defaults to flickr.DEFAULT_PER_PAGE.
+// This method requires authentication with 'read' permission.
+func PhotosForLocation(client *flickr.FlickrClient, lat, lon float64, accuracy int, extras string, page, perPage int) (*photos.PhotosResponse, error) {
+	if err := validateCoords(lat, lon); err != nil {
+		return nil, err
+	}
+	if err := validateAccuracy(accuracy, true); err != nil {
+		return nil, err
+	}
+
+	client.Init()
+	client.Args.Set("method", "flickr.photos.geo.photosForLocation")
+	setCoordsArgs(client, lat, lon, accuracy)
+	if extras != "" {
+		client.Args.Set("extras", extras)
+	}
+	flickr.SetPageArgs(client, page, perPage)
+	client.OAuthSign()
+
+	response := &photos.PhotosResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Correct the places hierarchy for all the photos of the calling user at the given
+// location, setting it to the place identified by either placeId or woeId.
+// Flickr requires the accuracy of the location, ranging from 1 to 16.
+// This method requires authentication with 'write' permission.
+func BatchCorrectLocation(client *flickr.FlickrClient, lat, lon float64, accuracy int, placeId, woeId string) (*flickr.BasicResponse, error) {
+	if err := validateCoords(lat, lon); err != nil {
+		return nil, err
+	}
+	if err := validateAccuracy(accuracy, false); err != nil {
+		return nil, err
+	}
+	if placeId == "" && woeId == "" {
+		return nil, flickErr.NewError(flickErr.ArgumentError, "one of placeId and woeId is required")
+	}
+
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.geo.batchCorrectLocation")
+	setCoordsArgs(client, lat, lon, accuracy)
+	if placeId != "" {
+		client.Args.Set("place_id", placeId)
+	}
+	if woeId != "" {
+		client.Args.Set("woe_id", woeId)
+	}
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
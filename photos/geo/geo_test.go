@@ -0,0 +1,184 @@
+package geo
+
+import (
+	"math"
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestSetLocation(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := SetLocation(fclient, "123456", 45.4642, 9.19, 11)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.HasErrors(), false)
+	flickr.Expect(t, fclient.Args.Get("lat"), "45.4642")
+	flickr.Expect(t, fclient.Args.Get("lon"), "9.19")
+	flickr.Expect(t, fclient.Args.Get("accuracy"), "11")
+
+	_, err = SetLocation(fclient, "123456", 45.4642, 9.19, 0)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("accuracy"), "")
+}
+
+func TestSetLocationInvalid(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	for _, c := range []struct {
+		lat, lon float64
+		accuracy int
+	}{
+		{91, 0, 0},
+		{-90.5, 0, 0},
+		{0, 180.1, 0},
+		{0, -181, 0},
+		{0, 0, 17},
+		{math.NaN(), 0, 0},
+		{0, math.Inf(1), 0},
+	} {
+		resp, err := SetLocation(fclient, "123456", c.lat, c.lon, c.accuracy)
+		flickr.Expect(t, resp == nil, true)
+		ferr, ok := err.(*flickErr.Error)
+		flickr.Expect(t, ok, true)
+		flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	}
+}
+
+func TestGetLocation(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photo id="123456">
+    <location latitude="-17.685281" longitude="-149.514322" accuracy="16" context="0" place_id="cKbWTyuaAJiWCx5GSg" woeid="56052692">
+      <neighbourhood place_id="Ks_CBTiaAJjT_X.lSQ" woeid="56052692">Faaa</neighbourhood>
+      <locality place_id="a3TCBTiaAJjl_WiI" woeid="12489">Papeete</locality>
+      <region place_id="amXCQY6aAJkOIAI.Qw" woeid="28740">Windward Islands</region>
+      <country place_id="xso6fu2YAJhw2N3EXw" woeid="23424817">French Polynesia</country>
+    </location>
+  </photo>
+</rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetLocation(fclient, "123456")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Photo.Id, "123456")
+	loc := resp.Photo.Location
+	flickr.Expect(t, loc.Latitude, -17.685281)
+	flickr.Expect(t, loc.Longitude, -149.514322)
+	flickr.Expect(t, loc.Accuracy, 16)
+	flickr.Expect(t, loc.Neighbourhood.Name, "Faaa")
+	flickr.Expect(t, loc.Locality.Name, "Papeete")
+	flickr.Expect(t, loc.Locality.WoeId, "12489")
+	flickr.Expect(t, loc.County.Name, "")
+	flickr.Expect(t, loc.Country.Name, "French Polynesia")
+}
+
+func TestRemoveLocation(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"><err code="2" msg="Photo has no location information" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := RemoveLocation(fclient, "123456")
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.ErrorCode(), 2)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.geo.removeLocation")
+}
+
+func TestPhotosForLocation(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photos page="1" pages="1" perpage="100" total="1">
+    <photo id="2636" owner="47058503995@N01" secret="a123456" server="2" farm="1" title="test_04" ispublic="1" isfriend="0" isfamily="0" />
+  </photos>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := PhotosForLocation(fclient, 45.4642, 9.19, 16, "geo", 0, 100)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.geo.photosForLocation")
+	flickr.Expect(t, fclient.Args.Get("lat"), "45.4642")
+	flickr.Expect(t, fclient.Args.Get("accuracy"), "16")
+	flickr.Expect(t, fclient.Args.Get("extras"), "geo")
+	flickr.Expect(t, fclient.Args.Get("per_page"), "100")
+	_, found := fclient.Args["page"]
+	flickr.Expect(t, found, false)
+	flickr.Expect(t, resp.Photos.Photos[0].Id, "2636")
+
+	_, err = PhotosForLocation(fclient, 95, 9.19, 16, "", 0, 0)
+	ferr, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+}
+
+func TestBatchCorrectLocation(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := BatchCorrectLocation(fclient, 45.4642, 9.19, 16, "", "718345")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.HTTPVerb, "POST")
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.geo.batchCorrectLocation")
+	flickr.Expect(t, fclient.Args.Get("woe_id"), "718345")
+	_, found := fclient.Args["place_id"]
+	flickr.Expect(t, found, false)
+
+	for _, c := range []struct {
+		accuracy       int
+		placeId, woeId string
+	}{
+		{0, "abc", ""},
+		{16, "", ""},
+	} {
+		resp, err := BatchCorrectLocation(fclient, 45.4642, 9.19, c.accuracy, c.placeId, c.woeId)
+		flickr.Expect(t, resp == nil, true)
+		ferr, ok := err.(*flickErr.Error)
+		flickr.Expect(t, ok, true)
+		flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	}
+}
+
+func TestSetPerms(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := SetPerms(fclient, "123456", false, true, false, true)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.HTTPVerb, "POST")
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.geo.setPerms")
+	flickr.Expect(t, fclient.Args.Get("photo_id"), "123456")
+	flickr.Expect(t, fclient.Args.Get("is_public"), "0")
+	flickr.Expect(t, fclient.Args.Get("is_contact"), "1")
+	flickr.Expect(t, fclient.Args.Get("is_friend"), "0")
+	flickr.Expect(t, fclient.Args.Get("is_family"), "1")
+}
+
+func TestGetPerms(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <perms id="10592" ispublic="0" iscontact="0" isfriend="0" isfamily="1" />
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPerms(fclient, "10592")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.geo.getPerms")
+	flickr.Expect(t, resp.Perms.Id, "10592")
+	flickr.Expect(t, resp.Perms.IsPublic, false)
+	flickr.Expect(t, resp.Perms.IsContact, false)
+	flickr.Expect(t, resp.Perms.IsFamily, true)
+}
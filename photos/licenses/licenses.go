@@ -0,0 +1,127 @@
+// Package implementing methods: flickr.photos.licenses.*
+package licenses
+
+import (
+	"strconv"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Ids of the licenses available on Flickr, as returned by GetInfo
+const (
+	AllRightsReserved = 0
+	CCBYNCSA          = 1 // Attribution-NonCommercial-ShareAlike
+	CCBYNC            = 2 // Attribution-NonCommercial
+	CCBYNCND          = 3 // Attribution-NonCommercial-NoDerivs
+	CCBY              = 4 // Attribution
+	CCBYSA            = 5 // Attribution-ShareAlike
+	CCBYND            = 6 // Attribution-NoDerivs
+	NoKnownCopyright  = 7 // No known copyright restrictions
+	USGovernmentWork  = 8 // United States Government Work
+	CC0               = 9 // Public Domain Dedication
+	PublicDomainMark  = 10
+)
+
+type License struct {
+	Id   int    `xml:"id,attr"`
+	Name string `xml:"name,attr"`
+	Url  string `xml:"url,attr"`
+}
+
+type LicensesResponse struct {
+	flickr.BasicResponse
+	Licenses []License `xml:"licenses>license"`
+}
+
+// Fetches a list of available photo licenses for Flickr.
+// This method does not require authentication.
+func GetInfo(client *flickr.FlickrClient) (*LicensesResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.photos.licenses.getInfo")
+	client.ApiSign()
+
+	response := &LicensesResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Key of the licenses stored with FlickrClient.Lazy
+const lazyKey = "flickr.photos.licenses.getInfo"
+
+// Return the available licenses by id, calling GetInfo the first time only: the list is
+// the same for everybody and hardly ever changes, it is kept by the client until
+// Refresh or FlickrClient.ResetCaches are called.
+// A copy of the client is used, so its Args are left untouched.
+// This method does not require authentication.
+func Licenses(client *flickr.FlickrClient) (map[int]License, error) {
+	licenses, err := client.Lazy(lazyKey, func() (interface{}, error) {
+		return fetch(client)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copyLicenses(licenses.(map[int]License)), nil
+}
+
+// Fetch the licenses again, replacing the ones kept by Licenses.
+// This method does not require authentication.
+func Refresh(client *flickr.FlickrClient) (map[int]License, error) {
+	client.Forget(lazyKey)
+	return Licenses(client)
+}
+
+// Return the name of the license with the given id, as found in getInfo or search
+// results. Ids missing from the cached licenses make it fetch them again, an empty
+// string is returned if the id is still unknown or fetching fails.
+// This method does not require authentication.
+func Name(client *flickr.FlickrClient, id int) string {
+	licenses, err := Licenses(client)
+	if err != nil {
+		return ""
+	}
+	if _, found := licenses[id]; !found {
+		if licenses, err = Refresh(client); err != nil {
+			return ""
+		}
+	}
+	return licenses[id].Name
+}
+
+// Return the licenses returned by GetInfo, by id
+func fetch(client *flickr.FlickrClient) (map[int]License, error) {
+	c := *client
+	response, err := GetInfo(&c)
+	if err != nil {
+		return nil, err
+	}
+
+	licenses := map[int]License{}
+	for _, l := range response.Licenses {
+		licenses[l.Id] = l
+	}
+	return licenses, nil
+}
+
+// Return a copy of licenses, the stored ones are shared
+func copyLicenses(licenses map[int]License) map[int]License {
+	ret := make(map[int]License, len(licenses))
+	for id, l := range licenses {
+		ret[id] = l
+	}
+	return ret
+}
+
+// Sets the license for a photo.
+// This method requires authentication with 'write' permission.
+func SetLicense(client *flickr.FlickrClient, photoId string, licenseId int) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.licenses.setLicense")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("license_id", strconv.Itoa(licenseId))
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
@@ -0,0 +1,130 @@
+package licenses
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestGetInfo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <licenses>
+    <license id="0" name="All Rights Reserved" url="" />
+    <license id="4" name="Attribution License" url="https://creativecommons.org/licenses/by/2.0/" />
+    <license id="9" name="Public Domain Dedication (CC0)" url="https://creativecommons.org/publicdomain/zero/1.0/" />
+  </licenses>
+</rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetInfo(fclient)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(resp.Licenses), 3)
+	flickr.Expect(t, resp.Licenses[0].Id, AllRightsReserved)
+	flickr.Expect(t, resp.Licenses[0].Url, "")
+	flickr.Expect(t, resp.Licenses[1].Id, CCBY)
+	flickr.Expect(t, resp.Licenses[1].Name, "Attribution License")
+	flickr.Expect(t, resp.Licenses[2].Id, CC0)
+	flickr.Expect(t, resp.Licenses[2].Url, "https://creativecommons.org/publicdomain/zero/1.0/")
+}
+
+func TestSetLicense(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := SetLicense(fclient, "123456", CCBYSA)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.HasErrors(), false)
+	flickr.Expect(t, fclient.Args.Get("license_id"), "5")
+
+	server, client = flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"><err code="2" msg="License not found" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	resp, err = SetLicense(fclient, "123456", 99)
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.ErrorCode(), 2)
+}
+
+func TestLicensesCache(t *testing.T) {
+	calls := 0
+	body := `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <licenses>
+    <license id="0" name="All Rights Reserved" url="" />
+    <license id="4" name="Attribution License" url="https://creativecommons.org/licenses/by/2.0/" />
+  </licenses>
+</rsp>`
+	server, fclient := flickr.FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+	fclient.Args.Set("method", "flickr.photos.getInfo")
+
+	licenses, err := Refresh(fclient)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(licenses), 2)
+	flickr.Expect(t, calls, 1)
+
+	licenses, err = Licenses(fclient)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, licenses[CCBY].Name, "Attribution License")
+	flickr.Expect(t, Name(fclient, AllRightsReserved), "All Rights Reserved")
+	flickr.Expect(t, calls, 1)
+	// client Args are left untouched
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.getInfo")
+
+	// unknown id, licenses are fetched again
+	flickr.Expect(t, Name(fclient, CC0), "")
+	flickr.Expect(t, calls, 2)
+
+	body = `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <licenses>
+    <license id="9" name="Public Domain Dedication (CC0)" url="https://creativecommons.org/publicdomain/zero/1.0/" />
+  </licenses>
+</rsp>`
+	flickr.Expect(t, Name(fclient, CC0), "Public Domain Dedication (CC0)")
+	flickr.Expect(t, calls, 3)
+
+	// the returned map is a copy
+	licenses[CC0] = License{Name: "changed"}
+	flickr.Expect(t, Name(fclient, CC0), "Public Domain Dedication (CC0)")
+}
+
+func TestLicensesConcurrent(t *testing.T) {
+	var calls int32
+	server, fclient := flickr.FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok"><licenses><license id="4" name="Attribution License" url="" /></licenses></rsp>`))
+	}))
+	defer server.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			licenses, err := Licenses(fclient)
+			flickr.Expect(t, err, nil)
+			flickr.Expect(t, licenses[CCBY].Name, "Attribution License")
+			flickr.Expect(t, Name(fclient, CCBY), "Attribution License")
+		}()
+	}
+	wg.Wait()
+	flickr.Expect(t, atomic.LoadInt32(&calls), int32(1))
+
+	fclient.ResetCaches()
+	Licenses(fclient)
+	flickr.Expect(t, atomic.LoadInt32(&calls), int32(2))
+}
@@ -0,0 +1,94 @@
+// Package implementing methods: flickr.photos.notes.*
+package notes
+
+import (
+	"fmt"
+	"strconv"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// Notes coordinates refer to the medium (500px) size of the photo
+const MaxCoord = 500
+
+type AddNoteResponse struct {
+	flickr.BasicResponse
+	Note struct {
+		Id string `xml:"id,attr"`
+	} `xml:"note"`
+}
+
+// Check the note rectangle fits in the medium size of the photo and the text is not empty
+func validateNote(x, y, w, h int, text string) error {
+	if x < 0 || y < 0 || w <= 0 || h <= 0 || x+w > MaxCoord || y+h > MaxCoord {
+		return flickErr.NewError(flickErr.ArgumentError,
+			fmt.Sprintf("note rectangle (%d, %d, %d, %d) does not fit in %dx%d", x, y, w, h, MaxCoord, MaxCoord))
+	}
+	if text == "" {
+		return flickErr.NewError(flickErr.ArgumentError, "note text is empty")
+	}
+	return nil
+}
+
+// Set the rectangle and the text of a note
+func setNoteArgs(client *flickr.FlickrClient, x, y, w, h int, text string) {
+	client.Args.Set("note_x", strconv.Itoa(x))
+	client.Args.Set("note_y", strconv.Itoa(y))
+	client.Args.Set("note_w", strconv.Itoa(w))
+	client.Args.Set("note_h", strconv.Itoa(h))
+	client.Args.Set("note_text", text)
+}
+
+// Add a note to a photo. Coordinates and sizes are in pixels, based on the 500px
+// image size shown on individual photo pages.
+// This method requires authentication with 'write' permission.
+func Add(client *flickr.FlickrClient, photoId string, x, y, w, h int, text string) (*AddNoteResponse, error) {
+	if err := validateNote(x, y, w, h, text); err != nil {
+		return nil, err
+	}
+
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.notes.add")
+	client.Args.Set("photo_id", photoId)
+	setNoteArgs(client, x, y, w, h, text)
+	client.OAuthSign()
+
+	response := &AddNoteResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Edit a note on a photo, coordinates and sizes are the same as in Add.
+// This method requires authentication with 'write' permission.
+func Edit(client *flickr.FlickrClient, noteId string, x, y, w, h int, text string) (*flickr.BasicResponse, error) {
+	if err := validateNote(x, y, w, h, text); err != nil {
+		return nil, err
+	}
+
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.notes.edit")
+	client.Args.Set("note_id", noteId)
+	setNoteArgs(client, x, y, w, h, text)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Delete a note from a photo.
+// This method requires authentication with 'write' permission.
+func Delete(client *flickr.FlickrClient, noteId string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.notes.delete")
+	client.Args.Set("note_id", noteId)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
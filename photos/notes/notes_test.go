@@ -0,0 +1,82 @@
+package notes
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestAdd(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok"><note id="1234" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := Add(fclient, "123456", 10, 20, 50, 40, "a cat")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.HTTPVerb, "POST")
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.notes.add")
+	flickr.Expect(t, fclient.Args.Get("photo_id"), "123456")
+	flickr.Expect(t, fclient.Args.Get("note_x"), "10")
+	flickr.Expect(t, fclient.Args.Get("note_y"), "20")
+	flickr.Expect(t, fclient.Args.Get("note_w"), "50")
+	flickr.Expect(t, fclient.Args.Get("note_h"), "40")
+	flickr.Expect(t, fclient.Args.Get("note_text"), "a cat")
+	flickr.Expect(t, resp.Note.Id, "1234")
+}
+
+func TestAddInvalid(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	for _, c := range []struct {
+		x, y, w, h int
+		text       string
+	}{
+		{-1, 0, 10, 10, "text"},
+		{0, -1, 10, 10, "text"},
+		{0, 0, 0, 10, "text"},
+		{0, 0, 10, 0, "text"},
+		{450, 0, 51, 10, "text"},
+		{0, 490, 10, 11, "text"},
+		{0, 0, 10, 10, ""},
+	} {
+		resp, err := Add(fclient, "123456", c.x, c.y, c.w, c.h, c.text)
+		flickr.Expect(t, resp == nil, true)
+		ferr, ok := err.(*flickErr.Error)
+		flickr.Expect(t, ok, true)
+		flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	}
+}
+
+func TestEdit(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := Edit(fclient, "1234", 0, 0, 500, 500, "the whole photo")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.notes.edit")
+	flickr.Expect(t, fclient.Args.Get("note_id"), "1234")
+	flickr.Expect(t, fclient.Args.Get("note_w"), "500")
+
+	_, err = Edit(fclient, "1234", 0, 0, 501, 500, "too large")
+	ferr, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+}
+
+func TestDelete(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="fail"><err code="1" msg="Note not found" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := Delete(fclient, "1234")
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.ErrorCode(), 1)
+	flickr.Expect(t, fclient.Args.Get("note_id"), "1234")
+}
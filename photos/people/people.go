@@ -0,0 +1,107 @@
+// Package implementing methods: flickr.photos.people.*
+package people
+
+import (
+	"fmt"
+	"strconv"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// A person tagged in a photo, the bounding box is only present when set
+type Person struct {
+	Nsid       string `xml:"nsid,attr"`
+	Username   string `xml:"username,attr"`
+	Realname   string `xml:"realname,attr"`
+	IconServer string `xml:"iconserver,attr"`
+	IconFarm   string `xml:"iconfarm,attr"`
+	AddedBy    string `xml:"added_by,attr"`
+	X          int    `xml:"x,attr"`
+	Y          int    `xml:"y,attr"`
+	W          int    `xml:"w,attr"`
+	H          int    `xml:"h,attr"`
+}
+
+type PhotoPeopleResponse struct {
+	flickr.BasicResponse
+	People struct {
+		Total   int      `xml:"total,attr"`
+		Persons []Person `xml:"person"`
+	} `xml:"people"`
+}
+
+// Get a list of people in a given photo.
+// This method does not require authentication.
+func GetList(client *flickr.FlickrClient, photoId string) (*PhotoPeopleResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.photos.people.getList")
+	client.Args.Set("photo_id", photoId)
+	client.ApiSign()
+
+	response := &PhotoPeopleResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Add a person to a photo. The bounding box around the person is sent only when
+// both w and h are greater than zero, otherwise the person is added without coordinates.
+// This method requires authentication with 'write' permission.
+func Add(client *flickr.FlickrClient, photoId, userId string, x, y, w, h int) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.people.add")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("user_id", userId)
+	if w > 0 && h > 0 {
+		client.Args.Set("person_x", strconv.Itoa(x))
+		client.Args.Set("person_y", strconv.Itoa(y))
+		client.Args.Set("person_w", strconv.Itoa(w))
+		client.Args.Set("person_h", strconv.Itoa(h))
+	}
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Move the bounding box around a person in a photo, keeping the person tag. x and y
+// must not be negative and the box must have a non-zero area, otherwise an
+// ArgumentError is returned before sending the request.
+// This method requires authentication with 'write' permission.
+func EditCoords(client *flickr.FlickrClient, photoId, userId string, x, y, w, h int) (*flickr.BasicResponse, error) {
+	if x < 0 || y < 0 || w <= 0 || h <= 0 {
+		return nil, flickErr.NewError(flickErr.ArgumentError, fmt.Sprintf("invalid bounding box %d,%d %dx%d", x, y, w, h))
+	}
+
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.people.editCoords")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("user_id", userId)
+	client.Args.Set("person_x", strconv.Itoa(x))
+	client.Args.Set("person_y", strconv.Itoa(y))
+	client.Args.Set("person_w", strconv.Itoa(w))
+	client.Args.Set("person_h", strconv.Itoa(h))
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Remove a person from a photo.
+// This method requires authentication with 'write' permission.
+func Delete(client *flickr.FlickrClient, photoId, userId string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.people.delete")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("user_id", userId)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
@@ -0,0 +1,93 @@
+package people
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestGetList(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <people total="2">
+    <person nsid="87944415@N00" username="hitherto" iconserver="1" iconfarm="1" realname="Simon Batistoni" added_by="12037949754@N01" x="50" y="50" w="100" h="100" />
+    <person nsid="12037949754@N01" username="bees" iconserver="2" iconfarm="1" realname="" added_by="12037949754@N01" />
+  </people>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetList(fclient, "123456")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.People.Total, 2)
+	flickr.Expect(t, len(resp.People.Persons), 2)
+	p := resp.People.Persons[0]
+	flickr.Expect(t, p.Nsid, "87944415@N00")
+	flickr.Expect(t, p.Username, "hitherto")
+	flickr.Expect(t, p.Realname, "Simon Batistoni")
+	flickr.Expect(t, p.X, 50)
+	flickr.Expect(t, p.H, 100)
+	p = resp.People.Persons[1]
+	flickr.Expect(t, p.Realname, "")
+	flickr.Expect(t, p.W, 0)
+}
+
+func TestAdd(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := Add(fclient, "123456", "87944415@N00", 0, 10, 100, 120)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("person_x"), "0")
+	flickr.Expect(t, fclient.Args.Get("person_y"), "10")
+	flickr.Expect(t, fclient.Args.Get("person_w"), "100")
+	flickr.Expect(t, fclient.Args.Get("person_h"), "120")
+
+	_, err = Add(fclient, "123456", "87944415@N00", 0, 0, 0, 0)
+	flickr.Expect(t, err, nil)
+	for _, arg := range []string{"person_x", "person_y", "person_w", "person_h"} {
+		_, found := fclient.Args[arg]
+		flickr.Expect(t, found, false)
+	}
+}
+
+func TestEditCoords(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := EditCoords(fclient, "123456", "87944415@N00", 0, 10, 100, 120)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.people.editCoords")
+	flickr.Expect(t, fclient.Args.Get("photo_id"), "123456")
+	flickr.Expect(t, fclient.Args.Get("user_id"), "87944415@N00")
+	flickr.Expect(t, fclient.Args.Get("person_x"), "0")
+	flickr.Expect(t, fclient.Args.Get("person_y"), "10")
+	flickr.Expect(t, fclient.Args.Get("person_w"), "100")
+	flickr.Expect(t, fclient.Args.Get("person_h"), "120")
+
+	for _, box := range [][4]int{{0, 0, 0, 120}, {0, 0, 100, 0}, {-1, 0, 100, 120}, {0, 10, -100, 120}} {
+		resp, err := EditCoords(fclient, "123456", "87944415@N00", box[0], box[1], box[2], box[3])
+		flickr.Expect(t, resp == nil, true)
+		ferr, ok := err.(*flickErr.Error)
+		flickr.Expect(t, ok, true)
+		flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"><err code="2" msg="Person not found" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := Delete(fclient, "123456", "87944415@N00")
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.ErrorCode(), 2)
+}
@@ -1,28 +1,44 @@
 package photos
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
 	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
 )
 
 type PhotoInfo struct {
-	Id             string `xml:"id,attr"`
-	Secret         string `xml:"secret,attr"`
-	Server         string `xml:"server,attr"`
-	Farm           string `xml:"farm,attr"`
-	DateUploaded   string `xml:"dateuploaded,attr"`
-	IsFavorite     bool   `xml:"isfavorite,attr"`
-	License        string `xml:"license,attr"`
+	Id           string `xml:"id,attr"`
+	Secret       string `xml:"secret,attr"`
+	Server       string `xml:"server,attr"`
+	Farm         string `xml:"farm,attr"`
+	DateUploaded string `xml:"dateuploaded,attr"`
+	IsFavorite   bool   `xml:"isfavorite,attr"`
+	License      string `xml:"license,attr"`
 	// NOTE: one less than safety level set on upload (ie, here 0 = safe, 1 = moderate, 2 = restricted)
 	//       while on upload, 1 = safe, 2 = moderate, 3 = restricted
-	SafetyLevel    int    `xml:"safety_level,attr"` 
-	Rotation       int    `xml:"rotation,attr"`
+	SafetyLevel    int    `xml:"safety_level,attr"`
+	Rotation       int    `xml:"rotation,attr"` // clockwise, in degrees: 0, 90, 180 or 270, see transform.Rotate
 	OriginalSecret string `xml:"originalsecret,attr"`
 	OriginalFormat string `xml:"originalformat,attr"`
 	Views          int    `xml:"views,attr"`
 	Media          string `xml:"media,attr"`
-	Title          string `xml:"title"`
-	Description    string `xml:"description"`
-	Visibility     struct {
+	Owner          struct {
+		Nsid      string `xml:"nsid,attr"`
+		Username  string `xml:"username,attr"`
+		Realname  string `xml:"realname,attr"`
+		Location  string `xml:"location,attr"`
+		PathAlias string `xml:"path_alias,attr"`
+	} `xml:"owner"`
+	Title       string `xml:"title"`
+	Description string `xml:"description"`
+	Visibility  struct {
 		IsPublic bool `xml:"ispublic,attr"`
 		IsFriend bool `xml:"isfriend,attr"`
 		IsFamily bool `xml:"isfamily,attr"`
@@ -36,7 +52,7 @@ type PhotoInfo struct {
 	} `xml:"dates"`
 	Permissions struct {
 		PermComment string `xml:"permcomment,attr"`
-		PermAdMeta  string `xml:"permadmeta,attr"`
+		PermAdMeta  string `xml:"permaddmeta,attr"`
 	} `xml:"permissions"`
 	Editability struct {
 		CanComment string `xml:"cancomment,attr"`
@@ -52,11 +68,104 @@ type PhotoInfo struct {
 		CanPrint    string `xml:"canprint,attr"`
 		CanShare    string `xml:"canshare,attr"`
 	} `xml:"usage"`
-	Comments int `xml:"comments"`
-	// Notes XXX: not handled yet
-	// People XXX: not handled yet
+	Comments int         `xml:"comments"`
+	Notes    []PhotoNote `xml:"notes>note"`
+	People   struct {
+		HasPeople bool `xml:"haspeople,attr"`
+	} `xml:"people"`
 	// Tags XXX: not handled yet
-	// Urls XXX: not handled yet
+	Urls []struct {
+		Type string `xml:"type,attr"`
+		URL  string `xml:",chardata"`
+	} `xml:"urls>url"`
+}
+
+// A note left on a region of a photo
+type PhotoNote struct {
+	Id         string `xml:"id,attr"`
+	Author     string `xml:"author,attr"`
+	AuthorName string `xml:"authorname,attr"`
+	X          int    `xml:"x,attr"`
+	Y          int    `xml:"y,attr"`
+	W          int    `xml:"w,attr"`
+	H          int    `xml:"h,attr"`
+	Text       string `xml:",chardata"`
+}
+
+// Engagement counters of a photo, as returned by getInfo
+type Popularity struct {
+	Views, Comments, Notes int
+}
+
+// Return the views, comments and notes counts of the photo, to rank photos without
+// a stats call. Views are only sent by Flickr to the owner and for public photos.
+func (p *PhotoInfo) Popularity() Popularity {
+	return Popularity{Views: p.Views, Comments: p.Comments, Notes: len(p.Notes)}
+}
+
+// Return the URL of the photo page sent by Flickr, empty if missing; see Permalink
+// to build it
+func (p *PhotoInfo) PageURL() string {
+	for _, u := range p.Urls {
+		if u.Type == "photopage" {
+			return u.URL
+		}
+	}
+	return ""
+}
+
+// Tell whether the photo belongs to the user with the given nsid
+func (p *PhotoInfo) IsOwnedBy(nsid string) bool {
+	return nsid != "" && p.Owner.Nsid == nsid
+}
+
+// Tell whether the calling user can comment the photo, as told by the editability
+// elements of getInfo. Anonymous calls only get the public editability.
+func (p *PhotoInfo) CanComment() bool {
+	return p.Editability.CanComment == "1" || p.PublicEditability.CanComment == "1"
+}
+
+// Tell whether the calling user can add notes and tags to the photo, as told by the
+// editability elements of getInfo. Anonymous calls only get the public editability.
+func (p *PhotoInfo) CanAddMeta() bool {
+	return p.Editability.CanAddMeta == "1" || p.PublicEditability.CanAddMeta == "1"
+}
+
+// Return the upload date as a time.Time
+func (p *PhotoInfo) UploadedTime() (time.Time, error) {
+	return flickr.ParseUnixDate(p.DateUploaded)
+}
+
+// Return the posted date as a time.Time
+func (p *PhotoInfo) PostedTime() (time.Time, error) {
+	return flickr.ParseUnixDate(p.Dates.Posted)
+}
+
+// Return the date the photo was taken as a time.Time, zero if unknown
+func (p *PhotoInfo) TakenTime() (time.Time, error) {
+	return flickr.ParseTakenDate(p.Dates.Taken)
+}
+
+// Return the last update date as a time.Time
+func (p *PhotoInfo) LastUpdateTime() (time.Time, error) {
+	return flickr.ParseUnixDate(p.Dates.LastUpdate)
+}
+
+// Return the URL of the photo page, the link to share the photo. The owner path
+// alias is preferred to the nsid, see flickr.PhotoPageURL.
+func (p *PhotoInfo) Permalink() string {
+	return flickr.PhotoPageURL(p.Owner.Nsid, p.Owner.PathAlias, p.Id)
+}
+
+// Implements flickr.PhotoReferrer
+func (p *PhotoInfo) PhotoRef() flickr.PhotoRef {
+	return flickr.PhotoRef{
+		Id:             p.Id,
+		Secret:         p.Secret,
+		Server:         p.Server,
+		OriginalSecret: p.OriginalSecret,
+		OriginalFormat: p.OriginalFormat,
+	}
 }
 
 type PhotoInfoResponse struct {
@@ -79,7 +188,8 @@ func Delete(client *flickr.FlickrClient, id string) (*flickr.BasicResponse, erro
 	return response, err
 }
 
-// Get information about a Flickr photo
+// Get information about a Flickr photo. The secret can be empty: it is only needed
+// to access photos without permission to view them, ie. public photos don't need it.
 func GetInfo(client *flickr.FlickrClient, id string, secret string) (*PhotoInfoResponse, error) {
 	client.Init()
 	client.EndpointUrl = flickr.API_ENDPOINT
@@ -96,23 +206,689 @@ func GetInfo(client *flickr.FlickrClient, id string, secret string) (*PhotoInfoR
 	return response, err
 }
 
-// Set date posted and date taken on a Flickr photo
-// datePosted and dateTaken are optional and may be set to ""
-func SetDates(client *flickr.FlickrClient, id string, datePosted string, dateTaken string) (*flickr.BasicResponse, error) {
+// Granularity of the date taken of a photo
+const (
+	TakenGranularityExact = 0
+	TakenGranularityMonth = 4
+	TakenGranularityYear  = 6
+	TakenGranularityCirca = 8
+)
+
+// Set date posted and date taken on a Flickr photo.
+// datePosted and dateTaken are optional and omitted when zero, but at least one of them
+// must be set; dateTakenGranularity is one of the TakenGranularity* values and is only
+// sent along with dateTaken.
+// This method requires authentication with 'write' permission.
+func SetDates(client *flickr.FlickrClient, id string, datePosted, dateTaken time.Time, dateTakenGranularity int) (*flickr.BasicResponse, error) {
+	if datePosted.IsZero() && dateTaken.IsZero() {
+		return nil, flickErr.NewError(flickErr.ArgumentError, "one of datePosted and dateTaken is required")
+	}
+	switch dateTakenGranularity {
+	case TakenGranularityExact, TakenGranularityMonth, TakenGranularityYear, TakenGranularityCirca:
+	default:
+		return nil, flickErr.NewError(flickErr.ArgumentError, "unknown date taken granularity "+strconv.Itoa(dateTakenGranularity))
+	}
+
 	client.Init()
 	client.EndpointUrl = flickr.API_ENDPOINT
 	client.HTTPVerb = "POST"
 	client.Args.Set("method", "flickr.photos.setDates")
 	client.Args.Set("photo_id", id)
-	if datePosted != "" {
-		client.Args.Set("date_posted", datePosted)
+	if !datePosted.IsZero() {
+		client.Args.Set("date_posted", strconv.FormatInt(datePosted.Unix(), 10))
 	}
-	if dateTaken != "" {
-		client.Args.Set("date_taken", dateTaken)
+	if !dateTaken.IsZero() {
+		// Flickr stores taken dates as they are, with no timezone
+		client.Args.Set("date_taken", dateTaken.Format(flickr.TakenDateLayout))
+		client.Args.Set("date_taken_granularity", strconv.Itoa(dateTakenGranularity))
+	}
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Add tags to a photo, tags containing spaces are sent quoted (see flickr.JoinTags).
+// This method requires authentication with 'write' permission.
+func AddTags(client *flickr.FlickrClient, id string, tags []string) (*flickr.BasicResponse, error) {
+	joined := flickr.JoinTags(tags)
+	if joined == "" {
+		return nil, flickErr.NewError(flickErr.ArgumentError, "no tags to add")
 	}
+
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.addTags")
+	client.Args.Set("photo_id", id)
+	client.Args.Set("tags", joined)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Replace all the tags of a photo with the given ones, an empty list removes them all.
+// Tags containing spaces are sent quoted (see flickr.JoinTags).
+// This method requires authentication with 'write' permission.
+func SetTags(client *flickr.FlickrClient, id string, tags []string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.setTags")
+	client.Args.Set("photo_id", id)
+	client.Args.Set("tags", flickr.JoinTags(tags))
 	client.OAuthSign()
 
 	response := &flickr.BasicResponse{}
 	err := flickr.DoPost(client, response)
 	return response, err
 }
+
+// Remove a tag from a photo. tagId identifies the tag on that photo only, see
+// tags.GetListPhoto.
+// This method requires authentication with 'write' permission.
+func RemoveTag(client *flickr.FlickrClient, tagId string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.removeTag")
+	client.Args.Set("tag_id", tagId)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Who is allowed to comment or add meta information (tags and notes) to a photo
+type PermLevel int
+
+const (
+	PermOwner            PermLevel = iota // nobody but the owner
+	PermFriendsAndFamily                  // friends and family
+	PermContacts                          // contacts
+	PermEverybody                         // any Flickr user
+)
+
+type PhotoPerms struct {
+	Id          string    `xml:"id,attr"`
+	IsPublic    bool      `xml:"ispublic,attr"`
+	IsFriend    bool      `xml:"isfriend,attr"`
+	IsFamily    bool      `xml:"isfamily,attr"`
+	PermComment PermLevel `xml:"permcomment,attr"`
+	PermAddMeta PermLevel `xml:"permaddmeta,attr"`
+}
+
+type PhotoPermsResponse struct {
+	flickr.BasicResponse
+	Perms PhotoPerms `xml:"perms"`
+}
+
+// Flickr expects booleans as "0" or "1"
+func boolString(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// Get permissions for a photo.
+// This method requires authentication with 'read' permission.
+func GetPerms(client *flickr.FlickrClient, id string) (*PhotoPermsResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.photos.getPerms")
+	client.Args.Set("photo_id", id)
+	client.OAuthSign()
+
+	response := &PhotoPermsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Set permissions for a photo, the Id field of perms is ignored. A nil perms is an
+// ArgumentError.
+// This method requires authentication with 'write' permission.
+func SetPerms(client *flickr.FlickrClient, id string, perms *PhotoPerms) (*flickr.BasicResponse, error) {
+	if perms == nil {
+		return nil, flickErr.NewError(flickErr.ArgumentError, "no permissions to set")
+	}
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.setPerms")
+	client.Args.Set("photo_id", id)
+	client.Args.Set("is_public", boolString(perms.IsPublic))
+	client.Args.Set("is_friend", boolString(perms.IsFriend))
+	client.Args.Set("is_family", boolString(perms.IsFamily))
+	client.Args.Set("perm_comment", strconv.Itoa(int(perms.PermComment)))
+	client.Args.Set("perm_addmeta", strconv.Itoa(int(perms.PermAddMeta)))
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Set the safety level of a photo and whether it is hidden from public searches.
+// With NoSafetyLevelSpecified only the hidden flag is changed.
+// An ArgumentError is returned, before sending anything, if level is not valid.
+// This method requires authentication with 'write' permission.
+func SetSafetyLevel(client *flickr.FlickrClient, id string, level flickr.SafetyLevel, hidden bool) (*flickr.BasicResponse, error) {
+	if err := level.Validate(); err != nil {
+		return nil, err
+	}
+
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.setSafetyLevel")
+	client.Args.Set("photo_id", id)
+	if level != flickr.NoSafetyLevelSpecified {
+		client.Args.Set("safety_level", strconv.Itoa(int(level)))
+	}
+	client.Args.Set("hidden", boolString(hidden))
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Set the content type of a photo (photo, screenshot, other).
+// An ArgumentError is returned, before sending anything, if ct is not valid or
+// NoContentTypeSpecified.
+// This method requires authentication with 'write' permission.
+func SetContentType(client *flickr.FlickrClient, id string, ct flickr.ContentType) (*flickr.BasicResponse, error) {
+	if err := ct.Validate(); err != nil {
+		return nil, err
+	}
+	if ct == flickr.NoContentTypeSpecified {
+		return nil, flickErr.NewError(flickErr.ArgumentError, "a content type is required")
+	}
+
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.setContentType")
+	client.Args.Set("photo_id", id)
+	client.Args.Set("content_type", strconv.Itoa(int(ct)))
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// A photoset containing a photo, as returned by GetAllContexts
+type ContextSet struct {
+	Id    string `xml:"id,attr"`
+	Title string `xml:"title,attr"`
+}
+
+// A group pool containing a photo, as returned by GetAllContexts
+type ContextPool struct {
+	Id    string `xml:"id,attr"`
+	Title string `xml:"title,attr"`
+	Url   string `xml:"url,attr"`
+}
+
+type ContextsResponse struct {
+	flickr.BasicResponse
+	Sets  []ContextSet  `xml:"set"`
+	Pools []ContextPool `xml:"pool"`
+}
+
+// The previous or next photo in a context (photostream, photoset, ...)
+type ContextPhoto struct {
+	Id     string `xml:"id,attr"`
+	Owner  string `xml:"owner,attr"`
+	Secret string `xml:"secret,attr"`
+	Server string `xml:"server,attr"`
+	Farm   string `xml:"farm,attr"`
+	Title  string `xml:"title,attr"`
+	Url    string `xml:"url,attr"`
+	Thumb  string `xml:"thumb,attr"`
+	Media  string `xml:"media,attr"`
+}
+
+// Flickr returns a photo with id 0 when there is no previous or next photo
+func (p *ContextPhoto) IsBoundary() bool {
+	return p.Id == "" || p.Id == "0"
+}
+
+type PhotoContextResponse struct {
+	flickr.BasicResponse
+	Count int `xml:"count"`
+	// nil when the photo is the first of the stream
+	PrevPhoto *ContextPhoto `xml:"prevphoto"`
+	// nil when the photo is the last of the stream
+	NextPhoto *ContextPhoto `xml:"nextphoto"`
+}
+
+// Returns next and previous photos for a photo in a photostream.
+// This method does not require authentication.
+func GetContext(client *flickr.FlickrClient, id string) (*PhotoContextResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.photos.getContext")
+	client.Args.Set("photo_id", id)
+	client.ApiSign()
+
+	response := &PhotoContextResponse{}
+	err := flickr.DoGet(client, response)
+	if response.PrevPhoto != nil && response.PrevPhoto.IsBoundary() {
+		response.PrevPhoto = nil
+	}
+	if response.NextPhoto != nil && response.NextPhoto.IsBoundary() {
+		response.NextPhoto = nil
+	}
+	return response, err
+}
+
+// Returns all visible sets and pools the photo belongs to.
+// This method does not require authentication.
+func GetAllContexts(client *flickr.FlickrClient, id string) (*ContextsResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.photos.getAllContexts")
+	client.Args.Set("photo_id", id)
+	client.ApiSign()
+
+	response := &ContextsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// A page of photos as returned by list methods
+type PhotosResponse struct {
+	flickr.BasicResponse
+	Photos struct {
+		Page    int            `xml:"page,attr"`
+		Pages   int            `xml:"pages,attr"`
+		PerPage int            `xml:"perpage,attr"`
+		Total   int            `xml:"total,attr"`
+		Photos  []flickr.Photo `xml:"photo"`
+	} `xml:"photos"`
+}
+
+// Number of the page held by the response, implements flickr.Pager
+func (r *PhotosResponse) Page() int {
+	return r.Photos.Page
+}
+
+// Total number of pages, implements flickr.Pager
+func (r *PhotosResponse) Pages() int {
+	return r.Photos.Pages
+}
+
+// Set the pagination and extras arguments shared by list methods, see
+// flickr.SetPageArgs, an empty extras is ignored.
+func setListArgs(client *flickr.FlickrClient, page, perPage int, extras string) {
+	flickr.SetPageArgs(client, page, perPage)
+	if extras != "" {
+		client.Args.Set("extras", extras)
+	}
+}
+
+// Perform an authenticated call to a list method of the calling user's photostream
+func getUserPhotos(client *flickr.FlickrClient, method string, page, perPage int, extras string) (*PhotosResponse, error) {
+	client.Init()
+	client.Args.Set("method", method)
+	setListArgs(client, page, perPage, extras)
+	client.OAuthSign()
+
+	response := &PhotosResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Returns a list of the calling user's photos that are not part of any sets.
+// This method requires authentication with 'read' permission.
+func GetNotInSet(client *flickr.FlickrClient, page, perPage int, extras string) (*PhotosResponse, error) {
+	return getUserPhotos(client, "flickr.photos.getNotInSet", page, perPage, extras)
+}
+
+// Returns a list of the calling user's photos with no tags.
+// This method requires authentication with 'read' permission.
+func GetUntagged(client *flickr.FlickrClient, page, perPage int, extras string) (*PhotosResponse, error) {
+	return getUserPhotos(client, "flickr.photos.getUntagged", page, perPage, extras)
+}
+
+// Returns a list of the calling user's geo-tagged photos.
+// This method requires authentication with 'read' permission.
+func GetWithGeoData(client *flickr.FlickrClient, page, perPage int, extras string) (*PhotosResponse, error) {
+	return getUserPhotos(client, "flickr.photos.getWithGeoData", page, perPage, extras)
+}
+
+// Returns a list of the calling user's photos which haven't been geo-tagged.
+// This method requires authentication with 'read' permission.
+func GetWithoutGeoData(client *flickr.FlickrClient, page, perPage int, extras string) (*PhotosResponse, error) {
+	return getUserPhotos(client, "flickr.photos.getWithoutGeoData", page, perPage, extras)
+}
+
+// Return a list of the calling user's photos that have been created or modified
+// since minDate.
+// This method requires authentication with 'read' permission.
+func RecentlyUpdated(client *flickr.FlickrClient, minDate time.Time, page, perPage int, extras string) (*PhotosResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.photos.recentlyUpdated")
+	client.Args.Set("min_date", strconv.FormatInt(minDate.Unix(), 10))
+	setListArgs(client, page, perPage, extras)
+	client.OAuthSign()
+
+	response := &PhotosResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Return a list of the latest public photos uploaded to Flickr, by any user.
+// Pass flickr.Extras values in extras to get URLs and owner names along with photos.
+// This method does not require authentication.
+func GetRecent(client *flickr.FlickrClient, page, perPage int, extras string) (*PhotosResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.photos.getRecent")
+	setListArgs(client, page, perPage, extras)
+	client.ApiSign()
+
+	response := &PhotosResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// A user who marked a photo as favorite
+type Favorite struct {
+	Nsid       string `xml:"nsid,attr"`
+	Username   string `xml:"username,attr"`
+	Realname   string `xml:"realname,attr"`
+	FaveDate   string `xml:"favedate,attr"`
+	IconServer string `xml:"iconserver,attr"`
+	IconFarm   string `xml:"iconfarm,attr"`
+}
+
+// Return the date the photo was marked as favorite as a time.Time
+func (f *Favorite) FaveTime() (time.Time, error) {
+	return flickr.ParseUnixDate(f.FaveDate)
+}
+
+type PhotoFavoritesResponse struct {
+	flickr.BasicResponse
+	Photo struct {
+		Id        string     `xml:"id,attr"`
+		Secret    string     `xml:"secret,attr"`
+		Server    string     `xml:"server,attr"`
+		Farm      string     `xml:"farm,attr"`
+		Page      int        `xml:"page,attr"`
+		Pages     int        `xml:"pages,attr"`
+		PerPage   int        `xml:"perpage,attr"`
+		Total     int        `xml:"total,attr"`
+		Favorites []Favorite `xml:"person"`
+	} `xml:"photo"`
+}
+
+// Number of the page held by the response, implements flickr.Pager
+func (r *PhotoFavoritesResponse) Page() int {
+	return r.Photo.Page
+}
+
+// Total number of pages, implements flickr.Pager
+func (r *PhotoFavoritesResponse) Pages() int {
+	return r.Photo.Pages
+}
+
+// Returns the list of people who have favorited a given photo.
+// This method does not require authentication.
+func GetFavorites(client *flickr.FlickrClient, id string, page, perPage int) (*PhotoFavoritesResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.photos.getFavorites")
+	client.Args.Set("photo_id", id)
+	setListArgs(client, page, perPage, "")
+	client.ApiSign()
+
+	response := &PhotoFavoritesResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// A user marking a photo as favorite, at Time
+type FaveEvent struct {
+	Favorite
+	Time time.Time
+}
+
+// Largest page size accepted by flickr.photos.getFavorites
+const maxFavoritesPerPage = 50
+
+// Return all the favorites of a photo, fetching every page of getFavorites, sorted from
+// the oldest to the most recent. If the quota runs out before the last page, a
+// *flickr.RateLimitError is returned instead of a partial timeline.
+// This method does not require authentication.
+func FavoritesOverTime(client *flickr.FlickrClient, photoId string) ([]FaveEvent, error) {
+	var events []FaveEvent
+	_, _, err := flickr.Paginate(0, func(page int) (flickr.Pager, error) {
+		if err := client.CheckRateLimit(1); err != nil {
+			return nil, err
+		}
+		response, err := GetFavorites(client, photoId, page, maxFavoritesPerPage)
+		if err != nil {
+			return nil, err
+		}
+		for _, fave := range response.Photo.Favorites {
+			faveTime, err := fave.FaveTime()
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, FaveEvent{Favorite: fave, Time: faveTime})
+		}
+		return response, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	return events, nil
+}
+
+// Number of photos within a date range
+type PhotoCount struct {
+	Count    int    `xml:"count,attr"`
+	FromDate string `xml:"fromdate,attr"`
+	ToDate   string `xml:"todate,attr"`
+}
+
+type PhotoCountsResponse struct {
+	flickr.BasicResponse
+	PhotoCounts []PhotoCount `xml:"photocounts>photocount"`
+}
+
+// Gets a list of photo counts for the given date ranges for the calling user.
+// Each couple of consecutive dates in uploadDates or takenDates defines a range,
+// at least one of the two slices must not be empty.
+// This method requires authentication with 'read' permission.
+func GetCounts(client *flickr.FlickrClient, takenDates []time.Time, uploadDates []time.Time) (*PhotoCountsResponse, error) {
+	if len(takenDates) == 0 && len(uploadDates) == 0 {
+		return nil, flickErr.NewError(flickErr.ArgumentError, "one of takenDates and uploadDates is required")
+	}
+
+	client.Init()
+	client.Args.Set("method", "flickr.photos.getCounts")
+	if len(uploadDates) > 0 {
+		dates := make([]string, len(uploadDates))
+		for i, d := range uploadDates {
+			dates[i] = strconv.FormatInt(d.Unix(), 10)
+		}
+		client.Args.Set("dates", strings.Join(dates, ","))
+	}
+	if len(takenDates) > 0 {
+		// taken dates are sent as mysql datetimes
+		dates := make([]string, len(takenDates))
+		for i, d := range takenDates {
+			dates[i] = d.Format(flickr.TakenDateLayout)
+		}
+		client.Args.Set("taken_dates", strings.Join(dates, ","))
+	}
+	client.OAuthSign()
+
+	response := &PhotoCountsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Bucket sizes accepted by TakenHistogram
+const (
+	BucketDay   = "day"
+	BucketWeek  = "week"
+	BucketMonth = "month"
+	BucketYear  = "year"
+)
+
+// Maximum number of buckets of a histogram: the boundaries are sent in the URL of
+// a single getCounts call
+const maxHistogramBuckets = 200
+
+// Number of photos taken in [Start, End)
+type HistogramBucket struct {
+	Start, End time.Time
+	Count      int
+}
+
+// Photo counts by consecutive date ranges, in chronological order
+type Histogram struct {
+	Buckets []HistogramBucket
+}
+
+// Return the boundaries of the buckets of the given size between from and to,
+// the last bucket ending at to even if shorter. An ArgumentError is returned when
+// there would be more than maxHistogramBuckets buckets.
+func bucketBoundaries(from, to time.Time, bucket string) ([]time.Time, error) {
+	var next func(time.Time) time.Time
+	switch bucket {
+	case BucketDay:
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	case BucketWeek:
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+	case BucketMonth:
+		next = func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
+	case BucketYear:
+		next = func(t time.Time) time.Time { return t.AddDate(1, 0, 0) }
+	default:
+		return nil, flickErr.NewError(flickErr.ArgumentError, "unknown bucket size "+bucket)
+	}
+
+	dates := []time.Time{from}
+	for t := next(from); t.Before(to); t = next(t) {
+		dates = append(dates, t)
+		if len(dates) > maxHistogramBuckets {
+			return nil, flickErr.NewError(flickErr.ArgumentError,
+				fmt.Sprintf("more than %d %s buckets, use a larger bucket size", maxHistogramBuckets, bucket))
+		}
+	}
+	return append(dates, to), nil
+}
+
+// Count the calling user's photos by taken date, in buckets of one day, week, month
+// or year (BucketDay, ...) starting from from; the last bucket ends at to.
+// An ArgumentError is returned, before sending anything, if from is not before to,
+// bucket is unknown or there would be more than 200 buckets.
+// This method requires authentication with 'read' permission.
+func TakenHistogram(client *flickr.FlickrClient, from, to time.Time, bucket string) (*Histogram, error) {
+	if !from.Before(to) {
+		return nil, flickErr.NewError(flickErr.ArgumentError, "from must be before to")
+	}
+	dates, err := bucketBoundaries(from, to, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := GetCounts(client, dates, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	histogram := &Histogram{Buckets: make([]HistogramBucket, len(dates)-1)}
+	for i := range histogram.Buckets {
+		histogram.Buckets[i].Start = dates[i]
+		histogram.Buckets[i].End = dates[i+1]
+		// counts are returned in the same order as ranges
+		if i < len(response.PhotoCounts) {
+			histogram.Buckets[i].Count = response.PhotoCounts[i].Count
+		}
+	}
+	return histogram, nil
+}
+
+// Upload the file at path, aborting as soon as ctx is done: the request is interrupted
+// and the returned error wraps ctx.Err(), check it with errors.Is. Set opts.Progress
+// to follow the upload, see flickr.UploadParams.
+// This method requires authentication with 'write' permission.
+func UploadContext(ctx context.Context, client *flickr.FlickrClient, path string, opts *flickr.UploadParams) (*flickr.UploadResponse, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return flickr.UploadReaderContext(ctx, client, file, file.Name(), opts)
+}
+
+// Outcome of the upload of a single file performed by UploadBatch
+type UploadResult struct {
+	Path string
+	// Empty if the upload failed or is processed asynchronously
+	PhotoId  string
+	TicketId string
+	// nil on success, a *flickErr.Error for errors returned by Flickr
+	Err error
+}
+
+// Upload error codes affecting every upload, not only the one that got them
+var fatalUploadErrors = map[int]bool{
+	96:  true, // invalid signature
+	97:  true, // missing signature
+	98:  true, // login failed / invalid auth token
+	99:  true, // user not logged in / insufficient permissions
+	100: true, // invalid API key
+	105: true, // service currently unavailable
+}
+
+// Upload the files one after another with the same params, going on when a single
+// upload fails: per-file errors are reported in the Err field of the results.
+// Once the rate limit quota is exhausted the files left are not uploaded, each
+// of them gets a *flickr.RateLimitError.
+// Failed uploads are not retried, as a photo may be created even when its upload
+// fails in flight: check the account before uploading such a file again.
+// The returned error is set only when the remaining uploads would fail as well
+// (authentication problems, Flickr unavailable) or when ctx is done, in which case
+// the results of the uploads attempted so far are returned along with it.
+// This method requires authentication with 'write' permission.
+func UploadBatch(ctx context.Context, client *flickr.FlickrClient, paths []string, opts *flickr.UploadParams) ([]UploadResult, error) {
+	results := []UploadResult{}
+	for i, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		if err := client.CheckRateLimit(1); err != nil {
+			for _, path := range paths[i:] {
+				results = append(results, UploadResult{Path: path, Err: err})
+			}
+			break
+		}
+		result := UploadResult{Path: path}
+		response, err := UploadContext(ctx, client, path, opts)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return results, ctxErr
+			}
+			result.Err = err
+			results = append(results, result)
+			if ferr, ok := err.(*flickErr.Error); ok && ferr.ErrorCode == flickErr.MaintenanceError {
+				return results, err
+			}
+			if response != nil && fatalUploadErrors[response.ErrorCode()] {
+				return results, err
+			}
+			continue
+		}
+		result.PhotoId = response.ID
+		result.TicketId = response.TicketID
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -1,7 +1,10 @@
 package photos
 
 import (
+	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"gopkg.in/masci/flickr.v2"
 	flickErr "gopkg.in/masci/flickr.v2/error"
@@ -27,3 +30,608 @@ func TestDeleteKo(t *testing.T) {
 	flickr.Expect(t, ok, true)
 	flickr.Expect(t, resp.HasErrors(), true)
 }
+
+func TestGetPerms(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <perms id="123456" ispublic="1" isfriend="1" isfamily="0" permcomment="0" permaddmeta="2" />
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	resp, err := GetPerms(fclient, "123456")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Perms.Id, "123456")
+	flickr.Expect(t, resp.Perms.IsPublic, true)
+	flickr.Expect(t, resp.Perms.IsFriend, true)
+	flickr.Expect(t, resp.Perms.IsFamily, false)
+	flickr.Expect(t, resp.Perms.PermComment, PermOwner)
+	flickr.Expect(t, resp.Perms.PermAddMeta, PermContacts)
+}
+
+func TestSetSafetyLevel(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := SetSafetyLevel(fclient, "123456", flickr.SafetyLevelModerate, true)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.setSafetyLevel")
+	flickr.Expect(t, fclient.Args.Get("safety_level"), "2")
+	flickr.Expect(t, fclient.Args.Get("hidden"), "1")
+
+	_, err = SetSafetyLevel(fclient, "123456", flickr.NoSafetyLevelSpecified, false)
+	flickr.Expect(t, err, nil)
+	_, found := fclient.Args["safety_level"]
+	flickr.Expect(t, found, false)
+	flickr.Expect(t, fclient.Args.Get("hidden"), "0")
+
+	resp, err := SetSafetyLevel(fclient, "123456", 4, false)
+	flickr.Expect(t, resp == nil, true)
+	ferr, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+}
+
+func TestSetContentType(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := SetContentType(fclient, "123456", flickr.ContentTypeScreenshot)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.setContentType")
+	flickr.Expect(t, fclient.Args.Get("content_type"), "2")
+
+	for _, ct := range []flickr.ContentType{flickr.NoContentTypeSpecified, 4} {
+		_, err = SetContentType(fclient, "123456", ct)
+		ferr, ok := err.(*flickErr.Error)
+		flickr.Expect(t, ok, true)
+		flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	}
+
+	server, client = flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"><err code="99" msg="Insufficient permissions" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	resp, err := SetContentType(fclient, "123456", flickr.ContentTypeOther)
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.ErrorCode(), 99)
+}
+
+func TestSetPerms(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	perms := &PhotoPerms{IsFamily: true, PermComment: PermEverybody, PermAddMeta: PermFriendsAndFamily}
+	resp, err := SetPerms(fclient, "123456", perms)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.HasErrors(), false)
+	flickr.Expect(t, fclient.Args.Get("is_public"), "0")
+	flickr.Expect(t, fclient.Args.Get("is_friend"), "0")
+	flickr.Expect(t, fclient.Args.Get("is_family"), "1")
+	flickr.Expect(t, fclient.Args.Get("perm_comment"), "3")
+	flickr.Expect(t, fclient.Args.Get("perm_addmeta"), "1")
+
+	fclient = flickr.GetTestClient()
+	resp, err = SetPerms(fclient, "123456", nil)
+	ferr, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	flickr.Expect(t, resp == nil, true)
+	flickr.Expect(t, fclient.Args.Get("method"), "")
+}
+
+func TestPhotoInfoDates(t *testing.T) {
+	info := PhotoInfo{DateUploaded: "1361132046"}
+	info.Dates.Posted = "1361132046"
+	info.Dates.Taken = "2013-02-17 20:14:06"
+	info.Dates.LastUpdate = "1361132100"
+
+	uploaded, err := info.UploadedTime()
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, uploaded.Equal(time.Unix(1361132046, 0)), true)
+	posted, err := info.PostedTime()
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, posted.Equal(uploaded), true)
+	taken, err := info.TakenTime()
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, taken.Equal(time.Date(2013, 2, 17, 20, 14, 6, 0, time.UTC)), true)
+	updated, err := info.LastUpdateTime()
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, updated.Equal(time.Unix(1361132100, 0)), true)
+
+	info.Dates.Taken = "0000-00-00 00:00:00"
+	taken, err = info.TakenTime()
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, taken.IsZero(), true)
+}
+
+func TestGetAllContexts(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <set id="392" title="My set" />
+  <pool id="34427465471@N01" title="FlickrDiscuss" url="/groups/discuss/pool/" />
+  <set id="393" title="Another set" />
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	resp, err := GetAllContexts(fclient, "123456")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(resp.Sets), 2)
+	flickr.Expect(t, resp.Sets[0].Id, "392")
+	flickr.Expect(t, resp.Sets[1].Title, "Another set")
+	flickr.Expect(t, len(resp.Pools), 1)
+	flickr.Expect(t, resp.Pools[0].Id, "34427465471@N01")
+	flickr.Expect(t, resp.Pools[0].Title, "FlickrDiscuss")
+	flickr.Expect(t, resp.Pools[0].Url, "/groups/discuss/pool/")
+}
+
+var photosBody = `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photos page="2" pages="89" perpage="10" total="881">
+    <photo id="2636" owner="47058503995@N01" secret="a123456" server="2" farm="1" title="test_04" ispublic="1" isfriend="0" isfamily="0" url_o="https://farm1.staticflickr.com/2/2636_b123456_o.jpg" />
+    <photo id="2635" owner="47058503995@N01" secret="b123456" server="2" farm="1" title="test_03" ispublic="0" isfriend="1" isfamily="1" />
+  </photos>
+</rsp>`
+
+func TestGetUserPhotos(t *testing.T) {
+	for method, f := range map[string]func(*flickr.FlickrClient, int, int, string) (*PhotosResponse, error){
+		"flickr.photos.getNotInSet":       GetNotInSet,
+		"flickr.photos.getUntagged":       GetUntagged,
+		"flickr.photos.getWithGeoData":    GetWithGeoData,
+		"flickr.photos.getWithoutGeoData": GetWithoutGeoData,
+	} {
+		fclient := flickr.GetTestClient()
+		server, client := flickr.FlickrMock(200, photosBody, "")
+		fclient.HTTPClient = client
+		resp, err := f(fclient, 2, 10, "url_o")
+		server.Close()
+
+		flickr.Expect(t, err, nil)
+		flickr.Expect(t, fclient.Args.Get("method"), method)
+		flickr.Expect(t, fclient.Args.Get("page"), "2")
+		flickr.Expect(t, fclient.Args.Get("per_page"), "10")
+		flickr.Expect(t, fclient.Args.Get("extras"), "url_o")
+		flickr.Expect(t, resp.Photos.Page, 2)
+		flickr.Expect(t, resp.Photos.Pages, 89)
+		flickr.Expect(t, resp.Photos.PerPage, 10)
+		flickr.Expect(t, resp.Photos.Total, 881)
+		flickr.Expect(t, len(resp.Photos.Photos), 2)
+		flickr.Expect(t, resp.Photos.Photos[0].Id, "2636")
+		flickr.Expect(t, resp.Photos.Photos[0].IsPublic, true)
+		flickr.Expect(t, resp.Photos.Photos[0].UrlO, "https://farm1.staticflickr.com/2/2636_b123456_o.jpg")
+		flickr.Expect(t, resp.Photos.Photos[1].IsFamily, true)
+	}
+
+	var pager flickr.Pager = &PhotosResponse{}
+	flickr.Expect(t, pager.Page(), 0)
+
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, photosBody, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	_, err := GetUntagged(fclient, 0, 0, "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("page"), "")
+	flickr.Expect(t, fclient.Args.Get("per_page"), "100")
+	flickr.Expect(t, fclient.Args.Get("extras"), "")
+}
+
+func TestRecentlyUpdated(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, photosBody, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	minDate := time.Date(2013, 2, 17, 20, 14, 6, 0, time.UTC)
+	resp, err := RecentlyUpdated(fclient, minDate, 2, 10, "last_update")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("min_date"), "1361132046")
+	flickr.Expect(t, fclient.Args.Get("page"), "2")
+	flickr.Expect(t, fclient.Args.Get("extras"), "last_update")
+	flickr.Expect(t, len(resp.Photos.Photos), 2)
+}
+
+func TestGetRecent(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, photosBody, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	resp, err := GetRecent(fclient, 0, 50, "url_q,owner_name")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.getRecent")
+	flickr.Expect(t, fclient.Args.Get("per_page"), "50")
+	flickr.Expect(t, fclient.Args.Get("extras"), "url_q,owner_name")
+	flickr.Expect(t, fclient.Args.Get("api_sig") != "", true)
+	_, found := fclient.Args["oauth_token"]
+	flickr.Expect(t, found, false)
+	flickr.Expect(t, len(resp.Photos.Photos), 2)
+}
+
+func TestGetFavorites(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photo id="1253576" secret="81b96be690" server="1" farm="1" page="1" pages="3" perpage="2" total="5">
+    <person nsid="33939862@N00" username="Dementation" favedate="1166689690" />
+    <person nsid="49485425@N00" username="indigenous_prodigy" favedate="1166573724" />
+  </photo>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	resp, err := GetFavorites(fclient, "1253576", 1, 2)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("per_page"), "2")
+	flickr.Expect(t, resp.Photo.Id, "1253576")
+	flickr.Expect(t, resp.Photo.Pages, 3)
+	flickr.Expect(t, resp.Photo.Total, 5)
+	flickr.Expect(t, len(resp.Photo.Favorites), 2)
+	fave := resp.Photo.Favorites[0]
+	flickr.Expect(t, fave.Nsid, "33939862@N00")
+	flickr.Expect(t, fave.Username, "Dementation")
+	faveTime, err := fave.FaveTime()
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, faveTime.Equal(time.Unix(1166689690, 0)), true)
+}
+
+func TestFavoritesOverTime(t *testing.T) {
+	server, fclient := flickr.FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("page") == "2" {
+			fmt.Fprint(w, `<rsp stat="ok"><photo id="1253576" page="2" pages="2" perpage="2" total="3">
+				<person nsid="12037949754@N01" username="Bees" favedate="1166600000" />
+			</photo></rsp>`)
+			return
+		}
+		fmt.Fprint(w, `<rsp stat="ok"><photo id="1253576" page="1" pages="2" perpage="2" total="3">
+			<person nsid="33939862@N00" username="Dementation" favedate="1166689690" />
+			<person nsid="49485425@N00" username="indigenous_prodigy" favedate="1166573724" />
+		</photo></rsp>`)
+	}))
+	defer server.Close()
+
+	events, err := FavoritesOverTime(fclient, "1253576")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("page"), "2")
+	flickr.Expect(t, fclient.Args.Get("per_page"), "50")
+	flickr.Expect(t, len(events), 3)
+	flickr.Expect(t, events[0].Nsid, "49485425@N00")
+	flickr.Expect(t, events[0].Time.Equal(time.Unix(1166573724, 0)), true)
+	flickr.Expect(t, events[1].Username, "Bees")
+	flickr.Expect(t, events[2].Nsid, "33939862@N00")
+}
+
+func TestTakenHistogram(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photocounts>
+    <photocount count="12" fromdate="2015-01-01 00:00:00" todate="2015-02-01 00:00:00" />
+    <photocount count="0" fromdate="2015-02-01 00:00:00" todate="2015-03-01 00:00:00" />
+    <photocount count="3" fromdate="2015-03-01 00:00:00" todate="2015-03-15 00:00:00" />
+  </photocounts>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	from := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2015, 3, 15, 0, 0, 0, 0, time.UTC)
+	histogram, err := TakenHistogram(fclient, from, to, BucketMonth)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("taken_dates"), "2015-01-01 00:00:00,2015-02-01 00:00:00,2015-03-01 00:00:00,2015-03-15 00:00:00")
+	flickr.Expect(t, len(histogram.Buckets), 3)
+	flickr.Expect(t, histogram.Buckets[0].Start, from)
+	flickr.Expect(t, histogram.Buckets[0].Count, 12)
+	flickr.Expect(t, histogram.Buckets[1].End, time.Date(2015, 3, 1, 0, 0, 0, 0, time.UTC))
+	flickr.Expect(t, histogram.Buckets[2].End, to)
+	flickr.Expect(t, histogram.Buckets[2].Count, 3)
+
+	dates, _ := bucketBoundaries(from, from.AddDate(0, 0, 14), BucketWeek)
+	flickr.Expect(t, len(dates), 3)
+	dates, _ = bucketBoundaries(from, from.AddDate(0, 0, 2), BucketDay)
+	flickr.Expect(t, len(dates), 3)
+	dates, _ = bucketBoundaries(from, from.AddDate(0, 0, 2), BucketYear)
+	flickr.Expect(t, len(dates), 2)
+	dates, err = bucketBoundaries(from, from.AddDate(0, 0, maxHistogramBuckets), BucketDay)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(dates), maxHistogramBuckets+1)
+
+	for _, c := range []struct {
+		from, to time.Time
+		bucket   string
+	}{
+		{to, from, BucketMonth},
+		{from, from, BucketDay},
+		{from, to, "decade"},
+		{from, from.AddDate(0, 0, maxHistogramBuckets+1), BucketDay},
+	} {
+		histogram, err = TakenHistogram(fclient, c.from, c.to, c.bucket)
+		flickr.Expect(t, histogram == nil, true)
+		ferr, ok := err.(*flickErr.Error)
+		flickr.Expect(t, ok, true)
+		flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	}
+}
+
+func TestGetCounts(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photocounts>
+    <photocount count="4" fromdate="1093566950" todate="1093653350" />
+    <photocount count="0" fromdate="1093653350" todate="1093739750" />
+  </photocounts>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	uploaded := []time.Time{time.Unix(1093566950, 0), time.Unix(1093653350, 0), time.Unix(1093739750, 0)}
+	resp, err := GetCounts(fclient, nil, uploaded)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.getCounts")
+	flickr.Expect(t, fclient.Args.Get("dates"), "1093566950,1093653350,1093739750")
+	_, found := fclient.Args["taken_dates"]
+	flickr.Expect(t, found, false)
+	flickr.Expect(t, len(resp.PhotoCounts), 2)
+	flickr.Expect(t, resp.PhotoCounts[0].Count, 4)
+	flickr.Expect(t, resp.PhotoCounts[1].FromDate, "1093653350")
+
+	taken := []time.Time{time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)}
+	_, err = GetCounts(fclient, taken, nil)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("taken_dates"), "2015-01-01 00:00:00,2016-01-01 00:00:00")
+	_, found = fclient.Args["dates"]
+	flickr.Expect(t, found, false)
+
+	resp, err = GetCounts(fclient, nil, []time.Time{})
+	flickr.Expect(t, resp == nil, true)
+	ferr, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+}
+
+func TestGetContext(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <count>3</count>
+  <prevphoto id="2980" secret="973da1e709" server="1" farm="1" title="boo!" url="/photos/bees/2980/" />
+  <nextphoto id="2985" secret="059b664012" server="1" farm="1" title="Amsterdam Amstel" url="/photos/bees/2985/" />
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetContext(fclient, "2983")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.getContext")
+	flickr.Expect(t, fclient.Args.Get("photo_id"), "2983")
+	flickr.Expect(t, resp.Count, 3)
+	flickr.Expect(t, resp.PrevPhoto.Id, "2980")
+	flickr.Expect(t, resp.PrevPhoto.Title, "boo!")
+	flickr.Expect(t, resp.NextPhoto.Secret, "059b664012")
+	flickr.Expect(t, resp.NextPhoto.Url, "/photos/bees/2985/")
+}
+
+func TestGetContextBoundaries(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <count>1</count>
+  <prevphoto id="0" />
+  <nextphoto id="0" />
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetContext(fclient, "2983")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.PrevPhoto == nil, true)
+	flickr.Expect(t, resp.NextPhoto == nil, true)
+}
+
+func TestSetDates(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	posted := time.Unix(1361132046, 0)
+	taken := time.Date(2012, 8, 3, 17, 4, 5, 0, time.UTC)
+	_, err := SetDates(fclient, "123", posted, taken, TakenGranularityMonth)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.HTTPVerb, "POST")
+	flickr.Expect(t, fclient.Args.Get("date_posted"), "1361132046")
+	flickr.Expect(t, fclient.Args.Get("date_taken"), "2012-08-03 17:04:05")
+	flickr.Expect(t, fclient.Args.Get("date_taken_granularity"), "4")
+
+	_, err = SetDates(fclient, "123", posted, time.Time{}, TakenGranularityExact)
+	flickr.Expect(t, err, nil)
+	_, found := fclient.Args["date_taken"]
+	flickr.Expect(t, found, false)
+	_, found = fclient.Args["date_taken_granularity"]
+	flickr.Expect(t, found, false)
+
+	_, err = SetDates(fclient, "123", time.Time{}, taken, TakenGranularityExact)
+	flickr.Expect(t, err, nil)
+	_, found = fclient.Args["date_posted"]
+	flickr.Expect(t, found, false)
+}
+
+func TestSetDatesInvalid(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	for _, c := range []struct {
+		posted, taken time.Time
+		granularity   int
+	}{
+		{time.Time{}, time.Time{}, 0},
+		{time.Unix(1361132046, 0), time.Time{}, 5},
+	} {
+		resp, err := SetDates(fclient, "123", c.posted, c.taken, c.granularity)
+		flickr.Expect(t, resp == nil, true)
+		ferr, ok := err.(*flickErr.Error)
+		flickr.Expect(t, ok, true)
+		flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	}
+}
+
+func TestAddTags(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := AddTags(fclient, "123", []string{"milan", "duomo di milano"})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.addTags")
+	flickr.Expect(t, fclient.Args.Get("tags"), `milan "duomo di milano"`)
+
+	_, err = AddTags(fclient, "123", nil)
+	ferr, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+}
+
+func TestSetTags(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := SetTags(fclient, "123", []string{"New York", "nyc"})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.setTags")
+	flickr.Expect(t, fclient.Args.Get("tags"), `"New York" nyc`)
+
+	_, err = SetTags(fclient, "123", nil)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("tags"), "")
+}
+
+func TestRemoveTag(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := RemoveTag(fclient, "12037949754-2733-156")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.removeTag")
+	flickr.Expect(t, fclient.Args.Get("tag_id"), "12037949754-2733-156")
+}
+
+func TestPhotoInfoOriginalURL(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photo id="2733" secret="123456" server="12" farm="1" originalsecret="1bc09ce34a" originalformat="png" />
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetInfo(fclient, "2733", "")
+	flickr.Expect(t, err, nil)
+	url, err := flickr.OriginalPhotoURL(&resp.Photo)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, url, "https://live.staticflickr.com/12/2733_1bc09ce34a_o.png")
+	flickr.Expect(t, flickr.PhotoURL(&resp.Photo, "z"), "https://live.staticflickr.com/12/2733_123456_z.jpg")
+}
+
+func TestGetInfoPublicPhoto(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photo id="2733" secret="123456" server="12" farm="1" dateuploaded="1436176130" isfavorite="0" license="3" rotation="90" media="photo">
+    <owner nsid="12037949754@N01" username="Bees" realname="Cal Henderson" location="Bedford, UK" path_alias="bees" />
+    <title>orford_castle_taster</title>
+    <visibility ispublic="1" isfriend="0" isfamily="0" />
+  </photo>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetInfo(fclient, "2733", "")
+	flickr.Expect(t, err, nil)
+	_, found := fclient.Args["secret"]
+	flickr.Expect(t, found, false)
+	flickr.Expect(t, resp.Photo.Owner.Nsid, "12037949754@N01")
+	flickr.Expect(t, resp.Photo.Owner.PathAlias, "bees")
+	flickr.Expect(t, resp.Photo.Title, "orford_castle_taster")
+	flickr.Expect(t, resp.Photo.Rotation, 90)
+	flickr.Expect(t, resp.Photo.Permalink(), "https://www.flickr.com/photos/bees/2733")
+	resp.Photo.Owner.PathAlias = ""
+	flickr.Expect(t, resp.Photo.Permalink(), "https://www.flickr.com/photos/12037949754@N01/2733")
+
+	_, err = GetInfo(fclient, "2733", "123456")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("secret"), "123456")
+}
+
+func TestGetInfoPopularity(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photo id="2733" secret="123456" server="12" farm="1" dateuploaded="1436176130" isfavorite="0" license="3" rotation="90" views="1234" media="photo">
+    <owner nsid="12037949754@N01" username="Bees" realname="Cal Henderson" location="Bedford, UK" path_alias="bees" />
+    <title>orford_castle_taster</title>
+    <comments>7</comments>
+    <notes>
+      <note id="313" author="12037949754@N01" authorname="Bees" x="10" y="10" w="50" h="50">foo</note>
+      <note id="314" author="12037949754@N01" authorname="Bees" x="60" y="20" w="30" h="40">bar</note>
+    </notes>
+    <people haspeople="1" />
+    <urls>
+      <url type="photopage">https://www.flickr.com/photos/bees/2733/</url>
+    </urls>
+  </photo>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetInfo(fclient, "2733", "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Photo.Popularity(), Popularity{Views: 1234, Comments: 7, Notes: 2})
+	flickr.Expect(t, resp.Photo.Notes[1].Id, "314")
+	flickr.Expect(t, resp.Photo.Notes[1].W, 30)
+	flickr.Expect(t, resp.Photo.Notes[1].Text, "bar")
+	flickr.Expect(t, resp.Photo.People.HasPeople, true)
+	flickr.Expect(t, resp.Photo.PageURL(), "https://www.flickr.com/photos/bees/2733/")
+
+	resp.Photo.Urls = nil
+	flickr.Expect(t, resp.Photo.PageURL(), "")
+	flickr.Expect(t, (&PhotoInfo{}).Popularity(), Popularity{})
+}
+
+func TestGetInfoEditability(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photo id="2733" secret="123456" server="12" farm="1" dateuploaded="1436176130" isfavorite="0" license="3" rotation="90" media="photo">
+    <owner nsid="12037949754@N01" username="Bees" realname="Cal Henderson" location="Bedford, UK" path_alias="bees" />
+    <title>orford_castle_taster</title>
+    <visibility ispublic="1" isfriend="0" isfamily="0" />
+    <permissions permcomment="3" permaddmeta="2" />
+    <editability cancomment="1" canaddmeta="0" />
+    <publiceditability cancomment="0" canaddmeta="0" />
+  </photo>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetInfo(fclient, "2733", "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Photo.IsOwnedBy("12037949754@N01"), true)
+	flickr.Expect(t, resp.Photo.IsOwnedBy("98765432@N01"), false)
+	flickr.Expect(t, resp.Photo.IsOwnedBy(""), false)
+	flickr.Expect(t, resp.Photo.Permissions.PermComment, "3")
+	flickr.Expect(t, resp.Photo.Permissions.PermAdMeta, "2")
+	flickr.Expect(t, resp.Photo.CanComment(), true)
+	flickr.Expect(t, resp.Photo.CanAddMeta(), false)
+
+	resp.Photo.PublicEditability.CanAddMeta = "1"
+	flickr.Expect(t, resp.Photo.CanAddMeta(), true)
+	flickr.Expect(t, (&PhotoInfo{}).IsOwnedBy(""), false)
+	flickr.Expect(t, (&PhotoInfo{}).CanComment(), false)
+}
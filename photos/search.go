@@ -0,0 +1,481 @@
+package photos
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// A geographic bounding box, coordinates are in decimal degrees
+type BBox struct {
+	MinLon, MinLat, MaxLon, MaxLat float64
+}
+
+// Tell whether none of values is NaN or infinite
+func areFinite(values ...float64) bool {
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// Check the box is within valid coordinates and its corners are in the right order
+func (b *BBox) Validate() error {
+	if !areFinite(b.MinLon, b.MinLat, b.MaxLon, b.MaxLat) {
+		return flickErr.NewError(flickErr.ArgumentError, "bbox coordinates must be finite numbers: "+b.String())
+	}
+	if b.MinLon < -180 || b.MaxLon > 180 {
+		return flickErr.NewError(flickErr.ArgumentError, fmt.Sprintf("bbox longitudes out of range [-180, 180]: %v, %v", b.MinLon, b.MaxLon))
+	}
+	if b.MinLat < -90 || b.MaxLat > 90 {
+		return flickErr.NewError(flickErr.ArgumentError, fmt.Sprintf("bbox latitudes out of range [-90, 90]: %v, %v", b.MinLat, b.MaxLat))
+	}
+	if b.MinLon >= b.MaxLon || b.MinLat >= b.MaxLat {
+		return flickErr.NewError(flickErr.ArgumentError, "bbox minimum coordinates must be lower than maximum ones")
+	}
+	return nil
+}
+
+// Format the box the way Flickr expects it: min_lon,min_lat,max_lon,max_lat
+func (b *BBox) String() string {
+	coords := []float64{b.MinLon, b.MinLat, b.MaxLon, b.MaxLat}
+	ret := make([]string, len(coords))
+	for i, c := range coords {
+		ret[i] = strconv.FormatFloat(c, 'f', -1, 64)
+	}
+	return strings.Join(ret, ",")
+}
+
+// Units of the radius of a Radial search
+const (
+	RadiusKilometers = "km"
+	RadiusMiles      = "mi"
+)
+
+// A circle around a point, coordinates are in decimal degrees. Flickr accepts radiuses
+// up to 32 km (20 miles) and defaults to 5 km.
+type Radial struct {
+	Lat, Lon float64
+	Radius   float64
+	// RadiusKilometers (the default if empty) or RadiusMiles
+	Units string
+}
+
+// Check the point coordinates and the radius
+func (r *Radial) Validate() error {
+	if !areFinite(r.Lat, r.Lon, r.Radius) {
+		return flickErr.NewError(flickErr.ArgumentError, fmt.Sprintf("radial search values must be finite numbers: %v, %v, %v", r.Lat, r.Lon, r.Radius))
+	}
+	if r.Lat < -90 || r.Lat > 90 || r.Lon < -180 || r.Lon > 180 {
+		return flickErr.NewError(flickErr.ArgumentError, fmt.Sprintf("radial search point out of range: %v, %v", r.Lat, r.Lon))
+	}
+	max := 32.0
+	switch r.Units {
+	case "", RadiusKilometers:
+	case RadiusMiles:
+		max = 20
+	default:
+		return flickErr.NewError(flickErr.ArgumentError, "invalid radius units "+r.Units)
+	}
+	if r.Radius <= 0 || r.Radius > max {
+		return flickErr.NewError(flickErr.ArgumentError, fmt.Sprintf("radius must be greater than 0 and at most %v: %v", max, r.Radius))
+	}
+	return nil
+}
+
+// Whether photos were taken indoors or outdoors
+type GeoContext int
+
+const (
+	// The filter is not sent
+	NoGeoContextSpecified GeoContext = iota
+	GeoContextIndoors
+	GeoContextOutdoors
+)
+
+// Check the value is one of the constants
+func (c GeoContext) Validate() error {
+	if c < NoGeoContextSpecified || c > GeoContextOutdoors {
+		return flickErr.NewError(flickErr.ArgumentError, fmt.Sprintf("invalid geo context %d", c))
+	}
+	return nil
+}
+
+// Contacts whose photos are searched along with the ones of SearchParams.UserId
+const (
+	ContactsAll           = "all"
+	ContactsFriendsFamily = "ff"
+)
+
+// Media types searched by SearchParams.Media
+const (
+	MediaAll    = "all"
+	MediaPhotos = "photos"
+	MediaVideos = "videos"
+)
+
+// Search criteria for Search, zero values are not sent
+type SearchParams struct {
+	// Flickr ID of the owner, "me" for the calling user
+	UserId string
+	// Search photos of the contacts of UserId too: ContactsAll or ContactsFriendsFamily.
+	// Flickr silently ignores it without UserId, so it is refused instead.
+	Contacts string
+	// Free text search on title, description and tags
+	Text string
+	// Photos tagged with any of the tags, or all of them if TagModeAll is set.
+	// TagModeAll without Tags is refused.
+	Tags       []string
+	TagModeAll bool
+	// Dates limiting the search, converted to the format Flickr expects for each kind:
+	// unix timestamps for upload dates, mysql datetimes in the time location for
+	// taken dates
+	MinUploadDate, MaxUploadDate time.Time
+	MinTakenDate, MaxTakenDate   time.Time
+	// Limit results to photos geotagged within the box
+	BBox *BBox
+	// Limit results to photos geotagged within the circle. Lat, Lon and Radius
+	// are sent together, Flickr ignores them otherwise
+	Radial *Radial
+	// Only geotagged photos
+	HasGeo     bool
+	GeoContext GeoContext
+	// Only photos belonging to a gallery
+	InGallery bool
+	// Only photos part of the Flickr Commons project
+	IsCommons       bool
+	FoursquareWoeId string
+	// flickr.SortRelevance needs a Text query: without one Flickr has nothing to rank
+	// photos by and returns them in no meaningful order, so it is refused instead
+	Sort flickr.SortOrder
+	// Only photos with this safety level, sent as safe_search
+	SafetyLevel flickr.SafetyLevel
+	// Only photos with this content type
+	ContentType flickr.ContentType
+	// Restrict results to MediaPhotos or MediaVideos, Flickr defaults to MediaAll
+	Media string
+	// Comma separated list of extra fields, see flickr.Extras
+	Extras  string
+	Page    int
+	PerPage int
+	// Stop SearchAll after this number of pages, 0 for no limit
+	MaxPages int
+	// Skip, in SearchAll and SearchStream, the photos already returned by the previous
+	// pages within the window of Dedupe, nil to keep them. Each SearchStream call is a
+	// page; Dedupe.Skipped tells how many photos were skipped.
+	Dedupe *flickr.Dedupe
+}
+
+// Flickr needs some limiting factor along with geo queries, without one it returns no photos
+func (p *SearchParams) hasLimitingFactor() bool {
+	return p.UserId != "" || p.Text != "" || len(p.Tags) > 0 ||
+		!p.MinUploadDate.IsZero() || !p.MaxUploadDate.IsZero() ||
+		!p.MinTakenDate.IsZero() || !p.MaxTakenDate.IsZero()
+}
+
+// Check the params, before any request is performed
+func (p *SearchParams) validate() error {
+	if err := p.Sort.Validate(); err != nil {
+		return err
+	}
+	if p.Sort == flickr.SortRelevance && p.Text == "" {
+		return flickErr.NewError(flickErr.ArgumentError, "relevance sort needs a text query")
+	}
+	if err := p.GeoContext.Validate(); err != nil {
+		return err
+	}
+	if err := p.SafetyLevel.Validate(); err != nil {
+		return err
+	}
+	if err := p.ContentType.Validate(); err != nil {
+		return err
+	}
+	switch p.Contacts {
+	case "":
+	case ContactsAll, ContactsFriendsFamily:
+		if p.UserId == "" {
+			return flickErr.NewError(flickErr.ArgumentError, "contacts searches need a user")
+		}
+	default:
+		return flickErr.NewError(flickErr.ArgumentError, "invalid contacts filter "+p.Contacts)
+	}
+	switch p.Media {
+	case "", MediaAll, MediaPhotos, MediaVideos:
+	default:
+		return flickErr.NewError(flickErr.ArgumentError, "invalid media filter "+p.Media)
+	}
+	if p.TagModeAll && len(p.Tags) == 0 {
+		return flickErr.NewError(flickErr.ArgumentError, "tag mode set without tags")
+	}
+	if p.Radial != nil {
+		if err := p.Radial.Validate(); err != nil {
+			return err
+		}
+	}
+	if p.BBox != nil {
+		if err := p.BBox.Validate(); err != nil {
+			return err
+		}
+		if !p.hasLimitingFactor() {
+			return flickErr.NewError(flickErr.ArgumentError, "bbox searches need a user, text, tags or date to limit results")
+		}
+	}
+	return nil
+}
+
+// Set client Args from the params
+func (p *SearchParams) setArgs(client *flickr.FlickrClient) {
+	if p.UserId != "" {
+		client.Args.Set("user_id", p.UserId)
+	}
+	if p.Contacts != "" {
+		client.Args.Set("contacts", p.Contacts)
+	}
+	if p.Text != "" {
+		client.Args.Set("text", p.Text)
+	}
+	if len(p.Tags) > 0 {
+		client.Args.Set("tags", strings.Join(p.Tags, ","))
+		if p.TagModeAll {
+			client.Args.Set("tag_mode", "all")
+		}
+	}
+	var setDate = func(key string, t time.Time, layout string) {
+		if t.IsZero() {
+			return
+		}
+		if layout == "" {
+			client.Args.Set(key, strconv.FormatInt(t.Unix(), 10))
+		} else {
+			client.Args.Set(key, t.Format(layout))
+		}
+	}
+	setDate("min_upload_date", p.MinUploadDate, "")
+	setDate("max_upload_date", p.MaxUploadDate, "")
+	setDate("min_taken_date", p.MinTakenDate, flickr.TakenDateLayout)
+	setDate("max_taken_date", p.MaxTakenDate, flickr.TakenDateLayout)
+	if p.BBox != nil {
+		client.Args.Set("bbox", p.BBox.String())
+	}
+	if p.Radial != nil {
+		client.Args.Set("lat", strconv.FormatFloat(p.Radial.Lat, 'f', -1, 64))
+		client.Args.Set("lon", strconv.FormatFloat(p.Radial.Lon, 'f', -1, 64))
+		client.Args.Set("radius", strconv.FormatFloat(p.Radial.Radius, 'f', -1, 64))
+		if p.Radial.Units != "" {
+			client.Args.Set("radius_units", p.Radial.Units)
+		}
+	}
+	if p.HasGeo {
+		client.Args.Set("has_geo", "1")
+	}
+	if p.GeoContext != NoGeoContextSpecified {
+		client.Args.Set("geo_context", strconv.Itoa(int(p.GeoContext)))
+	}
+	if p.InGallery {
+		client.Args.Set("in_gallery", "1")
+	}
+	if p.IsCommons {
+		client.Args.Set("is_commons", "1")
+	}
+	if p.FoursquareWoeId != "" {
+		client.Args.Set("foursquare_woeid", p.FoursquareWoeId)
+	}
+	if p.Sort != flickr.NoSortSpecified {
+		client.Args.Set("sort", string(p.Sort))
+	}
+	if p.SafetyLevel != flickr.NoSafetyLevelSpecified {
+		client.Args.Set("safe_search", strconv.Itoa(int(p.SafetyLevel)))
+	}
+	if p.ContentType != flickr.NoContentTypeSpecified {
+		client.Args.Set("content_type", strconv.Itoa(int(p.ContentType)))
+	}
+	if p.Media != "" {
+		client.Args.Set("media", p.Media)
+	}
+	setListArgs(client, p.Page, p.PerPage, p.Extras)
+}
+
+// Return a list of photos matching the search criteria. Only public photos are returned
+// unless the call is authenticated.
+// An ArgumentError is returned without performing the request if params are not valid.
+// This method does not require authentication.
+func Search(client *flickr.FlickrClient, authenticate bool, params *SearchParams) (*PhotosResponse, error) {
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+
+	client.Init()
+	client.Args.Set("method", "flickr.photos.search")
+	params.setArgs(client)
+	if authenticate {
+		client.OAuthSign()
+	} else {
+		client.ApiSign()
+	}
+
+	response := &PhotosResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Tag modes of SearchByTags
+const (
+	TagModeAny = "any"
+	TagModeAll = "all"
+)
+
+// Return the photos tagged with any (TagModeAny, the default when empty) or all
+// (TagModeAll) of the tags. Tags holding spaces are quoted, so that Flickr matches them
+// as a whole, and tags are joined with commas; tags holding commas or quotes can't be
+// searched and are refused. The call is authenticated when the client holds an access
+// token. Zero values for extras and page are not sent, perPage defaults to
+// flickr.DEFAULT_PER_PAGE.
+// An ArgumentError is returned without performing the request for invalid tags or mode.
+// This method does not require authentication.
+func SearchByTags(client *flickr.FlickrClient, tags []string, mode string, extras string, page, perPage int) (*PhotosResponse, error) {
+	if mode != "" && mode != TagModeAny && mode != TagModeAll {
+		return nil, flickErr.NewError(flickErr.ArgumentError, "invalid tag mode "+mode)
+	}
+	params := &SearchParams{
+		TagModeAll: mode == TagModeAll,
+		Extras:     extras,
+		Page:       page,
+		PerPage:    perPage,
+	}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if strings.ContainsAny(tag, `,"`) {
+			return nil, flickErr.NewError(flickErr.ArgumentError, fmt.Sprintf("tag %q can't be searched", tag))
+		}
+		if strings.ContainsAny(tag, " \t") {
+			tag = `"` + tag + `"`
+		}
+		params.Tags = append(params.Tags, tag)
+	}
+	if len(params.Tags) == 0 {
+		return nil, flickErr.NewError(flickErr.ArgumentError, "no tags to search")
+	}
+
+	return Search(client, client.OAuthToken != "", params)
+}
+
+// Same as Search, but photos are passed to fn one at a time while the response is
+// read, instead of being collected in the response, so that pages with many photos and
+// extras can be processed in bounded memory. The first error returned by fn stops
+// decoding and is returned. The response holds the paging details but no photos.
+// Responses are not cached.
+// An ArgumentError is returned without performing the request if params are not valid.
+// This method does not require authentication.
+func SearchStream(client *flickr.FlickrClient, authenticate bool, params *SearchParams, fn func(p *flickr.Photo) error) (*PhotosResponse, error) {
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+
+	client.Init()
+	client.Args.Set("method", "flickr.photos.search")
+	params.setArgs(client)
+	if authenticate {
+		client.OAuthSign()
+	} else {
+		client.ApiSign()
+	}
+
+	if params.Dedupe != nil {
+		params.Dedupe.NextPage()
+	}
+
+	response := &PhotosResponse{}
+	err := flickr.DoGetStream(client, &response.BasicResponse, func(d *xml.Decoder, start xml.StartElement) error {
+		if start.Name.Local != "photos" {
+			return d.Skip()
+		}
+		response.setPaging(start.Attr)
+		for {
+			tok, err := d.Token()
+			if err != nil {
+				return err
+			}
+			switch t := tok.(type) {
+			case xml.StartElement:
+				if t.Name.Local != "photo" {
+					if err := d.Skip(); err != nil {
+						return err
+					}
+					continue
+				}
+				var p flickr.Photo
+				if err := d.DecodeElement(&p, &t); err != nil {
+					return err
+				}
+				if params.Dedupe != nil && params.Dedupe.Seen(p.Id) {
+					continue
+				}
+				if err := fn(&p); err != nil {
+					return err
+				}
+			case xml.EndElement:
+				return nil
+			}
+		}
+	})
+	return response, err
+}
+
+// Set the paging details from the attributes of the photos element
+func (r *PhotosResponse) setPaging(attrs []xml.Attr) {
+	for _, attr := range attrs {
+		n, _ := strconv.Atoi(attr.Value)
+		switch attr.Name.Local {
+		case "page":
+			r.Photos.Page = n
+		case "pages":
+			r.Photos.Pages = n
+		case "perpage":
+			r.Photos.PerPage = n
+		case "total":
+			r.Photos.Total = n
+		}
+	}
+}
+
+// Return the photos matching the search criteria from all the result pages, starting
+// from the first one regardless of params.Page. If params.MaxPages is set, pages after
+// that are not fetched and truncated is true; photos fetched so far are returned.
+// Photos repeated across pages are skipped when params.Dedupe is set.
+// An ArgumentError is returned without performing the request if params are not valid.
+// This method does not require authentication.
+func SearchAll(client *flickr.FlickrClient, authenticate bool, params *SearchParams) (photos []flickr.Photo, truncated bool, err error) {
+	p := *params
+	pages, truncated, err := flickr.Paginate(params.MaxPages, func(page int) (flickr.Pager, error) {
+		p.Page = page
+		res, err := Search(client, authenticate, &p)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	for _, page := range pages {
+		if params.Dedupe == nil {
+			photos = append(photos, page.(*PhotosResponse).Photos.Photos...)
+			continue
+		}
+		params.Dedupe.NextPage()
+		for _, photo := range page.(*PhotosResponse).Photos.Photos {
+			if !params.Dedupe.Seen(photo.Id) {
+				photos = append(photos, photo)
+			}
+		}
+	}
+	return photos, truncated, err
+}
@@ -0,0 +1,454 @@
+package photos
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestSearch(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photos page="1" pages="1" perpage="100" total="1">
+    <photo id="2636" owner="47058503995@N01" secret="a123456" server="2" farm="1" title="test_04" ispublic="1" isfriend="0" isfamily="0" />
+  </photos>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	params := &SearchParams{
+		Tags:         []string{"duomo", "milan"},
+		TagModeAll:   true,
+		MinTakenDate: time.Date(2015, 1, 2, 3, 4, 5, 0, time.UTC),
+		BBox:         &BBox{MinLon: 9.1, MinLat: 45.4, MaxLon: 9.25, MaxLat: 45.5},
+		Sort:         flickr.SortDateTakenDesc,
+		PerPage:      100,
+	}
+	resp, err := Search(fclient, false, params)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.search")
+	flickr.Expect(t, fclient.Args.Get("tags"), "duomo,milan")
+	flickr.Expect(t, fclient.Args.Get("tag_mode"), "all")
+	flickr.Expect(t, fclient.Args.Get("min_taken_date"), "2015-01-02 03:04:05")
+	flickr.Expect(t, fclient.Args.Get("bbox"), "9.1,45.4,9.25,45.5")
+	flickr.Expect(t, fclient.Args.Get("sort"), "date-taken-desc")
+	flickr.Expect(t, fclient.Args.Get("per_page"), "100")
+	for _, arg := range []string{"user_id", "text", "min_upload_date", "max_taken_date", "page", "extras"} {
+		_, found := fclient.Args[arg]
+		flickr.Expect(t, found, false)
+	}
+	flickr.Expect(t, resp.Photos.Photos[0].Id, "2636")
+}
+
+func TestSearchStream(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photos page="2" pages="3" perpage="2" total="6">
+    <photo id="2636" owner="47058503995@N01" secret="a123456" server="2" farm="1" title="test_04" ispublic="1" isfriend="0" isfamily="0" />
+    <photo id="2635" owner="12037949754@N01" secret="b123456" server="2" farm="1" title="test_03" ispublic="0" isfriend="1" isfamily="0">
+      <description>A test</description>
+    </photo>
+  </photos>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	var ids []string
+	resp, err := SearchStream(fclient, false, &SearchParams{Text: "milan", Page: 2}, func(p *flickr.Photo) error {
+		ids = append(ids, p.Id)
+		if p.Id == "2635" {
+			flickr.Expect(t, p.Description, "A test")
+			flickr.Expect(t, p.IsFriend, true)
+		}
+		return nil
+	})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.search")
+	flickr.Expect(t, fclient.Args.Get("text"), "milan")
+	flickr.Expect(t, strings.Join(ids, ","), "2636,2635")
+	flickr.Expect(t, resp.Page(), 2)
+	flickr.Expect(t, resp.Pages(), 3)
+	flickr.Expect(t, resp.Photos.Total, 6)
+	flickr.Expect(t, len(resp.Photos.Photos), 0)
+
+	ids = nil
+	stop := fmt.Errorf("stop")
+	_, err = SearchStream(fclient, false, &SearchParams{Text: "milan"}, func(p *flickr.Photo) error {
+		ids = append(ids, p.Id)
+		return stop
+	})
+	flickr.Expect(t, err, stop)
+	flickr.Expect(t, len(ids), 1)
+
+	_, err = SearchStream(fclient, false, &SearchParams{Sort: "newest"}, nil)
+	ferr, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+}
+
+func TestSearchDates(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok"><photos page="1" pages="0" perpage="100" total="0" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := Search(fclient, false, &SearchParams{
+		MinUploadDate: time.Date(2015, 1, 2, 3, 4, 5, 0, time.UTC),
+		MaxUploadDate: time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC),
+		MinTakenDate:  time.Date(2014, 6, 7, 8, 9, 10, 0, time.UTC),
+		MaxTakenDate:  time.Date(2014, 12, 31, 23, 59, 59, 0, time.UTC),
+	})
+	flickr.Expect(t, err, nil)
+	// upload dates are unix timestamps, taken dates mysql datetimes
+	flickr.Expect(t, fclient.Args.Get("min_upload_date"), "1420167845")
+	flickr.Expect(t, fclient.Args.Get("max_upload_date"), "1451703845")
+	flickr.Expect(t, fclient.Args.Get("min_taken_date"), "2014-06-07 08:09:10")
+	flickr.Expect(t, fclient.Args.Get("max_taken_date"), "2014-12-31 23:59:59")
+
+	_, err = Search(fclient, false, &SearchParams{Text: "milan", MaxTakenDate: time.Date(2014, 12, 31, 0, 0, 0, 0, time.UTC)})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("max_taken_date"), "2014-12-31 00:00:00")
+	for _, arg := range []string{"min_upload_date", "max_upload_date", "min_taken_date"} {
+		_, found := fclient.Args[arg]
+		flickr.Expect(t, found, false)
+	}
+}
+
+func TestSearchInvalidBBox(t *testing.T) {
+	since := time.Date(2015, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, b := range []BBox{
+		{-181, 0, 10, 10},
+		{0, 0, 180.5, 10},
+		{0, -91, 10, 10},
+		{0, 0, 10, 90.1},
+		// inverted
+		{10, 0, 0, 10},
+		{0, 10, 10, 0},
+		// empty
+		{5, 5, 5, 5},
+		{math.NaN(), 0, 10, 10},
+		{0, 0, 10, math.NaN()},
+	} {
+		box := b
+		resp, err := Search(flickr.GetTestClient(), false, &SearchParams{BBox: &box, MinUploadDate: since})
+		flickr.Expect(t, resp == nil, true)
+		ferr, ok := err.(*flickErr.Error)
+		flickr.Expect(t, ok, true)
+		flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	}
+}
+
+func TestSearchBBoxWithoutLimit(t *testing.T) {
+	params := &SearchParams{BBox: &BBox{MinLon: 9.1, MinLat: 45.4, MaxLon: 9.25, MaxLat: 45.5}}
+	_, err := Search(flickr.GetTestClient(), false, params)
+	ferr, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+
+	params.MinUploadDate = time.Now()
+	flickr.Expect(t, params.validate(), nil)
+}
+
+func TestSearchInvalidSort(t *testing.T) {
+	_, err := Search(flickr.GetTestClient(), false, &SearchParams{Text: "milan", Sort: "newest"})
+	ferr, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+}
+
+func TestSearchRelevance(t *testing.T) {
+	_, err := Search(flickr.GetTestClient(), false, &SearchParams{Tags: []string{"milan"}, Sort: flickr.SortRelevance})
+	ferr, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photos page="1" pages="1" perpage="100" total="2">
+    <photo id="2636" owner="47058503995@N01" secret="a123456" server="2" farm="1" title="duomo" relevance="0.92" />
+    <photo id="2637" owner="47058503995@N01" secret="a123457" server="2" farm="1" title="milan" />
+  </photos>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := Search(fclient, false, &SearchParams{Text: "duomo", Sort: flickr.SortRelevance})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("sort"), "relevance")
+	flickr.Expect(t, resp.Photos.Photos[0].Relevance, 0.92)
+	flickr.Expect(t, resp.Photos.Photos[1].Relevance, 0.0)
+}
+
+func TestSearchContacts(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok"><photos page="1" pages="0" perpage="100" total="0" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := Search(fclient, true, &SearchParams{UserId: "me", Contacts: ContactsFriendsFamily, Text: "milan"})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("user_id"), "me")
+	flickr.Expect(t, fclient.Args.Get("contacts"), "ff")
+
+	_, err = Search(fclient, true, &SearchParams{UserId: "me"})
+	flickr.Expect(t, err, nil)
+	_, found := fclient.Args["contacts"]
+	flickr.Expect(t, found, false)
+
+	for _, params := range []*SearchParams{
+		{Contacts: ContactsAll, Text: "milan"},
+		{UserId: "me", Contacts: "friends"},
+		{Text: "milan", TagModeAll: true},
+	} {
+		resp, err := Search(flickr.GetTestClient(), false, params)
+		flickr.Expect(t, resp == nil, true)
+		ferr, ok := err.(*flickErr.Error)
+		flickr.Expect(t, ok, true)
+		flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	}
+}
+
+func TestSearchGeoFilters(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok"><photos page="1" pages="0" perpage="100" total="0" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	params := &SearchParams{
+		Radial:     &Radial{Lat: 45.4642, Lon: 9.19, Radius: 2.5, Units: RadiusMiles},
+		HasGeo:     true,
+		GeoContext: GeoContextOutdoors,
+		InGallery:  true,
+		IsCommons:  true,
+	}
+	_, err := Search(fclient, false, params)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("lat"), "45.4642")
+	flickr.Expect(t, fclient.Args.Get("lon"), "9.19")
+	flickr.Expect(t, fclient.Args.Get("radius"), "2.5")
+	flickr.Expect(t, fclient.Args.Get("radius_units"), "mi")
+	flickr.Expect(t, fclient.Args.Get("has_geo"), "1")
+	flickr.Expect(t, fclient.Args.Get("geo_context"), "2")
+	flickr.Expect(t, fclient.Args.Get("in_gallery"), "1")
+	flickr.Expect(t, fclient.Args.Get("is_commons"), "1")
+	_, found := fclient.Args["foursquare_woeid"]
+	flickr.Expect(t, found, false)
+
+	_, err = Search(fclient, false, &SearchParams{Text: "milan", Radial: &Radial{Lat: 45.4642, Lon: 9.19, Radius: 3}})
+	flickr.Expect(t, err, nil)
+	_, found = fclient.Args["radius_units"]
+	flickr.Expect(t, found, false)
+	for _, arg := range []string{"has_geo", "geo_context", "in_gallery", "is_commons"} {
+		_, found := fclient.Args[arg]
+		flickr.Expect(t, found, false)
+	}
+}
+
+func TestSearchInvalidGeoFilters(t *testing.T) {
+	for _, params := range []*SearchParams{
+		// radius missing
+		{Radial: &Radial{Lat: 45.4642, Lon: 9.19}},
+		{Radial: &Radial{Lat: 45.4642, Lon: 9.19, Radius: 33}},
+		{Radial: &Radial{Lat: 45.4642, Lon: 9.19, Radius: 21, Units: RadiusMiles}},
+		{Radial: &Radial{Lat: 45.4642, Lon: 9.19, Radius: 1, Units: "m"}},
+		{Radial: &Radial{Lat: 91, Lon: 9.19, Radius: 1}},
+		{Radial: &Radial{Lat: math.NaN(), Lon: 9.19, Radius: 1}},
+		{Radial: &Radial{Lat: 45.4642, Lon: 9.19, Radius: math.NaN()}},
+		{Radial: &Radial{Lat: 45.4642, Lon: math.Inf(-1), Radius: 1}},
+		{GeoContext: 3},
+	} {
+		resp, err := Search(flickr.GetTestClient(), false, params)
+		flickr.Expect(t, resp == nil, true)
+		ferr, ok := err.(*flickErr.Error)
+		flickr.Expect(t, ok, true)
+		flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	}
+}
+
+func TestSearchLevels(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok"><photos page="1" pages="0" perpage="100" total="0" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	params := &SearchParams{
+		Text:        "milan",
+		SafetyLevel: flickr.SafetyLevelModerate,
+		ContentType: flickr.ContentTypeScreenshot,
+	}
+	_, err := Search(fclient, false, params)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("safe_search"), "2")
+	flickr.Expect(t, fclient.Args.Get("content_type"), "2")
+
+	_, err = Search(fclient, false, &SearchParams{Text: "milan"})
+	flickr.Expect(t, err, nil)
+	for _, arg := range []string{"safe_search", "content_type"} {
+		_, found := fclient.Args[arg]
+		flickr.Expect(t, found, false)
+	}
+
+	for _, params := range []*SearchParams{
+		{Text: "milan", SafetyLevel: 4},
+		{Text: "milan", ContentType: -1},
+	} {
+		resp, err := Search(flickr.GetTestClient(), false, params)
+		flickr.Expect(t, resp == nil, true)
+		ferr, ok := err.(*flickErr.Error)
+		flickr.Expect(t, ok, true)
+		flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	}
+}
+
+func TestSearchMedia(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok"><photos page="1" pages="0" perpage="100" total="0" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := Search(fclient, false, &SearchParams{Text: "milan", Media: MediaVideos})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("media"), "videos")
+
+	_, err = Search(fclient, false, &SearchParams{Text: "milan"})
+	flickr.Expect(t, err, nil)
+	_, found := fclient.Args["media"]
+	flickr.Expect(t, found, false)
+
+	resp, err := Search(flickr.GetTestClient(), false, &SearchParams{Text: "milan", Media: "video"})
+	flickr.Expect(t, resp == nil, true)
+	ferr, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+}
+
+func TestSearchAll(t *testing.T) {
+	var requested []string
+	server, fclient := flickr.FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		requested = append(requested, page)
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photos page="%s" pages="3" perpage="1" total="3">
+    <photo id="photo%s" owner="47058503995@N01" secret="a123456" server="2" farm="1" title="test" ispublic="1" isfriend="0" isfamily="0" />
+  </photos>
+</rsp>`, page, page)
+	}))
+	defer server.Close()
+
+	params := &SearchParams{Text: "milan", Page: 2}
+	photos, truncated, err := SearchAll(fclient, false, params)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, truncated, false)
+	flickr.Expect(t, strings.Join(requested, ","), "1,2,3")
+	flickr.Expect(t, len(photos), 3)
+	flickr.Expect(t, photos[2].Id, "photo3")
+	// params are left untouched
+	flickr.Expect(t, params.Page, 2)
+
+	requested = nil
+	params.MaxPages = 2
+	photos, truncated, err = SearchAll(fclient, false, params)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, truncated, true)
+	flickr.Expect(t, strings.Join(requested, ","), "1,2")
+	flickr.Expect(t, len(photos), 2)
+}
+
+func TestSearchDedupe(t *testing.T) {
+	// page 2 repeats the last photo of page 1, page 3 the first one
+	ids := map[string]string{"1": "a,b", "2": "b,c", "3": "a,d"}
+	server, fclient := flickr.FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><photos page="%s" pages="3" perpage="2" total="6">`, page)
+		for _, id := range strings.Split(ids[page], ",") {
+			fmt.Fprintf(w, `<photo id="%s" owner="47058503995@N01" secret="a123456" server="2" farm="1" title="test" />`, id)
+		}
+		fmt.Fprint(w, `</photos></rsp>`)
+	}))
+	defer server.Close()
+
+	// "a" on page 3 is out of a window of 2 pages
+	params := &SearchParams{Text: "milan", Dedupe: flickr.NewDedupe(2)}
+	photos, _, err := SearchAll(fclient, false, params)
+	flickr.Expect(t, err, nil)
+	var got []string
+	for _, p := range photos {
+		got = append(got, p.Id)
+	}
+	flickr.Expect(t, strings.Join(got, ","), "a,b,c,a,d")
+	flickr.Expect(t, params.Dedupe.Skipped, 1)
+
+	params.Dedupe = flickr.NewDedupe(3)
+	got = nil
+	for page := 1; page <= 3; page++ {
+		params.Page = page
+		_, err := SearchStream(fclient, false, params, func(p *flickr.Photo) error {
+			got = append(got, p.Id)
+			return nil
+		})
+		flickr.Expect(t, err, nil)
+	}
+	flickr.Expect(t, strings.Join(got, ","), "a,b,c,d")
+	flickr.Expect(t, params.Dedupe.Skipped, 2)
+}
+
+func TestSearchByTags(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok"><photos page="1" pages="1" perpage="100" total="0"></photos></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := SearchByTags(fclient, []string{"new york", "été", " ", "東京"}, TagModeAll, "tags", 2, 0)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.search")
+	flickr.Expect(t, fclient.Args.Get("tags"), `"new york",été,東京`)
+	flickr.Expect(t, fclient.Args.Get("tag_mode"), "all")
+	flickr.Expect(t, fclient.Args.Get("extras"), "tags")
+	flickr.Expect(t, fclient.Args.Get("page"), "2")
+	flickr.Expect(t, fclient.Args.Get("per_page"), strconv.Itoa(flickr.DEFAULT_PER_PAGE))
+	// no access token
+	_, found := fclient.Args["oauth_token"]
+	flickr.Expect(t, found, false)
+
+	_, err = SearchByTags(fclient, []string{"milan"}, "", "", 0, 0)
+	flickr.Expect(t, err, nil)
+	_, found = fclient.Args["tag_mode"]
+	flickr.Expect(t, found, false)
+
+	for _, c := range []struct {
+		tags []string
+		mode string
+	}{
+		{nil, TagModeAll},
+		{[]string{" "}, ""},
+		{[]string{"a,b"}, ""},
+		{[]string{`say "cheese"`}, ""},
+		{[]string{"milan"}, "some"},
+	} {
+		resp, err := SearchByTags(fclient, c.tags, c.mode, "", 0, 0)
+		flickr.Expect(t, resp == nil, true)
+		ferr, ok := err.(*flickErr.Error)
+		flickr.Expect(t, ok, true)
+		flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	}
+}
@@ -0,0 +1,127 @@
+package photos
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// A size available for a photo (or video)
+type Size struct {
+	Label string `xml:"label,attr"`
+	// 0 when Flickr doesn't know them, ie. for some video sizes
+	Width  int    `xml:"width,attr"`
+	Height int    `xml:"height,attr"`
+	Source string `xml:"source,attr"`
+	Url    string `xml:"url,attr"`
+	Media  string `xml:"media,attr"`
+}
+
+// Whether both dimensions are known
+func (s *Size) hasDimensions() bool {
+	return s.Width > 0 && s.Height > 0
+}
+
+type SizesResponse struct {
+	flickr.BasicResponse
+	Sizes struct {
+		CanBlog     bool   `xml:"canblog,attr"`
+		CanPrint    bool   `xml:"canprint,attr"`
+		CanDownload bool   `xml:"candownload,attr"`
+		Sizes       []Size `xml:"size"`
+	} `xml:"sizes"`
+}
+
+// Return the largest size fitting within maxWidth x maxHeight or, if none fits, the
+// smallest one. Sizes are compared by area, on ties the first one listed by Flickr
+// wins; sizes with unknown dimensions are never picked. nil is returned when there
+// are no sizes with known dimensions.
+func (r *SizesResponse) BestFit(maxWidth, maxHeight int) *Size {
+	var best, smallest *Size
+	for i := range r.Sizes.Sizes {
+		s := &r.Sizes.Sizes[i]
+		if !s.hasDimensions() {
+			continue
+		}
+		area := s.Width * s.Height
+		if smallest == nil || area < smallest.Width*smallest.Height {
+			smallest = s
+		}
+		if s.Width <= maxWidth && s.Height <= maxHeight &&
+			(best == nil || area > best.Width*best.Height) {
+			best = s
+		}
+	}
+	if best == nil {
+		return smallest
+	}
+	return best
+}
+
+// Return the sizes with a known width, narrowest first; sizes of the same width are
+// left in the order listed by Flickr
+func (r *SizesResponse) SortedSizes() []Size {
+	sizes := []Size{}
+	for _, s := range r.Sizes.Sizes {
+		if s.Width > 0 {
+			sizes = append(sizes, s)
+		}
+	}
+	sort.SliceStable(sizes, func(i, j int) bool {
+		return sizes[i].Width < sizes[j].Width
+	})
+	return sizes
+}
+
+// Return a srcset attribute value for an HTML img tag (ie. "https://...m.jpg 240w,
+// https://....jpg 500w") listing the image sizes by ascending width. Sizes without a
+// known width or with a different shape than the largest one, the square crops of non
+// square photos, are left out, as well as videos and widths already listed. An empty
+// string is returned when no size fits.
+func (r *SizesResponse) SrcSet() string {
+	sizes := r.SortedSizes()
+	var largest *Size
+	for i := range sizes {
+		if sizes[i].Media != "video" && sizes[i].hasDimensions() {
+			largest = &sizes[i]
+		}
+	}
+	if largest == nil {
+		return ""
+	}
+	ratio := float64(largest.Width) / float64(largest.Height)
+
+	var candidates []string
+	lastWidth := 0
+	for _, s := range sizes {
+		if s.Media == "video" || !s.hasDimensions() || s.Width == lastWidth {
+			continue
+		}
+		if sr := float64(s.Width) / float64(s.Height); math.Abs(sr-ratio) > srcSetRatioTolerance*ratio {
+			continue
+		}
+		candidates = append(candidates, fmt.Sprintf("%s %dw", s.Source, s.Width))
+		lastWidth = s.Width
+	}
+	return strings.Join(candidates, ", ")
+}
+
+// Relative difference of aspect ratio tolerated by SrcSet, dimensions being rounded
+const srcSetRatioTolerance = 0.02
+
+// Returns the available sizes for a photo, along with their URLs. The calling user
+// must have permission to view the photo.
+// This method does not require authentication.
+func GetSizes(client *flickr.FlickrClient, id string) (*SizesResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.photos.getSizes")
+	client.Args.Set("photo_id", id)
+	client.ApiSign()
+
+	response := &SizesResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
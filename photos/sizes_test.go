@@ -0,0 +1,107 @@
+package photos
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+var sizesBody = `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <sizes canblog="1" canprint="1" candownload="1">
+    <size label="Square" width="75" height="75" source="https://live.staticflickr.com/2/567229075_2cf8456f01_s.jpg" url="https://www.flickr.com/photos/bees/567229075/sizes/sq/" media="photo" />
+    <size label="Thumbnail" width="100" height="75" source="https://live.staticflickr.com/2/567229075_2cf8456f01_t.jpg" url="https://www.flickr.com/photos/bees/567229075/sizes/t/" media="photo" />
+    <size label="Small" width="240" height="180" source="https://live.staticflickr.com/2/567229075_2cf8456f01_m.jpg" url="https://www.flickr.com/photos/bees/567229075/sizes/s/" media="photo" />
+    <size label="Medium" width="500" height="375" source="https://live.staticflickr.com/2/567229075_2cf8456f01.jpg" url="https://www.flickr.com/photos/bees/567229075/sizes/m/" media="photo" />
+    <size label="Medium 640" width="640" height="480" source="https://live.staticflickr.com/2/567229075_2cf8456f01_z.jpg" url="https://www.flickr.com/photos/bees/567229075/sizes/z/" media="photo" />
+    <size label="Large Square" width="150" height="150" source="https://live.staticflickr.com/2/567229075_2cf8456f01_q.jpg" url="https://www.flickr.com/photos/bees/567229075/sizes/q/" media="photo" />
+    <size label="Site MP4" width="" height="" source="https://www.flickr.com/photos/bees/567229075/play/site/2cf8456f01/" url="https://www.flickr.com/photos/bees/567229075/" media="video" />
+  </sizes>
+</rsp>`
+
+func TestGetSizes(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, sizesBody, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetSizes(fclient, "567229075")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.getSizes")
+	flickr.Expect(t, fclient.Args.Get("photo_id"), "567229075")
+	flickr.Expect(t, resp.Sizes.CanDownload, true)
+	flickr.Expect(t, len(resp.Sizes.Sizes), 7)
+	flickr.Expect(t, resp.Sizes.Sizes[3].Width, 500)
+	flickr.Expect(t, resp.Sizes.Sizes[6].Width, 0)
+}
+
+func TestBestFit(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, sizesBody, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	resp, _ := GetSizes(fclient, "567229075")
+
+	for _, c := range []struct {
+		maxWidth, maxHeight int
+		label               string
+	}{
+		{1000, 1000, "Medium 640"},
+		{640, 480, "Medium 640"},
+		{639, 480, "Medium"},
+		{500, 200, "Small"},
+		{150, 150, "Large Square"},
+		{100, 100, "Thumbnail"},
+		{80, 80, "Square"},
+		// nothing fits
+		{50, 50, "Square"},
+		{0, 0, "Square"},
+	} {
+		s := resp.BestFit(c.maxWidth, c.maxHeight)
+		flickr.Expect(t, s.Label, c.label)
+	}
+
+	// ties are won by the first size listed
+	tie := &SizesResponse{}
+	tie.Sizes.Sizes = []Size{{Label: "a", Width: 100, Height: 50}, {Label: "b", Width: 50, Height: 100}}
+	flickr.Expect(t, tie.BestFit(100, 100).Label, "a")
+	flickr.Expect(t, tie.BestFit(10, 10).Label, "a")
+
+	flickr.Expect(t, (&SizesResponse{}).BestFit(100, 100) == nil, true)
+	noDims := &SizesResponse{}
+	noDims.Sizes.Sizes = []Size{{Label: "Site MP4"}}
+	flickr.Expect(t, noDims.BestFit(100, 100) == nil, true)
+}
+
+func TestSrcSet(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, sizesBody, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	resp, _ := GetSizes(fclient, "567229075")
+
+	sizes := resp.SortedSizes()
+	labels := []string{}
+	for _, s := range sizes {
+		labels = append(labels, s.Label)
+	}
+	flickr.Expect(t, strings.Join(labels, ","), "Square,Thumbnail,Large Square,Small,Medium,Medium 640")
+
+	flickr.Expect(t, resp.SrcSet(), "https://live.staticflickr.com/2/567229075_2cf8456f01_t.jpg 100w, "+
+		"https://live.staticflickr.com/2/567229075_2cf8456f01_m.jpg 240w, "+
+		"https://live.staticflickr.com/2/567229075_2cf8456f01.jpg 500w, "+
+		"https://live.staticflickr.com/2/567229075_2cf8456f01_z.jpg 640w")
+
+	// square photos keep the square sizes
+	square := &SizesResponse{}
+	square.Sizes.Sizes = []Size{
+		{Label: "Medium", Width: 500, Height: 500, Source: "m.jpg"},
+		{Label: "Square", Width: 75, Height: 75, Source: "s.jpg"},
+		{Label: "Original", Width: 500, Height: 500, Source: "o.jpg"},
+	}
+	flickr.Expect(t, square.SrcSet(), "s.jpg 75w, m.jpg 500w")
+
+	flickr.Expect(t, (&SizesResponse{}).SrcSet(), "")
+	flickr.Expect(t, len((&SizesResponse{}).SortedSizes()), 0)
+}
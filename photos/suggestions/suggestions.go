@@ -0,0 +1,83 @@
+// Package implementing methods: flickr.photos.suggestions.*
+package suggestions
+
+import (
+	"strconv"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Values for the statusId argument of GetList
+const (
+	StatusPending  = 0
+	StatusApproved = 1
+	StatusRejected = 2
+)
+
+// A location suggested by a user for a photo
+type Suggestion struct {
+	Id            string `xml:"id,attr"`
+	PhotoId       string `xml:"photo_id,attr"`
+	DateSuggested string `xml:"date_suggested,attr"`
+	SuggestedBy   struct {
+		Nsid     string `xml:"nsid,attr"`
+		Username string `xml:"username,attr"`
+	} `xml:"suggested_by"`
+	Note     string `xml:"note"`
+	Location struct {
+		Latitude  float64 `xml:"latitude,attr"`
+		Longitude float64 `xml:"longitude,attr"`
+		Accuracy  int     `xml:"accuracy,attr"`
+		WoeId     string  `xml:"woeid,attr"`
+	} `xml:"location"`
+}
+
+type SuggestionsResponse struct {
+	flickr.BasicResponse
+	Suggestions struct {
+		Total       int          `xml:"total,attr"`
+		Page        int          `xml:"page,attr"`
+		PerPage     int          `xml:"per_page,attr"`
+		Suggestions []Suggestion `xml:"suggestion"`
+	} `xml:"suggestions"`
+}
+
+// Return a list of suggestions for a photo with the given status
+// (one of StatusPending, StatusApproved or StatusRejected).
+// This method requires authentication with 'read' permission.
+func GetList(client *flickr.FlickrClient, photoId string, statusId int) (*SuggestionsResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.photos.suggestions.getList")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("status_id", strconv.Itoa(statusId))
+	client.OAuthSign()
+
+	response := &SuggestionsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Perform an authenticated write call on a suggestion
+func editSuggestion(client *flickr.FlickrClient, method, suggestionId string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", method)
+	client.Args.Set("suggestion_id", suggestionId)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Approve a suggestion for a photo.
+// This method requires authentication with 'write' permission.
+func Approve(client *flickr.FlickrClient, suggestionId string) (*flickr.BasicResponse, error) {
+	return editSuggestion(client, "flickr.photos.suggestions.approveSuggestion", suggestionId)
+}
+
+// Reject a suggestion for a photo.
+// This method requires authentication with 'write' permission.
+func Reject(client *flickr.FlickrClient, suggestionId string) (*flickr.BasicResponse, error) {
+	return editSuggestion(client, "flickr.photos.suggestions.rejectSuggestion", suggestionId)
+}
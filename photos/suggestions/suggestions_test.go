@@ -0,0 +1,67 @@
+package suggestions
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestGetList(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <suggestions total="1" page="1" per_page="10">
+    <suggestion id="1234-5678" photo_id="123456" date_suggested="1241503591">
+      <suggested_by nsid="35034348999@N01" username="bees" />
+      <note>I was there too</note>
+      <location latitude="37.794116" longitude="-122.400795" accuracy="16" woeid="2487956" />
+    </suggestion>
+  </suggestions>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetList(fclient, "123456", StatusPending)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("status_id"), "0")
+	flickr.Expect(t, resp.Suggestions.Total, 1)
+	flickr.Expect(t, len(resp.Suggestions.Suggestions), 1)
+	s := resp.Suggestions.Suggestions[0]
+	flickr.Expect(t, s.Id, "1234-5678")
+	flickr.Expect(t, s.PhotoId, "123456")
+	flickr.Expect(t, s.SuggestedBy.Nsid, "35034348999@N01")
+	flickr.Expect(t, s.SuggestedBy.Username, "bees")
+	flickr.Expect(t, s.Note, "I was there too")
+	flickr.Expect(t, s.Location.Latitude, 37.794116)
+	flickr.Expect(t, s.Location.Longitude, -122.400795)
+	flickr.Expect(t, s.Location.Accuracy, 16)
+}
+
+func TestApproveReject(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := Approve(fclient, "1234-5678")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.suggestions.approveSuggestion")
+	flickr.Expect(t, fclient.Args.Get("suggestion_id"), "1234-5678")
+
+	_, err = Reject(fclient, "1234-5678")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.suggestions.rejectSuggestion")
+}
+
+func TestApproveKo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"><err code="1" msg="Invalid suggestion ID" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := Approve(fclient, "foo")
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.ErrorCode(), 1)
+}
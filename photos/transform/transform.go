@@ -0,0 +1,41 @@
+// Package implementing methods: flickr.photos.transform.*
+package transform
+
+import (
+	"fmt"
+	"strconv"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// Response type used by Rotate function
+type RotateResponse struct {
+	flickr.BasicResponse
+	Photo struct {
+		// Photo ID
+		Id string `xml:",chardata"`
+		// Rotating a photo changes its secrets, urls must be built with the new ones
+		Secret         string `xml:"secret,attr"`
+		OriginalSecret string `xml:"originalsecret,attr"`
+	} `xml:"photoid"`
+}
+
+// Rotate a photo clockwise, valid values for degrees are 90, 180 and 270.
+// This method requires authentication with 'write' permission.
+func Rotate(client *flickr.FlickrClient, photoId string, degrees int) (*RotateResponse, error) {
+	if degrees != 90 && degrees != 180 && degrees != 270 {
+		return nil, flickErr.NewError(flickErr.ArgumentError, fmt.Sprintf("cannot rotate by %d degrees, use 90, 180 or 270", degrees))
+	}
+
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.transform.rotate")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("degrees", strconv.Itoa(degrees))
+	client.OAuthSign()
+
+	response := &RotateResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
@@ -0,0 +1,36 @@
+package transform
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestRotate(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photoid secret="abcdef" originalsecret="abcdefgh">1234</photoid>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := Rotate(fclient, "1234", 270)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("degrees"), "270")
+	flickr.Expect(t, resp.Photo.Id, "1234")
+	flickr.Expect(t, resp.Photo.Secret, "abcdef")
+	flickr.Expect(t, resp.Photo.OriginalSecret, "abcdefgh")
+}
+
+func TestRotateInvalid(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	for _, degrees := range []int{0, 45, -90, 360} {
+		resp, err := Rotate(fclient, "1234", degrees)
+		flickr.Expect(t, resp == nil, true)
+		ferr, ok := err.(*flickErr.Error)
+		flickr.Expect(t, ok, true)
+		flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	}
+}
@@ -0,0 +1,39 @@
+// Package implementing methods: flickr.photos.upload.*
+package upload
+
+import (
+	"strings"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Status of an asynchronous upload
+type Ticket struct {
+	Id string `xml:"id,attr"`
+	// 0 not completed yet, 1 completed, 2 failed
+	Complete int `xml:"complete,attr"`
+	// Set once the upload is completed
+	PhotoId string `xml:"photoid,attr"`
+	// Set when the ticket id was not found
+	Invalid bool `xml:"invalid,attr"`
+	// Upload time, unix timestamp
+	Imported string `xml:"imported,attr"`
+}
+
+type CheckTicketsResponse struct {
+	flickr.BasicResponse
+	Tickets []Ticket `xml:"uploader>ticket"`
+}
+
+// Checks the status of one or more asynchronous photo upload tickets.
+// This method does not require authentication.
+func CheckTickets(client *flickr.FlickrClient, ticketIds []string) (*CheckTicketsResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.photos.upload.checkTickets")
+	client.Args.Set("tickets", strings.Join(ticketIds, ","))
+	client.ApiSign()
+
+	response := &CheckTicketsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
@@ -0,0 +1,32 @@
+package upload
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+func TestCheckTickets(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <uploader>
+    <ticket id="128" complete="1" photoid="2995" imported="1302211123" />
+    <ticket id="129" complete="0" />
+    <ticket id="130" complete="2" />
+    <ticket id="131" invalid="1" />
+  </uploader>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := CheckTickets(fclient, []string{"128", "129", "130", "131"})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("tickets"), "128,129,130,131")
+	flickr.Expect(t, len(resp.Tickets), 4)
+	flickr.Expect(t, resp.Tickets[0].Complete, 1)
+	flickr.Expect(t, resp.Tickets[0].PhotoId, "2995")
+	flickr.Expect(t, resp.Tickets[1].Complete, 0)
+	flickr.Expect(t, resp.Tickets[2].Complete, 2)
+	flickr.Expect(t, resp.Tickets[3].Invalid, true)
+}
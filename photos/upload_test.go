@@ -0,0 +1,138 @@
+package photos
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// Return a client whose uploads get the given bodies in turn, and the paths of
+// n temporary files
+func uploadBatchSetup(t *testing.T, bodies []string, n int) (*httptest.Server, *flickr.FlickrClient, []string, string) {
+	server, fclient := flickr.FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		w.Write([]byte(bodies[0]))
+		bodies = bodies[1:]
+	}))
+	paths, dir := tempPhotos(t, n)
+	return server, fclient, paths, dir
+}
+
+// Return the paths of n temporary files and the directory holding them
+func tempPhotos(t *testing.T, n int) ([]string, string) {
+	dir, err := ioutil.TempDir("", "uploadbatch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths := []string{}
+	for i := 0; i < n; i++ {
+		path := filepath.Join(dir, string(rune('a'+i))+".jpg")
+		ioutil.WriteFile(path, []byte("photo"), 0600)
+		paths = append(paths, path)
+	}
+	return paths, dir
+}
+
+func TestUploadBatch(t *testing.T) {
+	server, fclient, paths, dir := uploadBatchSetup(t, []string{
+		`<rsp stat="ok"><photoid>1</photoid></rsp>`,
+		`<rsp stat="fail"><err code="5" msg="Filetype was not recognised" /></rsp>`,
+		`<rsp stat="ok"><photoid>3</photoid></rsp>`,
+	}, 3)
+	defer server.Close()
+	defer os.RemoveAll(dir)
+	// a missing file does not stop the batch either
+	paths = append(paths[:1], append([]string{filepath.Join(dir, "missing.jpg")}, paths[1:]...)...)
+
+	results, err := UploadBatch(context.Background(), fclient, paths, nil)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(results), 4)
+	flickr.Expect(t, results[0].Path, paths[0])
+	flickr.Expect(t, results[0].PhotoId, "1")
+	flickr.Expect(t, results[0].Err, nil)
+	flickr.Expect(t, results[1].Err != nil, true)
+	flickr.Expect(t, results[2].PhotoId, "")
+	ferr, ok := results[2].Err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ferr.ErrorCode, flickErr.ApiError)
+	flickr.Expect(t, results[3].PhotoId, "3")
+}
+
+func TestUploadBatchFatal(t *testing.T) {
+	server, fclient, paths, dir := uploadBatchSetup(t, []string{
+		`<rsp stat="ok"><photoid>1</photoid></rsp>`,
+		`<rsp stat="fail"><err code="98" msg="Invalid auth token" /></rsp>`,
+	}, 3)
+	defer server.Close()
+	defer os.RemoveAll(dir)
+
+	results, err := UploadBatch(context.Background(), fclient, paths, nil)
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, len(results), 2)
+	flickr.Expect(t, results[0].PhotoId, "1")
+	flickr.Expect(t, results[1].Err, err)
+}
+
+func TestUploadBatchContext(t *testing.T) {
+	server, fclient, paths, dir := uploadBatchSetup(t, nil, 2)
+	defer server.Close()
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := UploadBatch(ctx, fclient, paths, nil)
+	flickr.Expect(t, err, context.Canceled)
+	flickr.Expect(t, len(results), 0)
+}
+
+func TestUploadBatchRateLimit(t *testing.T) {
+	server, fclient := flickr.FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		w.Header().Set("X-RateLimit-Limit", "3600")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		w.Write([]byte(`<rsp stat="ok"><photoid>1</photoid></rsp>`))
+	}))
+	defer server.Close()
+	paths, dir := tempPhotos(t, 3)
+	defer os.RemoveAll(dir)
+
+	results, err := UploadBatch(context.Background(), fclient, paths, nil)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(results), 3)
+	flickr.Expect(t, results[0].PhotoId, "1")
+	flickr.Expect(t, results[0].Err, nil)
+	for _, result := range results[1:] {
+		flickr.Expect(t, result.PhotoId, "")
+		flickr.Expect(t, errors.Is(result.Err, flickr.ErrRateLimited), true)
+	}
+	flickr.Expect(t, results[2].Path, paths[2])
+}
+
+func TestUploadContext(t *testing.T) {
+	server, fclient, paths, dir := uploadBatchSetup(t, []string{
+		`<rsp stat="ok"><photoid>1</photoid></rsp>`,
+	}, 1)
+	defer server.Close()
+	defer os.RemoveAll(dir)
+
+	resp, err := UploadContext(context.Background(), fclient, paths[0], nil)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.ID, "1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = UploadContext(ctx, fclient, paths[0], nil)
+	flickr.Expect(t, errors.Is(err, context.Canceled), true)
+}
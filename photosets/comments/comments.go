@@ -0,0 +1,73 @@
+// Package implementing methods: flickr.photosets.comments.*
+package comments
+
+import (
+	"gopkg.in/masci/flickr.v2"
+	photoComments "gopkg.in/masci/flickr.v2/photos/comments"
+)
+
+type CommentsResponse struct {
+	flickr.BasicResponse
+	Comments struct {
+		PhotosetId string                  `xml:"photoset_id,attr"`
+		Comments   []photoComments.Comment `xml:"comment"`
+	} `xml:"comments"`
+}
+
+// Returns the comments for a photoset.
+// This method does not require authentication.
+func GetList(client *flickr.FlickrClient, photosetId string) (*CommentsResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.photosets.comments.getList")
+	client.Args.Set("photoset_id", photosetId)
+	client.ApiSign()
+
+	response := &CommentsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Add a comment to a photoset, the id of the new comment is returned in the response,
+// along with its permalink when Flickr sends it. Comments are flat, see photos/comments.AddComment.
+// This method requires authentication with 'write' permission.
+func AddComment(client *flickr.FlickrClient, photosetId, text string) (*photoComments.AddCommentResponse, error) {
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photosets.comments.addComment")
+	client.Args.Set("photoset_id", photosetId)
+	client.Args.Set("comment_text", text)
+	client.OAuthSign()
+
+	response := &photoComments.AddCommentResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Delete a photoset comment as the currently authenticated user.
+// This method requires authentication with 'write' permission.
+func DeleteComment(client *flickr.FlickrClient, commentId string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photosets.comments.deleteComment")
+	client.Args.Set("comment_id", commentId)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Edit the text of a comment as the currently authenticated user.
+// This method requires authentication with 'write' permission.
+func EditComment(client *flickr.FlickrClient, commentId, text string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photosets.comments.editComment")
+	client.Args.Set("comment_id", commentId)
+	client.Args.Set("comment_text", text)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
@@ -0,0 +1,76 @@
+package comments
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestGetList(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <comments photoset_id="72157594162285471">
+    <comment id="1030592-72157594162285471-72157594164867851" author="35468159852@N01"
+      authorname="Rev Dan Catt" datecreate="1141841470"
+      permalink="http://www.flickr.com/photos/straup/sets/72157594162285471/comments#comment72157594164867851">Umm, I'm not sure, can I get back to you on that one?</comment>
+  </comments>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetList(fclient, "72157594162285471")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photosets.comments.getList")
+	flickr.Expect(t, resp.Comments.PhotosetId, "72157594162285471")
+	flickr.Expect(t, len(resp.Comments.Comments), 1)
+	c := resp.Comments.Comments[0]
+	flickr.Expect(t, c.AuthorName, "Rev Dan Catt")
+	flickr.Expect(t, c.Text, "Umm, I'm not sure, can I get back to you on that one?")
+	created, err := c.CreatedTime()
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, created.Unix(), int64(1141841470))
+}
+
+func TestAddComment(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok"><comment id="97777-12492-72057594037942601" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := AddComment(fclient, "12492", "nice set")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.HTTPVerb, "POST")
+	flickr.Expect(t, fclient.Args.Get("photoset_id"), "12492")
+	flickr.Expect(t, fclient.Args.Get("comment_text"), "nice set")
+	flickr.Expect(t, resp.Comment.Id, "97777-12492-72057594037942601")
+}
+
+func TestDeleteComment(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := DeleteComment(fclient, "97777")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.HTTPVerb, "POST")
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photosets.comments.deleteComment")
+	flickr.Expect(t, fclient.Args.Get("comment_id"), "97777")
+}
+
+func TestEditComment(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="fail"><err code="2" msg="Comment not found" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := EditComment(fclient, "97777", "nicer set")
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.ErrorCode(), 2)
+	flickr.Expect(t, fclient.Args.Get("comment_text"), "nicer set")
+}
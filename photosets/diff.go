@@ -0,0 +1,75 @@
+package photosets
+
+import (
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Number of photos asked per getPhotos page by Diff, the maximum Flickr allows
+const diffPerPage = 500
+
+// Return the photos to add to and remove from a set holding current so that it holds
+// desired. toAdd follows the order of desired and toRemove the one of current;
+// duplicated ids are considered once.
+func diffMembers(current, desired []string) (toAdd, toRemove []string) {
+	in := map[string]bool{}
+	for _, id := range current {
+		in[id] = true
+	}
+	wanted := map[string]bool{}
+	for _, id := range desired {
+		if !in[id] && !wanted[id] {
+			toAdd = append(toAdd, id)
+		}
+		wanted[id] = true
+	}
+	removed := map[string]bool{}
+	for _, id := range current {
+		if !wanted[id] && !removed[id] {
+			toRemove = append(toRemove, id)
+			removed[id] = true
+		}
+	}
+	return toAdd, toRemove
+}
+
+// Fetch the photos of a set, walking all the pages of getPhotos, and return the ones
+// to add and to remove so that the set holds exactly desiredPhotoIds, see ApplyDiff.
+// toAdd follows the order of desiredPhotoIds, toRemove the order of the set.
+// This method requires authentication with 'read' permission.
+func Diff(client *flickr.FlickrClient, photosetId string, desiredPhotoIds []string) (toAdd, toRemove []string, err error) {
+	var current []string
+	_, _, err = flickr.Paginate(0, func(page int) (flickr.Pager, error) {
+		response, err := GetPhotosWithOptions(client, true, photosetId, "", GetPhotosOptionalArgs{Page: page, PerPage: diffPerPage})
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range response.Photoset.Photos {
+			current = append(current, p.Id)
+		}
+		return response, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	toAdd, toRemove = diffMembers(current, desiredPhotoIds)
+	return toAdd, toRemove, nil
+}
+
+// Add toAdd photos to a set, one call each in order, then remove toRemove ones with a
+// single call, as computed by Diff. Photos are added first so that the set never
+// gets empty: Flickr doesn't keep sets without photos. The first error stops the process.
+// This method requires authentication with 'write' permission.
+func ApplyDiff(client *flickr.FlickrClient, photosetId string, toAdd, toRemove []string) error {
+	for _, id := range toAdd {
+		if _, err := AddPhoto(client, photosetId, id); err != nil {
+			return err
+		}
+	}
+	if len(toRemove) > 0 {
+		if _, err := RemovePhotos(client, photosetId, toRemove); err != nil {
+			return err
+		}
+	}
+	return nil
+}
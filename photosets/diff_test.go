@@ -0,0 +1,84 @@
+package photosets
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+func TestDiffMembers(t *testing.T) {
+	for _, c := range []struct {
+		current, desired, toAdd, toRemove string
+	}{
+		{"1,2,3", "1,2,3", "", ""},
+		{"", "3,1,2", "3,1,2", ""},
+		{"1,2,3", "", "", "1,2,3"},
+		{"1,2,3", "5,3,4,1", "5,4", "2"},
+		{"1,2", "4,4,1,4", "4", "2"},
+	} {
+		split := func(s string) []string {
+			if s == "" {
+				return nil
+			}
+			return strings.Split(s, ",")
+		}
+		toAdd, toRemove := diffMembers(split(c.current), split(c.desired))
+		flickr.Expect(t, strings.Join(toAdd, ","), c.toAdd)
+		flickr.Expect(t, strings.Join(toRemove, ","), c.toRemove)
+	}
+}
+
+// Fake photosets API serving the photos of a set two per page and recording changes
+type fakeSet struct {
+	photos []string
+	calls  []string
+}
+
+func (f *fakeSet) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.ParseMultipartForm(1 << 20)
+	w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok">`))
+	switch method := r.FormValue("method"); method {
+	case "flickr.photosets.getPhotos":
+		page, _ := strconv.Atoi(r.FormValue("page"))
+		if page == 0 {
+			page = 1
+		}
+		pages := (len(f.photos) + 1) / 2
+		fmt.Fprintf(w, `<photoset id="%s" page="%d" pages="%d" total="%d">`, r.FormValue("photoset_id"), page, pages, len(f.photos))
+		for i := 2 * (page - 1); i < len(f.photos) && i < 2*page; i++ {
+			fmt.Fprintf(w, `<photo id="%s" />`, f.photos[i])
+		}
+		fmt.Fprint(w, `</photoset>`)
+	case "flickr.photosets.addPhoto":
+		f.calls = append(f.calls, "add "+r.FormValue("photo_id"))
+	case "flickr.photosets.removePhotos":
+		f.calls = append(f.calls, "remove "+r.FormValue("photo_ids"))
+	}
+	w.Write([]byte(`</rsp>`))
+}
+
+func TestDiff(t *testing.T) {
+	f := &fakeSet{photos: []string{"1", "2", "3", "4", "5"}}
+	server, fclient := flickr.FlickrMockHandler(f)
+	defer server.Close()
+
+	toAdd, toRemove, err := Diff(fclient, "72157", []string{"7", "5", "1", "6"})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("page"), "3")
+	flickr.Expect(t, fclient.Args.Get("per_page"), "500")
+	flickr.Expect(t, strings.Join(toAdd, ","), "7,6")
+	flickr.Expect(t, strings.Join(toRemove, ","), "2,3,4")
+
+	err = ApplyDiff(fclient, "72157", toAdd, toRemove)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, strings.Join(f.calls, ";"), "add 7;add 6;remove 2,3,4")
+
+	f.calls = nil
+	err = ApplyDiff(fclient, "72157", nil, nil)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(f.calls), 0)
+}
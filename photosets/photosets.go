@@ -2,10 +2,15 @@
 package photosets
 
 import (
+	"context"
+	"errors"
 	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+	"gopkg.in/masci/flickr.v2/photos"
 )
 
 type Photoset struct {
@@ -29,9 +34,22 @@ type Photoset struct {
 	Owner             string `xml:"owner,attr"`
 }
 
-type Photo struct {
-	Id    string `xml:"id,attr"`
-	Title string `xml:"title,attr"`
+// Photo of a set, kept for compatibility: set photos are a flickr.Photo
+type Photo = flickr.Photo
+
+// Implements flickr.PhotoReferrer, referring to the primary photo of the set
+func (s *Photoset) PhotoRef() flickr.PhotoRef {
+	return flickr.PhotoRef{
+		Id:     s.Primary,
+		Secret: s.Secret,
+		Server: s.Server,
+	}
+}
+
+// Return the URL of the set cover, its primary photo, with the given size suffix
+// (see flickr.PhotoURL). No further call is needed: getList returns all it takes.
+func (s *Photoset) CoverURL(size string) string {
+	return flickr.PhotoURL(s, size)
 }
 
 type PhotosetsListResponse struct {
@@ -45,6 +63,16 @@ type PhotosetsListResponse struct {
 	} `xml:"photosets"`
 }
 
+// Number of the page held by the response, implements flickr.Pager
+func (r *PhotosetsListResponse) Page() int {
+	return r.Photosets.Page
+}
+
+// Total number of pages, implements flickr.Pager
+func (r *PhotosetsListResponse) Pages() int {
+	return r.Photosets.Pages
+}
+
 type PhotosetResponse struct {
 	flickr.BasicResponse
 	Set Photoset `xml:"photoset"`
@@ -53,14 +81,37 @@ type PhotosetResponse struct {
 type PhotosListResponse struct {
 	flickr.BasicResponse
 	Photoset struct {
-		Page    int     `xml:"page,attr"`
-		Pages   int     `xml:"pages,attr"`
-		Perpage int     `xml:"perpage,attr"`
-		Total   int     `xml:"total,attr"`
-		Photos  []Photo `xml:"photo"`
+		Id      string         `xml:"id,attr"`
+		Primary string         `xml:"primary,attr"`
+		Page    int            `xml:"page,attr"`
+		Pages   int            `xml:"pages,attr"`
+		Perpage int            `xml:"perpage,attr"`
+		Total   int            `xml:"total,attr"`
+		Photos  []flickr.Photo `xml:"photo"`
 	} `xml:"photoset"`
 }
 
+// Number of the page held by the response, implements flickr.Pager
+func (r *PhotosListResponse) Page() int {
+	return r.Photoset.Page
+}
+
+// Total number of pages, implements flickr.Pager
+func (r *PhotosListResponse) Pages() int {
+	return r.Photoset.Pages
+}
+
+// Return the primary photo of the set if it is in the page held by the response, nil otherwise
+func (r *PhotosListResponse) PrimaryPhoto() *flickr.Photo {
+	for i := range r.Photoset.Photos {
+		p := &r.Photoset.Photos[i]
+		if p.IsPrimary || p.Id == r.Photoset.Primary {
+			return p
+		}
+	}
+	return nil
+}
+
 // Return the public sets belonging to the user with userId.
 // If userId is not provided it defaults to the caller user but call needs to be authenticated.
 // This method requires authentication to retrieve private sets.
@@ -150,9 +201,44 @@ func RemovePhoto(client *flickr.FlickrClient, photosetId, photoId string) (*flic
 	return response, err
 }
 
+// Media filters accepted by GetPhotosWithOptions
+const (
+	MediaAll    = "all"
+	MediaPhotos = "photos"
+	MediaVideos = "videos"
+)
+
+type GetPhotosOptionalArgs struct {
+	Page    int    // optional, flickr defaults it to 1
+	PerPage int    // optional, 0 for flickr.DEFAULT_PER_PAGE
+	Extras  string // optional, set to "" to ignore. comma separated string.
+	Media   string // optional, one of MediaAll, MediaPhotos, MediaVideos or "" to ignore
+	// optional, 0 to ignore. 1 public, 2 friends, 3 family, 4 friends and family, 5 private
+	PrivacyFilter int
+	// Move the primary photo first in the page holding it, Flickr doesn't guarantee
+	// any position for it
+	PrimaryFirst bool
+}
+
 // Get the photos in a set
 // This method requires authentication to retrieve photos from private sets
 func GetPhotos(client *flickr.FlickrClient, authenticate bool, photosetId, ownerID string, page int) (*PhotosListResponse, error) {
+	return GetPhotosWithOptions(client, authenticate, photosetId, ownerID, GetPhotosOptionalArgs{Page: page})
+}
+
+// Same as GetPhotos, with optional filters. Unknown media or privacy filters are reported
+// with an ArgumentError before sending the request.
+// This method requires authentication to retrieve photos from private sets
+func GetPhotosWithOptions(client *flickr.FlickrClient, authenticate bool, photosetId, ownerID string, opts GetPhotosOptionalArgs) (*PhotosListResponse, error) {
+	switch opts.Media {
+	case "", MediaAll, MediaPhotos, MediaVideos:
+	default:
+		return nil, flickErr.NewError(flickErr.ArgumentError, "unknown media filter "+opts.Media)
+	}
+	if opts.PrivacyFilter < 0 || opts.PrivacyFilter > 5 {
+		return nil, flickErr.NewError(flickErr.ArgumentError, "unknown privacy filter "+strconv.Itoa(opts.PrivacyFilter))
+	}
+
 	client.Init()
 	client.Args.Set("method", "flickr.photosets.getPhotos")
 	client.Args.Set("photoset_id", photosetId)
@@ -160,9 +246,20 @@ func GetPhotos(client *flickr.FlickrClient, authenticate bool, photosetId, owner
 	if ownerID != "" {
 		client.Args.Set("user_id", ownerID)
 	}
-	// if not provided, flickr defaults this argument to 1
-	if page > 1 {
-		client.Args.Set("page", strconv.Itoa(page))
+	// if not provided, flickr defaults page to 1
+	page := 0
+	if opts.Page > 1 {
+		page = opts.Page
+	}
+	flickr.SetPageArgs(client, page, opts.PerPage)
+	if opts.Extras != "" {
+		client.Args.Set("extras", opts.Extras)
+	}
+	if opts.Media != "" {
+		client.Args.Set("media", opts.Media)
+	}
+	if opts.PrivacyFilter != 0 {
+		client.Args.Set("privacy_filter", strconv.Itoa(opts.PrivacyFilter))
 	}
 	// sign the client for authentication and authorization
 	if authenticate {
@@ -173,9 +270,84 @@ func GetPhotos(client *flickr.FlickrClient, authenticate bool, photosetId, owner
 
 	response := &PhotosListResponse{}
 	err := flickr.DoGet(client, response)
+	if err == nil && opts.PrimaryFirst {
+		response.primaryFirst()
+	}
 	return response, err
 }
 
+// Stream the photos of a set, fetching the pages of getPhotos in sequence with the given
+// extras. Photos are sent on the first channel; then the error that ended the stream,
+// if any, is sent on the second one, and both channels are closed. Fetching stops when
+// ctx is done, reporting ctx.Err(): cancel ctx when you stop reading. When the rate
+// limit quota is exhausted, the next page waits for it to reset. The client must not be used for other calls until the
+// channels are closed.
+// The call is authenticated, and private sets readable, when the client holds an access token.
+func GetPhotosStream(ctx context.Context, client *flickr.FlickrClient, photosetId, extras string) (<-chan *flickr.Photo, <-chan error) {
+	photos := make(chan *flickr.Photo)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(photos)
+
+		authenticate := client.OAuthToken != ""
+		for page := 1; ; page++ {
+			if err := waitRateLimit(ctx, client); err != nil {
+				errs <- err
+				return
+			}
+			response, err := GetPhotosWithOptions(client, authenticate, photosetId, "", GetPhotosOptionalArgs{Page: page, Extras: extras})
+			if err != nil {
+				errs <- err
+				return
+			}
+			for i := range response.Photoset.Photos {
+				select {
+				case photos <- &response.Photoset.Photos[i]:
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				}
+			}
+			if page >= response.Pages() {
+				return
+			}
+		}
+	}()
+	return photos, errs
+}
+
+// Wait for the quota to reset if the rate limit reported by Flickr is exhausted, or
+// until ctx is done
+func waitRateLimit(ctx context.Context, client *flickr.FlickrClient) error {
+	var limited *flickr.RateLimitError
+	if !errors.As(client.CheckRateLimit(1), &limited) {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(time.Until(limited.Reset))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Move the primary photo, if any, at the beginning of the list keeping the order of the others
+func (r *PhotosListResponse) primaryFirst() {
+	photos := r.Photoset.Photos
+	for i := range photos {
+		if photos[i].IsPrimary || photos[i].Id == r.Photoset.Primary {
+			primary := photos[i]
+			copy(photos[1:i+1], photos[:i])
+			photos[0] = primary
+			return
+		}
+	}
+}
+
 // Edit set name and description
 // This method requires authentication with 'write' permission.
 func EditMeta(client *flickr.FlickrClient, photosetId, title, description string) (*flickr.BasicResponse, error) {
@@ -253,9 +425,14 @@ func OrderSets(client *flickr.FlickrClient, photosetIds []string) (*flickr.Basic
 	return response, err
 }
 
-// Remove multiple photos from a photoset.
+// Remove multiple photos from a photoset with a single call, photoIds must not be empty.
+// Flickr rejects the whole call with error code 2 when any of the photos is not found.
 // This method requires authentication with 'write' permission.
 func RemovePhotos(client *flickr.FlickrClient, photosetId string, photoIds []string) (*flickr.BasicResponse, error) {
+	if len(photoIds) == 0 {
+		return nil, flickErr.NewError(flickErr.ArgumentError, "no photos to remove")
+	}
+
 	client.Init()
 	client.HTTPVerb = "POST"
 	client.Args.Set("method", "flickr.photosets.removePhotos")
@@ -270,9 +447,26 @@ func RemovePhotos(client *flickr.FlickrClient, photosetId string, photoIds []str
 	return response, err
 }
 
-// Alias for EditPhotos
-func ReorderPhotos(client *flickr.FlickrClient, photosetId, primaryId string, photoIds []string) (*flickr.BasicResponse, error) {
-	return EditPhotos(client, photosetId, primaryId, photoIds)
+// Set the order of photos within a photoset, photoIds listing them in the new order.
+// Unlike EditPhotos it doesn't change which photos belong to the set: Flickr refuses
+// ids of photos not in the set, with error code 2. photoIds must not be empty.
+// This method requires authentication with 'write' permission.
+func ReorderPhotos(client *flickr.FlickrClient, photosetId string, photoIds []string) (*flickr.BasicResponse, error) {
+	if len(photoIds) == 0 {
+		return nil, flickErr.NewError(flickErr.ArgumentError, "no photos to reorder")
+	}
+
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photosets.reorderPhotos")
+	client.Args.Set("photoset_id", photosetId)
+	client.Args.Set("photo_ids", strings.Join(photoIds, ","))
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
 }
 
 // Set photoset primary photo
@@ -290,3 +484,187 @@ func SetPrimaryPhoto(client *flickr.FlickrClient, photosetId, primaryId string)
 	err := flickr.DoPost(client, response)
 	return response, err
 }
+
+type PhotosetContextResponse struct {
+	flickr.BasicResponse
+	Count int `xml:"count"`
+	// nil when the photo is the first of the set
+	PrevPhoto *photos.ContextPhoto `xml:"prevphoto"`
+	// nil when the photo is the last of the set
+	NextPhoto *photos.ContextPhoto `xml:"nextphoto"`
+}
+
+// Returns next and previous photos for a photo in a set.
+// This method does not require authentication.
+func GetContext(client *flickr.FlickrClient, photoId, photosetId string) (*PhotosetContextResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.photosets.getContext")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("photoset_id", photosetId)
+	client.ApiSign()
+
+	response := &PhotosetContextResponse{}
+	err := flickr.DoGet(client, response)
+	if response.PrevPhoto != nil && response.PrevPhoto.IsBoundary() {
+		response.PrevPhoto = nil
+	}
+	if response.NextPhoto != nil && response.NextPhoto.IsBoundary() {
+		response.NextPhoto = nil
+	}
+	return response, err
+}
+
+// Return the sets of the calling user titled exactly title, walking all the pages of getList
+func findByTitle(client *flickr.FlickrClient, title string) ([]Photoset, error) {
+	found := []Photoset{}
+	for page := 1; ; page++ {
+		response, err := GetList(client, true, "", page)
+		if err != nil {
+			return nil, err
+		}
+		for _, set := range response.Photosets.Items {
+			if set.Title == title {
+				found = append(found, set)
+			}
+		}
+		if page >= response.Photosets.Pages {
+			return found, nil
+		}
+	}
+}
+
+// Return the number of photos and videos in the sets of the user with userId, the
+// calling user if empty, summing the counts of all the pages of getList. Items belonging
+// to several sets are counted once per set. The call is authenticated, and private sets
+// counted, when the client holds an access token.
+// If the quota runs out before the last page, a *flickr.RateLimitError is returned
+// instead of a partial count.
+func CountAllPhotos(client *flickr.FlickrClient, userId string) (int, error) {
+	authenticate := client.OAuthToken != ""
+	total := 0
+	_, _, err := flickr.Paginate(0, func(page int) (flickr.Pager, error) {
+		if err := client.CheckRateLimit(1); err != nil {
+			return nil, err
+		}
+		response, err := GetList(client, authenticate, userId, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, set := range response.Photosets.Items {
+			total += set.Photos + set.Videos
+		}
+		return response, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// Return the oldest set, ties are broken by id
+func oldest(sets []Photoset) Photoset {
+	ret := sets[0]
+	for _, set := range sets[1:] {
+		if set.DateCreate < ret.DateCreate ||
+			(set.DateCreate == ret.DateCreate && (len(set.Id) < len(ret.Id) || (len(set.Id) == len(ret.Id) && set.Id < ret.Id))) {
+			ret = set
+		}
+	}
+	return ret
+}
+
+// Return the set of the calling user titled title, creating it if it doesn't exist yet.
+// Title matching is exact and case-sensitive. When more sets share the title, the oldest
+// is returned. If another set with the same title shows up while this one was being
+// created (e.g. by a concurrent import), the set just created is deleted and the oldest
+// is returned instead, so that concurrent callers converge on the same set.
+// This method requires authentication with 'write' permission.
+func EnsureByTitle(client *flickr.FlickrClient, title, description, primaryPhotoId string) (*Photoset, error) {
+	found, err := findByTitle(client, title)
+	if err != nil {
+		return nil, err
+	}
+	if len(found) > 0 {
+		set := oldest(found)
+		return &set, nil
+	}
+
+	created, err := Create(client, title, description, primaryPhotoId)
+	if err != nil {
+		return nil, err
+	}
+	set := created.Set
+	set.Title = title
+	set.Description = description
+	set.Primary = primaryPhotoId
+
+	found, err = findByTitle(client, title)
+	if err != nil || len(found) == 0 {
+		// the set was created anyway
+		return &set, nil
+	}
+	winner := oldest(found)
+	if winner.Id == set.Id {
+		return &set, nil
+	}
+	if _, err := Delete(client, set.Id); err != nil {
+		return nil, err
+	}
+	return &winner, nil
+}
+
+// Error code of photos.delete and photosets.delete when the photo or the set doesn't
+// exist, e.g. because it was already deleted
+const notFoundCode = 1
+
+// Delete a set and, only when alsoDeletePhotos is set, every photo it holds before,
+// including photos belonging to other sets too. The number of photos deleted is
+// returned. Photos already deleted are skipped and not counted. Any other failure
+// stops the deletion and is returned along with the count of photos deleted so far,
+// leaving the set in place. Flickr removes a set once its last photo is gone, which
+// is not an error either.
+// Once the rate limit quota is exhausted the deletion stops with a
+// *flickr.RateLimitError, running it again after the reset resumes it.
+// This method requires authentication with 'delete' permission.
+func DeleteWithPhotos(client *flickr.FlickrClient, photosetId string, alsoDeletePhotos bool) (deleted int, err error) {
+	if alsoDeletePhotos {
+		// listed beforehand, pages shift as photos are deleted
+		var ids []string
+		_, _, err := flickr.Paginate(0, func(page int) (flickr.Pager, error) {
+			if err := client.CheckRateLimit(1); err != nil {
+				return nil, err
+			}
+			response, err := GetPhotosWithOptions(client, true, photosetId, "", GetPhotosOptionalArgs{Page: page, PerPage: 500})
+			if err != nil {
+				return nil, err
+			}
+			for _, photo := range response.Photoset.Photos {
+				ids = append(ids, photo.Id)
+			}
+			return response, nil
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		for _, id := range ids {
+			if err := client.CheckRateLimit(1); err != nil {
+				return deleted, err
+			}
+			response, err := photos.Delete(client, id)
+			if err != nil {
+				if response.ErrorCode() == notFoundCode {
+					continue
+				}
+				return deleted, err
+			}
+			deleted++
+		}
+	}
+
+	response, err := Delete(client, photosetId)
+	if err != nil && !(alsoDeletePhotos && response.ErrorCode() == notFoundCode) {
+		return deleted, err
+	}
+	return deleted, nil
+}
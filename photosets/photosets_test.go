@@ -1,7 +1,15 @@
 package photosets
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"gopkg.in/masci/flickr.v2"
 	flickErr "gopkg.in/masci/flickr.v2/error"
@@ -59,6 +67,7 @@ func TestGetList(t *testing.T) {
 	flickr.Expect(t, set1.DateUpdate, 1376079704)
 	flickr.Expect(t, set1.Title, "A photoset")
 	flickr.Expect(t, set1.Description, "")
+	flickr.Expect(t, set1.CoverURL("q"), "https://live.staticflickr.com/1234/123456_abcdef_q.jpg")
 
 	set2 := resp.Photosets.Items[1]
 	flickr.Expect(t, set2.Description, "Another cool photosets with some pics inside")
@@ -221,6 +230,119 @@ func TestGetPhotos(t *testing.T) {
 	flickr.AssertParamsInBody(t, fclient, params)
 }
 
+func TestGetPhotosWithOptions(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+	<rsp stat="ok">
+	  <photoset id="72157654991267328" primary="16492421763" owner="126545133@N08" page="1" perpage="500" pages="1" total="3">
+		<photo id="18497456039" secret="e590ac1028" server="410" farm="1" title="a" isprimary="0" media="photo" />
+		<photo id="17217350039" secret="4fbc01db5b" server="8751" farm="9" title="b" isprimary="0" media="video" />
+		<photo id="16492421763" secret="5a08237214" server="8794" farm="9" title="c" isprimary="1" media="photo" />
+	  </photoset>
+	</rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPhotosWithOptions(fclient, true, "72157654991267328", "", GetPhotosOptionalArgs{
+		Media:         MediaPhotos,
+		PrivacyFilter: 5,
+		Extras:        "media",
+		PrimaryFirst:  true,
+	})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("media"), "photos")
+	flickr.Expect(t, fclient.Args.Get("privacy_filter"), "5")
+	flickr.Expect(t, fclient.Args.Get("extras"), "media")
+	flickr.Expect(t, resp.Photoset.Primary, "16492421763")
+	photos := resp.Photoset.Photos
+	flickr.Expect(t, len(photos), 3)
+	flickr.Expect(t, photos[0].Id, "16492421763")
+	flickr.Expect(t, photos[0].IsPrimary, true)
+	flickr.Expect(t, photos[1].Id, "18497456039")
+	flickr.Expect(t, photos[2].Id, "17217350039")
+	flickr.Expect(t, photos[2].Media, "video")
+	var video *Photo = &photos[2]
+	flickr.Expect(t, video.Title, "b")
+	flickr.Expect(t, resp.PrimaryPhoto().Id, "16492421763")
+
+	resp, err = GetPhotosWithOptions(fclient, false, "72157654991267328", "", GetPhotosOptionalArgs{})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Photoset.Photos[2].Id, "16492421763")
+	for _, arg := range []string{"media", "privacy_filter", "extras", "page"} {
+		_, found := fclient.Args[arg]
+		flickr.Expect(t, found, false)
+	}
+	flickr.Expect(t, fclient.Args.Get("per_page"), "100")
+
+	for _, opts := range []GetPhotosOptionalArgs{{Media: "pictures"}, {PrivacyFilter: 6}} {
+		resp, err = GetPhotosWithOptions(fclient, false, "72157654991267328", "", opts)
+		flickr.Expect(t, resp == nil, true)
+		ferr, ok := err.(*flickErr.Error)
+		flickr.Expect(t, ok, true)
+		flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	}
+}
+
+// Serve a set of two pages holding two photos each
+func twoPagesSet() (*httptest.Server, *flickr.FlickrClient) {
+	server, fclient := flickr.FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.FormValue("page"))
+		if page == 0 {
+			page = 1
+		}
+		fmt.Fprintf(w, `<rsp stat="ok"><photoset id="72157654991267328" page="%d" pages="2" total="4">
+			<photo id="%d1" secret="e590ac1028" server="410" farm="1" title="a" />
+			<photo id="%d2" secret="4fbc01db5b" server="8751" farm="9" title="b" />
+		</photoset></rsp>`, page, page, page)
+	}))
+	return server, fclient
+}
+
+func TestGetPhotosStream(t *testing.T) {
+	server, fclient := twoPagesSet()
+	defer server.Close()
+
+	photos, errs := GetPhotosStream(context.Background(), fclient, "72157654991267328", "media")
+	var ids []string
+	for p := range photos {
+		ids = append(ids, p.Id)
+	}
+	flickr.Expect(t, <-errs, nil)
+	flickr.Expect(t, strings.Join(ids, ","), "11,12,21,22")
+	flickr.Expect(t, fclient.Args.Get("extras"), "media")
+	flickr.Expect(t, fclient.Args.Get("page"), "2")
+
+	// the consumer stops reading after the first photo
+	ctx, cancel := context.WithCancel(context.Background())
+	photos, errs = GetPhotosStream(ctx, fclient, "72157654991267328", "")
+	flickr.Expect(t, (<-photos).Id, "11")
+	cancel()
+	flickr.Expect(t, <-errs, context.Canceled)
+	_, open := <-photos
+	flickr.Expect(t, open, false)
+	flickr.Expect(t, fclient.Args.Get("page") == "2", false)
+}
+
+func TestGetPhotosStreamRateLimit(t *testing.T) {
+	pages := 0
+	server, fclient := flickr.FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		fmt.Fprint(w, `<rsp stat="ok"><photoset id="1" page="1" pages="2"><photo id="11" /></photoset></rsp>`)
+	}))
+	defer server.Close()
+
+	// waiting for the quota to reset instead of fetching the second page
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	photos, errs := GetPhotosStream(ctx, fclient, "1", "")
+	flickr.Expect(t, (<-photos).Id, "11")
+	flickr.Expect(t, <-errs, context.DeadlineExceeded)
+	flickr.Expect(t, pages, 1)
+}
+
 func TestEditMeta(t *testing.T) {
 	fclient := flickr.GetTestClient()
 	server, client := flickr.FlickrMock(200, `<rsp stat="ok"></rsp>`, "text/xml")
@@ -294,6 +416,40 @@ func TestRemovePhotos(t *testing.T) {
 	RemovePhotos(fclient, "72157654991267328", []string{"123456", "23456"})
 	params := []string{"photoset_id", "photo_ids"}
 	flickr.AssertParamsInBody(t, fclient, params)
+
+	resp, err = RemovePhotos(fclient, "72157654991267328", []string{})
+	flickr.Expect(t, resp == nil, true)
+	ferr, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+}
+
+func TestReorderPhotos(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := ReorderPhotos(fclient, "72157654991267328", []string{"23456", "123456", "345"})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.HTTPVerb, "POST")
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photosets.reorderPhotos")
+	flickr.Expect(t, fclient.Args.Get("photoset_id"), "72157654991267328")
+	flickr.Expect(t, fclient.Args.Get("photo_ids"), "23456,123456,345")
+
+	server, client = flickr.FlickrMock(200, `<rsp stat="fail"><err code="2" msg="Photo not in set"/></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+	resp, err := ReorderPhotos(fclient, "72157654991267328", []string{"999"})
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.ErrorCode(), 2)
+
+	resp, err = ReorderPhotos(fclient, "72157654991267328", nil)
+	flickr.Expect(t, resp == nil, true)
+	ferr, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
 }
 
 func TestSetPrimaryPhoto(t *testing.T) {
@@ -383,3 +539,198 @@ func TestOrderSet(t *testing.T) {
 	flickr.AssertParamsInBody(t, fclient, params)
 
 }
+
+func TestGetContext(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <count>2</count>
+  <prevphoto id="2980" secret="973da1e709" server="1" farm="1" title="boo!" url="/photos/bees/2980/in/set-72157594162285471/" />
+  <nextphoto id="0" />
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetContext(fclient, "2983", "72157594162285471")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photosets.getContext")
+	flickr.Expect(t, fclient.Args.Get("photo_id"), "2983")
+	flickr.Expect(t, fclient.Args.Get("photoset_id"), "72157594162285471")
+	flickr.Expect(t, resp.Count, 2)
+	flickr.Expect(t, resp.PrevPhoto.Id, "2980")
+	flickr.Expect(t, resp.NextPhoto == nil, true)
+}
+
+// Fake photosets API holding sets in memory, concurrent sets are created behind
+// the caller's back right after its create call
+type fakeSets struct {
+	sets       []string
+	concurrent []string
+	deleted    []string
+}
+
+func (f *fakeSets) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok">`))
+	switch r.FormValue("method") {
+	case "flickr.photosets.getList":
+		// one set per page
+		page, _ := strconv.Atoi(r.FormValue("page"))
+		if page == 0 {
+			page = 1
+		}
+		fmt.Fprintf(w, `<photosets page="%d" pages="%d" perpage="1" total="%d">`, page, len(f.sets), len(f.sets))
+		if page <= len(f.sets) {
+			fmt.Fprint(w, f.sets[page-1])
+		}
+		fmt.Fprint(w, `</photosets>`)
+	case "flickr.photosets.create":
+		f.sets = append(f.sets, fmt.Sprintf(`<photoset id="300" date_create="30"><title>%s</title></photoset>`, r.FormValue("title")))
+		f.sets = append(f.sets, f.concurrent...)
+		fmt.Fprint(w, `<photoset id="300" url="http://www.flickr.com/photos/bees/sets/300/" />`)
+	case "flickr.photosets.delete":
+		f.deleted = append(f.deleted, r.FormValue("photoset_id"))
+	}
+	w.Write([]byte(`</rsp>`))
+}
+
+func TestEnsureByTitleExisting(t *testing.T) {
+	f := &fakeSets{sets: []string{
+		`<photoset id="100" date_create="10"><title>holidays</title></photoset>`,
+		`<photoset id="200" date_create="20"><title>Summer</title></photoset>`,
+	}}
+	server, fclient := flickr.FlickrMockHandler(f)
+	defer server.Close()
+
+	set, err := EnsureByTitle(fclient, "Summer", "desc", "123")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, set.Id, "200")
+	flickr.Expect(t, len(f.sets), 2)
+
+	// matching is case-sensitive
+	set, err = EnsureByTitle(fclient, "summer", "desc", "123")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, set.Id, "300")
+	flickr.Expect(t, set.Url, "http://www.flickr.com/photos/bees/sets/300/")
+	flickr.Expect(t, len(f.sets), 3)
+	flickr.Expect(t, len(f.deleted), 0)
+}
+
+func TestEnsureByTitleCreated(t *testing.T) {
+	f := &fakeSets{}
+	server, fclient := flickr.FlickrMockHandler(f)
+	defer server.Close()
+
+	set, err := EnsureByTitle(fclient, "Summer", "desc", "123")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, set.Id, "300")
+	flickr.Expect(t, set.Title, "Summer")
+	flickr.Expect(t, len(f.deleted), 0)
+}
+
+func TestEnsureByTitleRace(t *testing.T) {
+	f := &fakeSets{concurrent: []string{`<photoset id="250" date_create="25"><title>Summer</title></photoset>`}}
+	server, fclient := flickr.FlickrMockHandler(f)
+	defer server.Close()
+
+	set, err := EnsureByTitle(fclient, "Summer", "desc", "123")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, set.Id, "250")
+	flickr.Expect(t, len(f.deleted), 1)
+	flickr.Expect(t, f.deleted[0], "300")
+}
+
+func TestCountAllPhotos(t *testing.T) {
+	f := &fakeSets{sets: []string{
+		`<photoset id="100" photos="10" videos="1"><title>holidays</title></photoset>`,
+		`<photoset id="200" photos="5" videos="0"><title>Summer</title></photoset>`,
+		`<photoset id="300" photos="0" videos="7"><title>Clips</title></photoset>`,
+	}}
+	server, fclient := flickr.FlickrMockHandler(f)
+	defer server.Close()
+
+	total, err := CountAllPhotos(fclient, "123456@N00")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, total, 23)
+	flickr.Expect(t, fclient.Args.Get("user_id"), "123456@N00")
+	flickr.Expect(t, fclient.Args.Get("page"), "3")
+	_, signed := fclient.Args["oauth_token"]
+	flickr.Expect(t, signed, false)
+
+	f.sets = nil
+	fclient.OAuthToken = "token"
+	total, err = CountAllPhotos(fclient, "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, total, 0)
+	flickr.Expect(t, fclient.Args.Get("oauth_token"), "token")
+}
+
+func TestCountAllPhotosRateLimit(t *testing.T) {
+	pages := 0
+	server, fclient := flickr.FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		fmt.Fprint(w, `<rsp stat="ok"><photosets page="1" pages="5"><photoset id="1" photos="3" /></photosets></rsp>`)
+	}))
+	defer server.Close()
+
+	total, err := CountAllPhotos(fclient, "123456@N00")
+	flickr.Expect(t, errors.Is(err, flickr.ErrRateLimited), true)
+	flickr.Expect(t, total, 0)
+	flickr.Expect(t, pages, 1)
+}
+
+// Serve a set of three photos over two pages, recording the methods called. Deleting
+// photo "2" fails with failCode, and the set is gone once its photos are.
+func deletableSet(failCode int) (*httptest.Server, *flickr.FlickrClient, *[]string) {
+	calls := []string{}
+	server, fclient := flickr.FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseMultipartForm(1 << 20)
+		method := r.FormValue("method")
+		calls = append(calls, strings.TrimPrefix(method, "flickr.")+" "+r.FormValue("photo_id"))
+		switch method {
+		case "flickr.photosets.getPhotos":
+			if r.FormValue("page") == "2" {
+				fmt.Fprint(w, `<rsp stat="ok"><photoset id="1" page="2" pages="2"><photo id="3" /></photoset></rsp>`)
+				return
+			}
+			fmt.Fprint(w, `<rsp stat="ok"><photoset id="1" page="1" pages="2"><photo id="1" /><photo id="2" /></photoset></rsp>`)
+		case "flickr.photos.delete":
+			if r.FormValue("photo_id") == "2" {
+				fmt.Fprintf(w, `<rsp stat="fail"><err code="%d" msg="failed" /></rsp>`, failCode)
+				return
+			}
+			fmt.Fprint(w, `<rsp stat="ok"></rsp>`)
+		case "flickr.photosets.delete":
+			fmt.Fprint(w, `<rsp stat="fail"><err code="1" msg="Photoset not found" /></rsp>`)
+		}
+	}))
+	return server, fclient, &calls
+}
+
+func TestDeleteWithPhotos(t *testing.T) {
+	// photo 2 already deleted
+	server, fclient, calls := deletableSet(1)
+	defer server.Close()
+	deleted, err := DeleteWithPhotos(fclient, "1", true)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, deleted, 2)
+	flickr.Expect(t, strings.Join(*calls, ","), "photosets.getPhotos ,photosets.getPhotos ,photos.delete 1,photos.delete 2,photos.delete 3,photosets.delete ")
+
+	server, fclient, calls = deletableSet(99)
+	defer server.Close()
+	deleted, err = DeleteWithPhotos(fclient, "1", true)
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, deleted, 1)
+	flickr.Expect(t, (*calls)[len(*calls)-1], "photos.delete 2")
+
+	// photos are left alone, the set not found is an error
+	server, fclient, calls = deletableSet(1)
+	defer server.Close()
+	deleted, err = DeleteWithPhotos(fclient, "1", false)
+	_, ok = err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, deleted, 0)
+	flickr.Expect(t, strings.Join(*calls, ","), "photosets.delete ")
+}
@@ -0,0 +1,125 @@
+package flickr
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// What is needed to build the URLs of a photo on Flickr static servers
+type PhotoRef struct {
+	Id     string
+	Secret string
+	Server string
+	// Only available to users allowed to access the original size, see
+	// the "original_format" extra
+	OriginalSecret string
+	OriginalFormat string
+}
+
+// Implemented by types describing a photo (Photo, photos.PhotoInfo, ...)
+type PhotoReferrer interface {
+	PhotoRef() PhotoRef
+}
+
+// Return the URL of the photo with the given size suffix (e.g. "b" for large 1024,
+// "" for medium 500), see https://www.flickr.com/services/api/misc.urls.html
+func PhotoURL(p PhotoReferrer, size string) string {
+	ref := p.PhotoRef()
+	if size != "" {
+		size = "_" + size
+	}
+	return fmt.Sprintf("%s/%s/%s_%s%s.jpg", STATIC_URL, ref.Server, ref.Id, ref.Secret, size)
+}
+
+// Size suffixes of the URLs that can be built from the photo secret alone, see SizeURLs:
+// square 75 and 150, thumbnail 100, small 240 and 320, medium 640 and 800, large 1024.
+// Larger sizes and the original one use other secrets.
+var standardSizes = []string{"s", "q", "t", "m", "n", "z", "c", "b"}
+
+// Return the URLs of the standard sizes of a photo by size suffix, built without any call
+// from id, server and secret: a fallback for photos whose sizes can't be fetched with
+// getSizes. Flickr may not have generated all of them for small photos. The original
+// size isn't included, it needs the original secret, see OriginalPhotoURL.
+func SizeURLs(p PhotoReferrer) map[string]string {
+	urls := make(map[string]string, len(standardSizes))
+	for _, size := range standardSizes {
+		urls[size] = PhotoURL(p, size)
+	}
+	return urls
+}
+
+// Return the URL of the original size of the photo, which uses the original secret
+// and format. An error is returned when they are not available.
+func OriginalPhotoURL(p PhotoReferrer) (string, error) {
+	ref := p.PhotoRef()
+	if ref.OriginalSecret == "" || ref.OriginalFormat == "" {
+		return "", flickErr.NewError(flickErr.ArgumentError,
+			fmt.Sprintf("original secret and format of photo %s are not available", ref.Id))
+	}
+	return fmt.Sprintf("%s/%s/%s_%s_o.%s", STATIC_URL, ref.Server, ref.Id, ref.OriginalSecret, ref.OriginalFormat), nil
+}
+
+// Return the URL of the page of a photo on Flickr. The owner path alias, the custom
+// name users can choose for their URLs, is used when not empty, the owner nsid otherwise.
+func PhotoPageURL(nsid, pathAlias, id string) string {
+	owner := nsid
+	if pathAlias != "" {
+		owner = pathAlias
+	}
+	return fmt.Sprintf("https://www.flickr.com/photos/%s/%s", owner, id)
+}
+
+// Digits of the base58 encoding of photo ids in short URLs: 0, O, I and l are left out
+const base58Alphabet = "123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// Return the short URL of a photo (SHORT_URL followed by the base58 encoded id),
+// or an empty string if photoId is not a photo id
+func EncodeShortURL(photoId string) string {
+	id, err := strconv.ParseUint(photoId, 10, 64)
+	if err != nil || id == 0 {
+		return ""
+	}
+
+	var code []byte
+	for ; id > 0; id /= 58 {
+		code = append([]byte{base58Alphabet[id%58]}, code...)
+	}
+	return SHORT_URL + string(code)
+}
+
+// Return the id of the photo a short URL (e.g. https://flic.kr/p/6aLSHT) points to.
+// The scheme can be omitted. An ArgumentError is returned for URLs not on flic.kr,
+// invalid base58 codes and ids too large to be photo ids.
+func DecodeShortURL(shortURL string) (string, error) {
+	raw := strings.TrimSpace(shortURL)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", flickErr.NewError(flickErr.ArgumentError, "invalid short URL: "+err.Error())
+	}
+	code := strings.TrimSuffix(strings.TrimPrefix(u.Path, "/p/"), "/")
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	if host != "flic.kr" || !strings.HasPrefix(u.Path, "/p/") || code == "" || strings.Contains(code, "/") {
+		return "", flickErr.NewError(flickErr.ArgumentError, shortURL+" is not a flic.kr photo URL")
+	}
+
+	var id uint64
+	for _, c := range code {
+		digit := strings.IndexRune(base58Alphabet, c)
+		if digit < 0 {
+			return "", flickErr.NewError(flickErr.ArgumentError, fmt.Sprintf("invalid character %q in short URL %s", c, shortURL))
+		}
+		if id > (math.MaxUint64-uint64(digit))/58 {
+			return "", flickErr.NewError(flickErr.ArgumentError, "photo id of short URL "+shortURL+" overflows")
+		}
+		id = id*58 + uint64(digit)
+	}
+	return strconv.FormatUint(id, 10), nil
+}
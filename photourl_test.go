@@ -0,0 +1,88 @@
+package flickr
+
+import (
+	"testing"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestPhotoURL(t *testing.T) {
+	p := &Photo{Id: "2636", Secret: "a123456", Server: "2"}
+	Expect(t, PhotoURL(p, ""), "https://live.staticflickr.com/2/2636_a123456.jpg")
+	Expect(t, PhotoURL(p, "b"), "https://live.staticflickr.com/2/2636_a123456_b.jpg")
+}
+
+func TestSizeURLs(t *testing.T) {
+	p := &Photo{Id: "2636", Secret: "a123456", Server: "2", OriginalSecret: "b654321", OriginalFormat: "png"}
+	urls := p.SizeURLs()
+	Expect(t, len(urls), 8)
+	for size, url := range map[string]string{
+		"s": "https://live.staticflickr.com/2/2636_a123456_s.jpg",
+		"q": "https://live.staticflickr.com/2/2636_a123456_q.jpg",
+		"t": "https://live.staticflickr.com/2/2636_a123456_t.jpg",
+		"m": "https://live.staticflickr.com/2/2636_a123456_m.jpg",
+		"n": "https://live.staticflickr.com/2/2636_a123456_n.jpg",
+		"z": "https://live.staticflickr.com/2/2636_a123456_z.jpg",
+		"c": "https://live.staticflickr.com/2/2636_a123456_c.jpg",
+		"b": "https://live.staticflickr.com/2/2636_a123456_b.jpg",
+	} {
+		Expect(t, urls[size], url)
+	}
+	_, found := urls["o"]
+	Expect(t, found, false)
+}
+
+func TestOriginalPhotoURL(t *testing.T) {
+	p := &Photo{Id: "2636", Secret: "a123456", Server: "2", OriginalSecret: "b654321", OriginalFormat: "png"}
+	url, err := OriginalPhotoURL(p)
+	Expect(t, err, nil)
+	Expect(t, url, "https://live.staticflickr.com/2/2636_b654321_o.png")
+
+	p.OriginalSecret = ""
+	url, err = OriginalPhotoURL(p)
+	Expect(t, url, "")
+	ferr, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+}
+
+func TestShortURL(t *testing.T) {
+	for _, c := range []struct{ code, id string }{
+		{"2", "1"},
+		{"Z", "57"},
+		{"21", "58"},
+		{"6aLSHT", "3392387861"},
+		{"ubyieR", "18497456039"},
+		{"2ovpEZr", "52841338563"},
+		{"JPwcyDCgEup", "18446744073709551615"},
+	} {
+		Expect(t, EncodeShortURL(c.id), "https://flic.kr/p/"+c.code)
+		id, err := DecodeShortURL("https://flic.kr/p/" + c.code)
+		Expect(t, err, nil)
+		Expect(t, id, c.id)
+	}
+
+	for _, u := range []string{"http://flic.kr/p/6aLSHT", "flic.kr/p/6aLSHT/", " https://www.flic.kr/p/6aLSHT?x=1 "} {
+		id, err := DecodeShortURL(u)
+		Expect(t, err, nil)
+		Expect(t, id, "3392387861")
+	}
+
+	Expect(t, EncodeShortURL("abc"), "")
+	Expect(t, EncodeShortURL("0"), "")
+
+	for _, u := range []string{
+		"https://example.com/p/6aLSHT",
+		"https://flic.kr/s/6aLSHT",
+		"https://flic.kr/p/",
+		"https://flic.kr/p/6aL0HT",
+		"https://flic.kr/p/JPwcyDCgEuq",
+		"https://flic.kr/p/%zz",
+	} {
+		id, err := DecodeShortURL(u)
+		Expect(t, id, "")
+		ferr, ok := err.(*flickErr.Error)
+		Expect(t, ok, true)
+		Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	}
+}
@@ -0,0 +1,98 @@
+// Package implementing methods: flickr.places.*
+package places
+
+import (
+	"strconv"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Place type ids used by Flickr
+const (
+	Locality      = 7
+	Region        = 8
+	County        = 9
+	Country       = 12
+	Neighbourhood = 22
+	Continent     = 29
+)
+
+type Place struct {
+	PlaceId     string  `xml:"place_id,attr"`
+	WoeId       string  `xml:"woeid,attr"`
+	Latitude    float64 `xml:"latitude,attr"`
+	Longitude   float64 `xml:"longitude,attr"`
+	PlaceUrl    string  `xml:"place_url,attr"`
+	PlaceType   string  `xml:"place_type,attr"`
+	PlaceTypeId int     `xml:"place_type_id,attr"`
+	PhotoCount  int     `xml:"photo_count,attr"`
+	Name        string  `xml:",chardata"`
+}
+
+type TopPlacesResponse struct {
+	flickr.BasicResponse
+	Places struct {
+		Total       int     `xml:"total,attr"`
+		DateStart   string  `xml:"date_start,attr"`
+		DateStop    string  `xml:"date_stop,attr"`
+		PlaceTypeId int     `xml:"place_type_id,attr"`
+		Places      []Place `xml:"place"`
+	} `xml:"places"`
+}
+
+type UserPlacesResponse struct {
+	flickr.BasicResponse
+	Places struct {
+		Total  int     `xml:"total,attr"`
+		Places []Place `xml:"place"`
+	} `xml:"places"`
+}
+
+// Set the optional woe_id and place_id args
+func setPlaceArgs(client *flickr.FlickrClient, woeId, placeId string) {
+	if woeId != "" {
+		client.Args.Set("woe_id", woeId)
+	}
+	if placeId != "" {
+		client.Args.Set("place_id", placeId)
+	}
+}
+
+// Return the top 100 most geotagged places of type placeTypeId for a day, date is
+// in YYYY-MM-DD format and defaults to yesterday when empty. woeId and placeId are
+// optional and limit results to places within the given one.
+// This method does not require authentication.
+func GetTopPlacesList(client *flickr.FlickrClient, placeTypeId int, date string, woeId, placeId string) (*TopPlacesResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.places.getTopPlacesList")
+	client.Args.Set("place_type_id", strconv.Itoa(placeTypeId))
+	if date != "" {
+		client.Args.Set("date", date)
+	}
+	setPlaceArgs(client, woeId, placeId)
+	client.ApiSign()
+
+	response := &TopPlacesResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Return the places of type placeTypeId where the calling user has geotagged photos,
+// along with the number of photos. woeId and placeId are optional and limit results
+// to places within the given one, threshold (ignored if 0) is the minimum number of
+// photos a place must have to be included.
+// This method requires authentication with 'read' permission.
+func PlacesForUser(client *flickr.FlickrClient, placeTypeId int, woeId, placeId string, threshold int) (*UserPlacesResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.places.placesForUser")
+	client.Args.Set("place_type_id", strconv.Itoa(placeTypeId))
+	setPlaceArgs(client, woeId, placeId)
+	if threshold > 0 {
+		client.Args.Set("threshold", strconv.Itoa(threshold))
+	}
+	client.OAuthSign()
+
+	response := &UserPlacesResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
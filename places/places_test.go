@@ -0,0 +1,64 @@
+package places
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+func TestGetTopPlacesList(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <places total="100" date_start="1246320000" date_stop="1246406399" place_type_id="12">
+    <place place_id="4KO02SibApitvSBieQ" woeid="23424977" latitude="48.890" longitude="-116.982"
+      place_url="/United+States" place_type="country" place_type_id="12" photo_count="23371">United States</place>
+  </places>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetTopPlacesList(fclient, Country, "2009-06-30", "", "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.places.getTopPlacesList")
+	flickr.Expect(t, fclient.Args.Get("place_type_id"), "12")
+	flickr.Expect(t, fclient.Args.Get("date"), "2009-06-30")
+	_, found := fclient.Args["woe_id"]
+	flickr.Expect(t, found, false)
+	flickr.Expect(t, resp.Places.Total, 100)
+	p := resp.Places.Places[0]
+	flickr.Expect(t, p.WoeId, "23424977")
+	flickr.Expect(t, p.Latitude, 48.890)
+	flickr.Expect(t, p.PhotoCount, 23371)
+	flickr.Expect(t, p.Name, "United States")
+}
+
+func TestPlacesForUser(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <places total="2">
+    <place place_id="kH8dLOubBZRvX_YZ" woeid="2487956" latitude="37.779" longitude="-122.420"
+      place_url="/United+States/California/San+Francisco" place_type="locality" photo_count="156">San Francisco, California</place>
+    <place place_id="n.ozdPSbBZRTXg" woeid="44418" latitude="51.506" longitude="-0.127"
+      place_url="/United+Kingdom/England/London" place_type="locality" photo_count="12">London, England</place>
+  </places>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := PlacesForUser(fclient, Locality, "23424977", "", 10)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.places.placesForUser")
+	flickr.Expect(t, fclient.Args.Get("place_type_id"), "7")
+	flickr.Expect(t, fclient.Args.Get("woe_id"), "23424977")
+	flickr.Expect(t, fclient.Args.Get("threshold"), "10")
+	flickr.Expect(t, resp.Places.Total, 2)
+	flickr.Expect(t, resp.Places.Places[1].PhotoCount, 12)
+	flickr.Expect(t, resp.Places.Places[1].PlaceType, "locality")
+
+	_, err = PlacesForUser(fclient, Locality, "", "", 0)
+	flickr.Expect(t, err, nil)
+	_, found := fclient.Args["threshold"]
+	flickr.Expect(t, found, false)
+}
@@ -0,0 +1,68 @@
+// Package implementing methods: flickr.prefs.*
+package prefs
+
+import (
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Response type shared by all the prefs methods, each method only fills
+// in the field it refers to.
+type PrefsResponse struct {
+	flickr.BasicResponse
+	Person struct {
+		Nsid string `xml:"nsid,attr"`
+		// 1 photos, 2 screenshots, 3 other
+		ContentType int `xml:"content_type,attr"`
+		// 0 no default, 1 nobody, 2 friends and family, 3 contacts, 4 everyone
+		GeoPerms      int  `xml:"geoperms,attr"`
+		ImportGeoExif bool `xml:"importgeoexif,attr"`
+		// 1 visible in public searches, 2 hidden
+		Hidden int `xml:"hidden,attr"`
+		// 1 public, 2 friends only, 3 family only, 4 friends and family, 5 private
+		Privacy int `xml:"privacy,attr"`
+		// 1 safe, 2 moderate, 3 restricted
+		SafetyLevel int `xml:"safety_level,attr"`
+	} `xml:"person"`
+}
+
+// Perform an authenticated call to one of the prefs methods
+func getPref(client *flickr.FlickrClient, method string) (*PrefsResponse, error) {
+	client.Init()
+	client.Args.Set("method", method)
+	client.OAuthSign()
+
+	response := &PrefsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Returns the default content type preference for the user.
+// This method requires authentication with 'read' permission.
+func GetContentType(client *flickr.FlickrClient) (*PrefsResponse, error) {
+	return getPref(client, "flickr.prefs.getContentType")
+}
+
+// Returns the default privacy level for geographic information attached to the user's photos
+// and whether or not the user has chosen to use geo-related EXIF information.
+// This method requires authentication with 'read' permission.
+func GetGeoPerms(client *flickr.FlickrClient) (*PrefsResponse, error) {
+	return getPref(client, "flickr.prefs.getGeoPerms")
+}
+
+// Returns the default hidden preference for the user.
+// This method requires authentication with 'read' permission.
+func GetHidden(client *flickr.FlickrClient) (*PrefsResponse, error) {
+	return getPref(client, "flickr.prefs.getHidden")
+}
+
+// Returns the default privacy level preference for the user.
+// This method requires authentication with 'read' permission.
+func GetPrivacy(client *flickr.FlickrClient) (*PrefsResponse, error) {
+	return getPref(client, "flickr.prefs.getPrivacy")
+}
+
+// Returns the default safety level preference for the user.
+// This method requires authentication with 'read' permission.
+func GetSafetyLevel(client *flickr.FlickrClient) (*PrefsResponse, error) {
+	return getPref(client, "flickr.prefs.getSafetyLevel")
+}
@@ -0,0 +1,63 @@
+package prefs
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestGetPrefs(t *testing.T) {
+	cases := []struct {
+		method string
+		f      func(*flickr.FlickrClient) (*PrefsResponse, error)
+		attr   string
+		value  func(*PrefsResponse) int
+	}{
+		{"flickr.prefs.getContentType", GetContentType, `content_type="2"`, func(r *PrefsResponse) int { return r.Person.ContentType }},
+		{"flickr.prefs.getGeoPerms", GetGeoPerms, `geoperms="2" importgeoexif="1"`, func(r *PrefsResponse) int { return r.Person.GeoPerms }},
+		{"flickr.prefs.getHidden", GetHidden, `hidden="2"`, func(r *PrefsResponse) int { return r.Person.Hidden }},
+		{"flickr.prefs.getPrivacy", GetPrivacy, `privacy="2"`, func(r *PrefsResponse) int { return r.Person.Privacy }},
+		{"flickr.prefs.getSafetyLevel", GetSafetyLevel, `safety_level="2"`, func(r *PrefsResponse) int { return r.Person.SafetyLevel }},
+	}
+
+	for _, c := range cases {
+		fclient := flickr.GetTestClient()
+		server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok"><person nsid="12037949754@N01" `+c.attr+` /></rsp>`, "")
+		fclient.HTTPClient = client
+		resp, err := c.f(fclient)
+		server.Close()
+
+		flickr.Expect(t, err, nil)
+		flickr.Expect(t, fclient.Args.Get("method"), c.method)
+		flickr.Expect(t, resp.Person.Nsid, "12037949754@N01")
+		flickr.Expect(t, c.value(resp), 2)
+	}
+}
+
+func TestGetGeoPerms(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok"><person nsid="12037949754@N01" geoperms="0" importgeoexif="1" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetGeoPerms(fclient)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Person.GeoPerms, 0)
+	flickr.Expect(t, resp.Person.ImportGeoExif, true)
+}
+
+func TestGetPrivacyKo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="fail"><err code="99" msg="Insufficient permissions. Method requires read privileges; none granted." /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPrivacy(fclient)
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.ErrorCode(), 99)
+}
@@ -0,0 +1,46 @@
+// Package implementing methods: flickr.profile.*
+package profile
+
+import (
+	"gopkg.in/masci/flickr.v2"
+)
+
+// A user profile, fields the caller is not allowed to see are left empty
+type Profile struct {
+	Id               string `xml:"id,attr"`
+	Nsid             string `xml:"nsid,attr"`
+	JoinDate         string `xml:"join_date,attr"`
+	Occupation       string `xml:"occupation,attr"`
+	Hometown         string `xml:"hometown,attr"`
+	City             string `xml:"city,attr"`
+	Country          string `xml:"country,attr"`
+	FirstName        string `xml:"first_name,attr"`
+	LastName         string `xml:"last_name,attr"`
+	Description      string `xml:"profile_description,attr"`
+	Website          string `xml:"website,attr"`
+	Facebook         string `xml:"facebook,attr"`
+	Twitter          string `xml:"twitter,attr"`
+	Tumblr           string `xml:"tumblr,attr"`
+	Instagram        string `xml:"instagram,attr"`
+	Pinterest        string `xml:"pinterest,attr"`
+	ShowcaseSet      string `xml:"showcase_set,attr"`
+	ShowcaseSetTitle string `xml:"showcase_set_title,attr"`
+}
+
+type ProfileResponse struct {
+	flickr.BasicResponse
+	Profile Profile `xml:"profile"`
+}
+
+// Returns specified user's profile info.
+// This method does not require authentication.
+func GetProfile(client *flickr.FlickrClient, userId string) (*ProfileResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.profile.getProfile")
+	client.Args.Set("user_id", userId)
+	client.ApiSign()
+
+	response := &ProfileResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
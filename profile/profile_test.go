@@ -0,0 +1,49 @@
+package profile
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestGetProfile(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <profile id="12037949754@N01" nsid="12037949754@N01" join_date="1123955457" occupation="Photographer" hometown="Milan" showcase_set="72157600001" first_name="Jane" last_name="Doe" city="London" country="UK" website="https://example.com" twitter="janedoe" instagram="jane.doe" />
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetProfile(fclient, "12037949754@N01")
+	flickr.Expect(t, err, nil)
+	p := resp.Profile
+	flickr.Expect(t, p.Nsid, "12037949754@N01")
+	flickr.Expect(t, p.JoinDate, "1123955457")
+	flickr.Expect(t, p.Occupation, "Photographer")
+	flickr.Expect(t, p.Hometown, "Milan")
+	flickr.Expect(t, p.City, "London")
+	flickr.Expect(t, p.Country, "UK")
+	flickr.Expect(t, p.Website, "https://example.com")
+	flickr.Expect(t, p.Twitter, "janedoe")
+	flickr.Expect(t, p.Instagram, "jane.doe")
+	flickr.Expect(t, p.ShowcaseSet, "72157600001")
+	// not visible to the caller
+	flickr.Expect(t, p.Facebook, "")
+}
+
+func TestGetProfileKo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="fail">
+  <err code="1" msg="User not found" />
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetProfile(fclient, "nobody")
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.HasErrors(), true)
+}
@@ -0,0 +1,128 @@
+// Package implementing methods: flickr.push.*
+package push
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Error codes returned by Subscribe and Unsubscribe, compare them with the
+// response ErrorCode()
+const (
+	RequiredParameterMissing = 1
+	InvalidParameterValue    = 2
+	CallbackFailed           = 3
+	AwaitingVerification     = 4
+	VerificationFailed       = 5
+)
+
+type TopicsResponse struct {
+	flickr.BasicResponse
+	Topics []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"topics>topic"`
+}
+
+type Subscription struct {
+	Topic          string `xml:"topic,attr"`
+	Callback       string `xml:"callback,attr"`
+	Pending        bool   `xml:"pending,attr"`
+	DateCreate     string `xml:"date_create,attr"`
+	LeaseSeconds   int    `xml:"lease_seconds,attr"`
+	Expiry         string `xml:"expiry,attr"`
+	VerifyAttempts int    `xml:"verify_attempts,attr"`
+}
+
+type SubscriptionsResponse struct {
+	flickr.BasicResponse
+	Subscriptions []Subscription `xml:"subscriptions>subscription"`
+}
+
+// Topic specific arguments for Subscribe, empty values are ignored
+type SubscribeOptionalArgs struct {
+	VerifyToken string   // echoed back to the callback during verification
+	Tags        []string // for the "tags" topic
+	UserIds     []string // for the "my_photos"-like topics restricted to some users
+	WoeIds      []string // for the "geo" topic
+	PlaceIds    []string // for the "geo" topic
+}
+
+// Returns a list of the topics available for subscription.
+// This method does not require authentication.
+func GetTopics(client *flickr.FlickrClient) (*TopicsResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.push.getTopics")
+	client.ApiSign()
+
+	response := &TopicsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Returns a list of the subscriptions for the calling user.
+// This method requires authentication with 'read' permission.
+func GetSubscriptions(client *flickr.FlickrClient) (*SubscriptionsResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.push.getSubscriptions")
+	client.OAuthSign()
+
+	response := &SubscriptionsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Subscribe callbackUrl to the given topic. verify is either "sync" or "async" and
+// sets how Flickr verifies the callback, leaseSeconds is ignored when 0.
+// This method requires authentication with 'read' permission.
+func Subscribe(client *flickr.FlickrClient, topic, callbackUrl, verify string, leaseSeconds int, opts SubscribeOptionalArgs) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.push.subscribe")
+	client.Args.Set("topic", topic)
+	client.Args.Set("callback", callbackUrl)
+	client.Args.Set("verify", verify)
+	if leaseSeconds > 0 {
+		client.Args.Set("lease_seconds", strconv.Itoa(leaseSeconds))
+	}
+	if opts.VerifyToken != "" {
+		client.Args.Set("verify_token", opts.VerifyToken)
+	}
+	if len(opts.Tags) > 0 {
+		client.Args.Set("tags", strings.Join(opts.Tags, ","))
+	}
+	if len(opts.UserIds) > 0 {
+		client.Args.Set("nsids", strings.Join(opts.UserIds, ","))
+	}
+	if len(opts.WoeIds) > 0 {
+		client.Args.Set("woe_ids", strings.Join(opts.WoeIds, ","))
+	}
+	if len(opts.PlaceIds) > 0 {
+		client.Args.Set("place_ids", strings.Join(opts.PlaceIds, ","))
+	}
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Unsubscribe callbackUrl from the given topic, verifyToken is ignored if empty.
+// This method requires authentication with 'read' permission.
+func Unsubscribe(client *flickr.FlickrClient, topic, callbackUrl, verify, verifyToken string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.push.unsubscribe")
+	client.Args.Set("topic", topic)
+	client.Args.Set("callback", callbackUrl)
+	client.Args.Set("verify", verify)
+	if verifyToken != "" {
+		client.Args.Set("verify_token", verifyToken)
+	}
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
@@ -0,0 +1,99 @@
+package push
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestGetTopics(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <topics>
+    <topic name="contacts_photos" />
+    <topic name="my_faves" />
+    <topic name="tags" />
+  </topics>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetTopics(fclient)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(resp.Topics), 3)
+	flickr.Expect(t, resp.Topics[2].Name, "tags")
+}
+
+func TestGetSubscriptions(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <subscriptions>
+    <subscription topic="contacts_photos" callback="https://example.com/push" pending="0" date_create="1302211123" lease_seconds="86400" expiry="1302297523" verify_attempts="1" />
+  </subscriptions>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetSubscriptions(fclient)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(resp.Subscriptions), 1)
+	s := resp.Subscriptions[0]
+	flickr.Expect(t, s.Topic, "contacts_photos")
+	flickr.Expect(t, s.Callback, "https://example.com/push")
+	flickr.Expect(t, s.Pending, false)
+	flickr.Expect(t, s.LeaseSeconds, 86400)
+	flickr.Expect(t, s.Expiry, "1302297523")
+	flickr.Expect(t, s.VerifyAttempts, 1)
+}
+
+func TestSubscribe(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	opts := SubscribeOptionalArgs{VerifyToken: "t0k3n", Tags: []string{"cats", "kittens"}}
+	_, err := Subscribe(fclient, "tags", "https://example.com/push", "sync", 3600, opts)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.HTTPVerb, "POST")
+	flickr.Expect(t, fclient.Args.Get("oauth_signature") != "", true)
+	flickr.Expect(t, fclient.Args.Get("topic"), "tags")
+	flickr.Expect(t, fclient.Args.Get("callback"), "https://example.com/push")
+	flickr.Expect(t, fclient.Args.Get("verify"), "sync")
+	flickr.Expect(t, fclient.Args.Get("verify_token"), "t0k3n")
+	flickr.Expect(t, fclient.Args.Get("lease_seconds"), "3600")
+	flickr.Expect(t, fclient.Args.Get("tags"), "cats,kittens")
+	_, found := fclient.Args["nsids"]
+	flickr.Expect(t, found, false)
+}
+
+func TestSubscribeKo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="fail"><err code="4" msg="Subscription awaiting verification callback response - try again later" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := Subscribe(fclient, "my_faves", "https://example.com/push", "async", 0, SubscribeOptionalArgs{})
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.ErrorCode(), AwaitingVerification)
+	_, found := fclient.Args["lease_seconds"]
+	flickr.Expect(t, found, false)
+}
+
+func TestUnsubscribe(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := Unsubscribe(fclient, "tags", "https://example.com/push", "sync", "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.push.unsubscribe")
+	_, found := fclient.Args["verify_token"]
+	flickr.Expect(t, found, false)
+}
@@ -0,0 +1,100 @@
+package flickr
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Wrapped by the errors returned instead of sending requests when the rate limit
+// reported by Flickr is exhausted, check it with errors.Is
+var ErrRateLimited = errors.New("rate limit reached")
+
+// Error returned by CheckRateLimit, holding the time the quota resets at. Get it with
+// errors.As.
+type RateLimitError struct {
+	Reset time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	return ErrRateLimited.Error() + ", quota resets at " + e.Reset.String()
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
+
+// Latest rate limit values reported by Flickr through X-RateLimit-* headers
+type rateLimitState struct {
+	sync.Mutex
+	limit     int
+	remaining int
+	reset     time.Time
+}
+
+// Update the state with the headers of a response, missing headers are ignored
+func (s *rateLimitState) record(header http.Header) {
+	if s == nil {
+		return
+	}
+	limit, errLimit := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	remaining, errRemaining := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	reset, errReset := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if errLimit != nil && errRemaining != nil && errReset != nil {
+		return
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	if errLimit == nil {
+		s.limit = limit
+	}
+	if errRemaining == nil {
+		s.remaining = remaining
+	}
+	if errReset == nil {
+		s.reset = time.Unix(reset, 0)
+	}
+}
+
+// Forget the values recorded so far
+func (s *rateLimitState) clear() {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.limit, s.remaining, s.reset = 0, 0, time.Time{}
+}
+
+// Return the rate limit values reported by the latest response carrying X-RateLimit-*
+// headers: the number of calls allowed, how many are left and when the quota resets.
+// Zero values are returned until such a response is received, and again once the reset
+// time has passed: the quota is unknown until the next response. It is safe to call
+// RateLimit while requests are in flight, copies of the client made by Batch share it.
+func (c *FlickrClient) RateLimit() (limit, remaining int, reset time.Time) {
+	s := c.rateLimit
+	if s == nil {
+		return 0, 0, time.Time{}
+	}
+	now := c.now()
+	s.Lock()
+	defer s.Unlock()
+	if !s.reset.IsZero() && now.After(s.reset) {
+		return 0, 0, time.Time{}
+	}
+	return s.limit, s.remaining, s.reset
+}
+
+// Return a *RateLimitError when the rate limit reported by Flickr (see RateLimit) leaves
+// fewer than calls requests, nil when it allows them or is unknown. Helpers sending
+// several requests check it before each one, so that they stop once the quota is
+// exhausted.
+func (c *FlickrClient) CheckRateLimit(calls int) error {
+	if limit, remaining, reset := c.RateLimit(); limit > 0 && remaining < calls {
+		return &RateLimitError{Reset: reset}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package flickr
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	remaining := "3599"
+	resetAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if remaining != "" {
+			w.Header().Set("X-RateLimit-Limit", "3600")
+			w.Header().Set("X-RateLimit-Remaining", remaining)
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+		}
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`))
+	}))
+	defer server.Close()
+
+	fclient := GetTestClient()
+	limit, left, reset := fclient.RateLimit()
+	Expect(t, limit, 0)
+	Expect(t, left, 0)
+	Expect(t, reset.IsZero(), true)
+
+	fclient.Init()
+	fclient.EndpointUrl = server.URL
+	err := DoGet(fclient, &BasicResponse{})
+	Expect(t, err, nil)
+	limit, left, reset = fclient.RateLimit()
+	Expect(t, limit, 3600)
+	Expect(t, left, 3599)
+	Expect(t, reset.Equal(resetAt), true)
+
+	// responses without headers leave values untouched
+	remaining = ""
+	err = DoPost(fclient, &BasicResponse{})
+	Expect(t, err, nil)
+	_, left, _ = fclient.RateLimit()
+	Expect(t, left, 3599)
+}
+
+func TestRateLimitConcurrent(t *testing.T) {
+	s := &rateLimitState{}
+	client := &FlickrClient{rateLimit: s}
+	header := http.Header{}
+	header.Set("X-RateLimit-Remaining", "10")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			s.record(header)
+		}()
+		go func() {
+			defer wg.Done()
+			client.RateLimit()
+		}()
+	}
+	wg.Wait()
+	_, left, _ := client.RateLimit()
+	Expect(t, left, 10)
+}
+
+func TestRateLimitPastReset(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	server, fclient := FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`))
+	}))
+	defer server.Close()
+
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "3600")
+	header.Set("X-RateLimit-Remaining", "0")
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10))
+	fclient.rateLimit.record(header)
+
+	// the quota is unknown again once the reset time has passed
+	limit, left, reset := fclient.RateLimit()
+	Expect(t, limit, 0)
+	Expect(t, left, 0)
+	Expect(t, reset.IsZero(), true)
+
+	results, err := Batch(fclient, []Call{{Method: "flickr.test.echo"}, {Method: "flickr.test.echo"}})
+	Expect(t, err, nil)
+	for _, res := range results {
+		Expect(t, res.Err, nil)
+	}
+	Expect(t, requests, 2)
+}
+
+func TestCheckRateLimit(t *testing.T) {
+	fclient := NewFlickrClient("apikey", "apisecret")
+	Expect(t, fclient.CheckRateLimit(1), nil)
+
+	resetAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "3600")
+	header.Set("X-RateLimit-Remaining", "3")
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+	fclient.rateLimit.record(header)
+	Expect(t, fclient.CheckRateLimit(3), nil)
+
+	err := fclient.CheckRateLimit(4)
+	Expect(t, errors.Is(err, ErrRateLimited), true)
+	var limited *RateLimitError
+	Expect(t, errors.As(err, &limited), true)
+	Expect(t, limited.Reset.Equal(resetAt), true)
+}
@@ -0,0 +1,248 @@
+// Package recorder records the responses of the Flickr API to files and replays them,
+// so that code built on top of the flickr package can be tested against real responses
+// without hitting Flickr.
+package recorder
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Whether a Recorder sends requests and saves responses or serves saved ones
+type Mode int
+
+const (
+	// Serve saved responses, requests without one fail
+	Replay Mode = iota
+	// Send requests and save their responses, overwriting previous recordings
+	Record
+)
+
+// A response saved by a Recorder, one file per request
+type recording struct {
+	// The request the response was received for, see requestKey
+	Request     string `json:"request"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body"`
+}
+
+// An http.RoundTripper recording responses to files in Dir, or replaying them, set it as
+// the Transport of FlickrClient.HTTPClient (see flickr.WithTransport).
+// Requests are matched on their method, endpoint and arguments, whether sent in the URL
+// or in the body, except the ones changing on each call (oauth_*, api_sig) and the
+// credentials (api_key, auth_token, blog_password), so that recordings can be replayed
+// with other credentials and never hold them. For the same reason the tokens found in
+// response bodies, as sent by the OAuth flow and the auth.oauth.* methods, are saved as
+// "REDACTED": replaying them gives the placeholder, recording still hands the real body
+// to the client. Responses are saved uncompressed, as JSON files.
+type Recorder struct {
+	Dir  string
+	Mode Mode
+	// Used to send requests in Record mode, http.DefaultTransport if nil
+	Transport http.RoundTripper
+	// Names of further arguments left out of the recordings, along with the default ones
+	Redact []string
+}
+
+// Arguments always left out of the recordings, besides the oauth_* ones
+var redactedArgs = []string{"api_sig", "api_key", "auth_token", "blog_password"}
+
+// Placeholder saved instead of the tokens of response bodies
+const redacted = "REDACTED"
+
+// Tokens in response bodies: oauth_token and oauth_token_secret fields of OAuth flow
+// replies and XML attributes, <token> elements and their JSON counterparts
+var tokenPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`((?:^|&)oauth_token(?:_secret)?=)[^&\s]*()`),
+	regexp.MustCompile(`(\soauth_token(?:_secret)?=")[^"]*(")`),
+	regexp.MustCompile(`(<token>)[^<]*(</token>)`),
+	regexp.MustCompile(`("(?:oauth_token|oauth_token_secret|token)"\s*:\s*(?:\{\s*"_content"\s*:\s*)?")[^"]*(")`),
+}
+
+// Replace the tokens of a response body with the redacted placeholder
+func scrubBody(body string) string {
+	for _, pattern := range tokenPatterns {
+		body = pattern.ReplaceAllString(body, "${1}"+redacted+"${2}")
+	}
+	return body
+}
+
+// Create a Recorder saving responses in dir, created if missing in Record mode
+func New(dir string, mode Mode) *Recorder {
+	return &Recorder{Dir: dir, Mode: mode}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	key, method, err := requestKey(req, r.Redact)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(r.Dir, fileName(key, method))
+
+	if r.Mode == Record {
+		return r.record(req, key, path)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("recorder: no recording for %s", key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	rec := recording{}
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("recorder: invalid recording %s: %v", path, err)
+	}
+
+	res := &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.Status, http.StatusText(rec.Status)),
+		StatusCode:    rec.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{},
+		Body:          ioutil.NopCloser(strings.NewReader(rec.Body)),
+		ContentLength: int64(len(rec.Body)),
+		Request:       req,
+	}
+	if rec.ContentType != "" {
+		res.Header.Set("Content-Type", rec.ContentType)
+	}
+	return res, nil
+}
+
+// Send the request and save its response at path
+func (r *Recorder) record(req *http.Request, key, path string) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	// let the transport decompress responses, so that they are saved readable
+	req = req.Clone(req.Context())
+	req.Header.Del("Accept-Encoding")
+
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	data, err := json.MarshalIndent(recording{
+		Request:     key,
+		Status:      res.StatusCode,
+		ContentType: res.Header.Get("Content-Type"),
+		Body:        scrubBody(string(body)),
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(r.Dir, 0755); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Tell whether an argument changes on each call or with credentials, or is in redact
+func isVolatile(arg string, redact []string) bool {
+	if strings.HasPrefix(arg, "oauth_") {
+		return true
+	}
+	for _, names := range [][]string{redactedArgs, redact} {
+		for _, name := range names {
+			if arg == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Return the HTTP method, the URL without query and the stable arguments of the
+// request, sorted, along with the API method called. Arguments in redact are left
+// out along with the volatile ones. The body of POST requests is read and restored.
+func requestKey(req *http.Request, redact []string) (key, method string, err error) {
+	args := url.Values{}
+	for k, v := range req.URL.Query() {
+		args[k] = v
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return "", "", err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if err := bodyArgs(req.Header.Get("Content-Type"), body, args); err != nil {
+			return "", "", err
+		}
+	}
+
+	for k := range args {
+		if isVolatile(k, redact) {
+			args.Del(k)
+		}
+	}
+	u := *req.URL
+	u.RawQuery = ""
+	return fmt.Sprintf("%s %s?%s", req.Method, u.String(), args.Encode()), args.Get("method"), nil
+}
+
+// Add to args the fields of a form body, files excluded
+func bodyArgs(contentType string, body []byte, args url.Values) error {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil
+	}
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return err
+		}
+		for k, v := range values {
+			args[k] = append(args[k], v...)
+		}
+	case "multipart/form-data":
+		form, err := multipart.NewReader(bytes.NewReader(body), params["boundary"]).ReadForm(int64(len(body)) + 1)
+		if err != nil {
+			return err
+		}
+		defer form.RemoveAll()
+		for k, v := range form.Value {
+			args[k] = append(args[k], v...)
+		}
+	}
+	return nil
+}
+
+// Return the name of the file the response to the request with the given key is saved
+// to: the API method called, if any, followed by a hash of the key
+func fileName(key, method string) string {
+	if method == "" {
+		method = "request"
+	}
+	sum := sha1.Sum([]byte(key))
+	return fmt.Sprintf("%s-%x.json", method, sum[:8])
+}
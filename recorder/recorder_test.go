@@ -0,0 +1,185 @@
+package recorder
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/auth/oauth"
+)
+
+func TestRecordReplay(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		r.ParseMultipartForm(1 << 20)
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+		fmt.Fprintf(w, `<rsp stat="ok"><photo id="%s" /></rsp>`, r.FormValue("photo_id"))
+	}))
+	u, _ := url.Parse(server.URL)
+	dir := filepath.Join(t.TempDir(), "fixtures")
+
+	rec := New(dir, Record)
+	rec.Transport = flickr.RewriteTransport{URL: u}
+	fclient := flickr.GetTestClient()
+	fclient.HTTPClient = &http.Client{Transport: rec}
+	fclient.Compress = true
+
+	call := func(client *flickr.FlickrClient, method, id string, post bool) (string, error) {
+		client.Init()
+		client.Args.Set("method", method)
+		client.Args.Set("photo_id", id)
+		client.OAuthSign()
+		resp := &flickr.BasicResponse{}
+		var err error
+		if post {
+			err = flickr.DoPost(client, resp)
+		} else {
+			err = flickr.DoGet(client, resp)
+		}
+		return resp.Extra, err
+	}
+
+	body, err := call(fclient, "flickr.photos.getInfo", "1", false)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, body, `<photo id="1" />`)
+	_, err = call(fclient, "flickr.photos.delete", "2", true)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, requests, 2)
+
+	files, _ := filepath.Glob(filepath.Join(dir, "flickr.photos.getInfo-*.json"))
+	flickr.Expect(t, len(files), 1)
+	data, _ := ioutil.ReadFile(files[0])
+	flickr.Expect(t, strings.Contains(string(data), "oauth_"), false)
+	flickr.Expect(t, strings.Contains(string(data), "api_sig"), false)
+	server.Close()
+
+	// fresh nonces and other credentials
+	fclient = flickr.NewFlickrClient("otherkey", "othersecret", flickr.WithTransport(New(dir, Replay)))
+	body, err = call(fclient, "flickr.photos.getInfo", "1", false)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, body, `<photo id="1" />`)
+	body, err = call(fclient, "flickr.photos.delete", "2", true)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, body, `<photo id="2" />`)
+
+	_, err = call(fclient, "flickr.photos.getInfo", "3", false)
+	flickr.Expect(t, err != nil, true)
+	flickr.Expect(t, strings.Contains(err.Error(), "no recording for GET"), true)
+}
+
+func TestRecordRedact(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<rsp stat="ok" />`)
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	dir := t.TempDir()
+
+	rec := New(dir, Record)
+	rec.Transport = flickr.RewriteTransport{URL: u}
+	rec.Redact = []string{"title"}
+	fclient := flickr.GetTestClient()
+	fclient.HTTPClient = &http.Client{Transport: rec}
+	fclient.ApiKey = "secret-key"
+
+	fclient.Init()
+	fclient.Args.Set("method", "flickr.blogs.postPhoto")
+	fclient.Args.Set("photo_id", "1")
+	fclient.Args.Set("auth_token", "secret-token")
+	fclient.Args.Set("blog_password", "secret-password")
+	fclient.Args.Set("title", "secret-title")
+	fclient.OAuthSign()
+	err := flickr.DoPost(fclient, &flickr.BasicResponse{})
+	flickr.Expect(t, err, nil)
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	flickr.Expect(t, len(files), 1)
+	data, _ := ioutil.ReadFile(files[0])
+	for _, secret := range []string{"auth_token", "secret-token", "blog_password", "secret-password", "secret-title", "secret-key"} {
+		flickr.Expect(t, strings.Contains(string(data), secret), false)
+	}
+	flickr.Expect(t, strings.Contains(string(data), "photo_id=1"), true)
+}
+
+func TestRecordTokenExchange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("method") {
+		case "flickr.auth.oauth.getAccessToken":
+			fmt.Fprint(w, `<rsp stat="ok"><auth><access_token oauth_token="legacy-access" oauth_token_secret="legacy-secret" /></auth></rsp>`)
+		case "flickr.auth.oauth.checkToken":
+			fmt.Fprint(w, `<rsp stat="ok"><oauth><token>checked-token</token><perms>read</perms></oauth></rsp>`)
+		default:
+			fmt.Fprint(w, "fullname=Jamal%20Fanaian&oauth_token=flow-access&oauth_token_secret=flow-secret&user_nsid=21207597%40N07")
+		}
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	dir := t.TempDir()
+
+	rec := New(dir, Record)
+	rec.Transport = flickr.RewriteTransport{URL: u}
+	fclient := flickr.NewFlickrClient("apikey", "apisecret", flickr.WithTransport(rec))
+
+	tok, err := flickr.GetAccessToken(fclient, &flickr.RequestToken{OauthToken: "req", OauthTokenSecret: "reqsecret"}, "verifier")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, tok.OAuthTokenSecret, "flow-secret")
+	tok, err = oauth.ExchangeLegacyToken(fclient, "legacy-token")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, tok.OAuthTokenSecret, "legacy-secret")
+	check, err := oauth.CheckToken(fclient, "checked-token")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, check.OAuth.Token, "checked-token")
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	flickr.Expect(t, len(files), 3)
+	for _, file := range files {
+		data, _ := ioutil.ReadFile(file)
+		for _, secret := range []string{"flow-access", "flow-secret", "legacy-access", "legacy-secret", "checked-token"} {
+			flickr.Expect(t, strings.Contains(string(data), secret), false)
+		}
+	}
+
+	// replayed tokens are the placeholder
+	fclient = flickr.NewFlickrClient("apikey", "apisecret", flickr.WithTransport(New(dir, Replay)))
+	tok, err = oauth.ExchangeLegacyToken(fclient, "legacy-token")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, tok.OAuthToken, "REDACTED")
+	flickr.Expect(t, tok.OAuthTokenSecret, "REDACTED")
+}
+
+func TestScrubBody(t *testing.T) {
+	for body, expected := range map[string]string{
+		"oauth_token=a&oauth_token_secret=b&user_nsid=1":                                     "oauth_token=REDACTED&oauth_token_secret=REDACTED&user_nsid=1",
+		`{"auth":{"access_token":{"oauth_token":"a","oauth_token_secret":"b"}},"stat":"ok"}`: `{"auth":{"access_token":{"oauth_token":"REDACTED","oauth_token_secret":"REDACTED"}},"stat":"ok"}`,
+		`{"oauth":{"token":{"_content":"a"},"perms":{"_content":"read"}},"stat":"ok"}`:       `{"oauth":{"token":{"_content":"REDACTED"},"perms":{"_content":"read"}},"stat":"ok"}`,
+		`<rsp stat="ok"><photo id="1" /></rsp>`:                                              `<rsp stat="ok"><photo id="1" /></rsp>`,
+	} {
+		flickr.Expect(t, scrubBody(body), expected)
+	}
+}
+
+func TestRequestKey(t *testing.T) {
+	req, _ := http.NewRequest("GET", "https://api.flickr.com/services/rest?method=flickr.test.echo&b=2&a=1&api_key=k&api_sig=s&oauth_nonce=n", nil)
+	key, method, err := requestKey(req, nil)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, key, "GET https://api.flickr.com/services/rest?a=1&b=2&method=flickr.test.echo")
+	flickr.Expect(t, method, "flickr.test.echo")
+
+	req, _ = http.NewRequest("POST", "https://api.flickr.com/services/rest", strings.NewReader("method=flickr.test.echo&oauth_token=t&a=1"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	key, _, err = requestKey(req, nil)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, key, "POST https://api.flickr.com/services/rest?a=1&method=flickr.test.echo")
+	body, _ := ioutil.ReadAll(req.Body)
+	flickr.Expect(t, string(body), "method=flickr.test.echo&oauth_token=t&a=1")
+
+	flickr.Expect(t, strings.HasPrefix(fileName(key, ""), "request-"), true)
+	flickr.Expect(t, fileName(key, "flickr.test.echo") != fileName("GET "+key, "flickr.test.echo"), true)
+}
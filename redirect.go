@@ -0,0 +1,87 @@
+package flickr
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// Number of redirects a GET request follows before failing
+const maxRedirects = 10
+
+// Tell whether res redirects the request to another location
+func isRedirect(res *http.Response) bool {
+	switch res.StatusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+// Tell whether a redirect to loc may be followed: signed requests are only sent to
+// Flickr hosts over TLS
+func isFlickrEndpoint(loc *url.URL) bool {
+	host := loc.Hostname()
+	return loc.Scheme == "https" && (host == "flickr.com" || strings.HasSuffix(host, ".flickr.com"))
+}
+
+// Return the endpoint the redirect res points to, without the query: the request is
+// built again from client Args
+func redirectEndpoint(res *http.Response) (string, error) {
+	loc, err := res.Location()
+	if err != nil {
+		return "", flickErr.NewError(flickErr.RedirectError, fmt.Sprintf("status %d: %v", res.StatusCode, err))
+	}
+	loc.RawQuery = ""
+	loc.Fragment = ""
+	if !isFlickrEndpoint(loc) {
+		return "", flickErr.NewError(flickErr.RedirectError,
+			fmt.Sprintf("status %d to %s, only https flickr.com endpoints are followed", res.StatusCode, loc))
+	}
+	return loc.String(), nil
+}
+
+// Send the request built by newRequest, handling redirects here rather than in the
+// http.Client, which would replay the signature meant for the old endpoint and drop
+// the body of POST requests. A redirected GET request gets the new location as
+// client.EndpointUrl and is signed again and sent there, as long as the location is
+// an https URL on a flickr.com host. A redirected POST request, or one redirected
+// elsewhere, fails with a RedirectError: its body can't be signed again, and signed
+// requests are not handed to other hosts.
+func sendFollowingRedirects(client *FlickrClient, verb string, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	for redirects := 0; ; redirects++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+		res, err := sendRequest(client, req)
+		if err != nil || !isRedirect(res) {
+			return res, err
+		}
+		res.Body.Close()
+
+		endpoint, err := redirectEndpoint(res)
+		if err != nil {
+			return nil, err
+		}
+		if verb != "GET" {
+			return nil, flickErr.NewError(flickErr.RedirectError,
+				fmt.Sprintf("%s to %s (status %d), the signed body can't be sent again", verb, endpoint, res.StatusCode))
+		}
+		if redirects >= maxRedirects {
+			return nil, flickErr.NewError(flickErr.RedirectError,
+				fmt.Sprintf("stopped after %d redirects, last to %s", maxRedirects, endpoint))
+		}
+		client.EndpointUrl = endpoint
+		client.resign(verb)
+	}
+}
+
+// Keep the redirect responses instead of following them, see sendFollowingRedirects
+func noFollow(*http.Request, []*http.Request) error {
+	return http.ErrUseLastResponse
+}
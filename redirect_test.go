@@ -0,0 +1,75 @@
+package flickr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// Return a client whose endpoint redirects with status to a second handler reached
+// through location, and the signatures received by each handler
+func redirectServer(status int, location string) (*httptest.Server, *FlickrClient, map[string][]string) {
+	signatures := map[string][]string{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseMultipartForm(1 << 20)
+		signatures["old"] = append(signatures["old"], r.FormValue("oauth_signature"))
+		http.Redirect(w, r, location+"?"+r.URL.RawQuery, status)
+	})
+	mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseMultipartForm(1 << 20)
+		signatures["new"] = append(signatures["new"], r.FormValue("oauth_signature"))
+		fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`)
+	})
+	server, fclient := FlickrMockHandler(mux)
+	fclient.OAuthToken = "token"
+	fclient.OAuthTokenSecret = "secret"
+	fclient.Init()
+	fclient.EndpointUrl = "https://api.flickr.com/old"
+	fclient.Args.Set("method", "flickr.test.null")
+	fclient.OAuthSign()
+	return server, fclient, signatures
+}
+
+func TestRedirectGet(t *testing.T) {
+	server, fclient, signatures := redirectServer(http.StatusFound, "https://www.flickr.com/new")
+	defer server.Close()
+
+	err := DoGet(fclient, &BasicResponse{})
+	Expect(t, err, nil)
+	Expect(t, len(signatures["old"]), 1)
+	Expect(t, len(signatures["new"]), 1)
+	Expect(t, fclient.EndpointUrl, "https://www.flickr.com/new")
+	// signed again for the new endpoint
+	Expect(t, signatures["new"][0] != signatures["old"][0], true)
+	Expect(t, signatures["new"][0], fclient.Args.Get("oauth_signature"))
+}
+
+func TestRedirectPost(t *testing.T) {
+	server, fclient, signatures := redirectServer(http.StatusMovedPermanently, "https://www.flickr.com/new")
+	defer server.Close()
+
+	err := DoPost(fclient, &BasicResponse{})
+	ee, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ee.ErrorCode, flickErr.RedirectError)
+	Expect(t, len(signatures["old"]), 1)
+	Expect(t, len(signatures["new"]), 0)
+}
+
+func TestRedirectElsewhere(t *testing.T) {
+	for _, location := range []string{"http://www.flickr.com/new", "https://flickr.com.example.org/new", "/new"} {
+		server, fclient, signatures := redirectServer(http.StatusFound, location)
+
+		err := DoGet(fclient, &BasicResponse{})
+		ee, ok := err.(*flickErr.Error)
+		Expect(t, ok, true)
+		Expect(t, ee.ErrorCode, flickErr.RedirectError)
+		Expect(t, len(signatures["new"]), 0)
+		Expect(t, fclient.EndpointUrl, "https://api.flickr.com/old")
+		server.Close()
+	}
+}
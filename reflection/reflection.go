@@ -0,0 +1,63 @@
+// Package implementing methods: flickr.reflection.*
+package reflection
+
+import (
+	"gopkg.in/masci/flickr.v2"
+)
+
+type Argument struct {
+	Name        string `xml:"name,attr"`
+	Optional    bool   `xml:"optional,attr"`
+	Description string `xml:",chardata"`
+}
+
+type MethodError struct {
+	Code        int    `xml:"code,attr"`
+	Message     string `xml:"message,attr"`
+	Description string `xml:",chardata"`
+}
+
+type MethodInfoResponse struct {
+	flickr.BasicResponse
+	Method struct {
+		Name          string `xml:"name,attr"`
+		NeedsLogin    bool   `xml:"needslogin,attr"`
+		NeedsSigning  bool   `xml:"needssigning,attr"`
+		RequiredPerms int    `xml:"requiredperms,attr"`
+		Description   string `xml:"description"`
+		Response      string `xml:"response"`
+		Explanation   string `xml:"explanation"`
+	} `xml:"method"`
+	Arguments []Argument    `xml:"arguments>argument"`
+	Errors    []MethodError `xml:"errors>error"`
+}
+
+type MethodsResponse struct {
+	flickr.BasicResponse
+	Methods []string `xml:"methods>method"`
+}
+
+// Returns information for a given flickr API method.
+// This method does not require authentication.
+func GetMethodInfo(client *flickr.FlickrClient, methodName string) (*MethodInfoResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.reflection.getMethodInfo")
+	client.Args.Set("method_name", methodName)
+	client.ApiSign()
+
+	response := &MethodInfoResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Returns a list of available flickr API methods.
+// This method does not require authentication.
+func GetMethods(client *flickr.FlickrClient) (*MethodsResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.reflection.getMethods")
+	client.ApiSign()
+
+	response := &MethodsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
@@ -0,0 +1,58 @@
+package reflection
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+func TestGetMethodInfo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <method name="flickr.photos.getInfo" needslogin="0" needssigning="0" requiredperms="0">
+    <description>Get information about a photo.</description>
+  </method>
+  <arguments>
+    <argument name="api_key" optional="0">Your API application key.</argument>
+    <argument name="photo_id" optional="0">The id of the photo to get information for.</argument>
+    <argument name="secret" optional="1">The secret for the photo.</argument>
+  </arguments>
+  <errors>
+    <error code="1" message="Photo not found">The photo id was either invalid or was for a photo not viewable by the calling user.</error>
+  </errors>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetMethodInfo(fclient, "flickr.photos.getInfo")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.reflection.getMethodInfo")
+	flickr.Expect(t, fclient.Args.Get("method_name"), "flickr.photos.getInfo")
+	flickr.Expect(t, resp.Method.Name, "flickr.photos.getInfo")
+	flickr.Expect(t, resp.Method.NeedsLogin, false)
+	flickr.Expect(t, len(resp.Arguments), 3)
+	flickr.Expect(t, resp.Arguments[1].Name, "photo_id")
+	flickr.Expect(t, resp.Arguments[1].Optional, false)
+	flickr.Expect(t, resp.Arguments[2].Optional, true)
+	flickr.Expect(t, resp.Errors[0].Code, 1)
+	flickr.Expect(t, resp.Errors[0].Message, "Photo not found")
+}
+
+func TestGetMethods(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <methods>
+    <method>flickr.blogs.getList</method>
+    <method>flickr.blogs.postPhoto</method>
+  </methods>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetMethods(fclient)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(resp.Methods), 2)
+	flickr.Expect(t, resp.Methods[1], "flickr.blogs.postPhoto")
+}
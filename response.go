@@ -1,9 +1,15 @@
 package flickr
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/xml"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
+	"unicode/utf8"
 
 	flickErr "gopkg.in/masci/flickr.v2/error"
 )
@@ -21,7 +27,8 @@ type FlickrResponse interface {
 // Base type representing responses from Flickr API
 type BasicResponse struct {
 	XMLName xml.Name `xml:"rsp"`
-	// Status might contain "fail" or "ok" strings
+	// Status might contain "fail" or "ok" strings, any other value is a failure too,
+	// reported with UnexpectedStatusError code
 	Status string `xml:"stat,attr"`
 	// Flickr API error detail
 	Error struct {
@@ -29,6 +36,18 @@ type BasicResponse struct {
 		Message string `xml:"msg,attr"`
 	} `xml:"err"`
 	Extra string `xml:",innerxml"`
+	// Attributes of the rsp element other than stat, undocumented or recently added ones
+	// included
+	RootAttrs []xml.Attr `xml:",any,attr"`
+}
+
+// Return all the attributes of the rsp element, stat included, by name
+func (r *BasicResponse) Attributes() map[string]string {
+	ret := map[string]string{"stat": r.Status}
+	for _, attr := range r.RootAttrs {
+		ret[attr.Name.Local] = attr.Value
+	}
+	return ret
 }
 
 // Return whether a response contains errors
@@ -65,16 +84,154 @@ func (r *BasicResponse) SetErrorMsg(msg string) {
 	r.Error.Message = msg
 }
 
-// Given an http.Response retrieved from Flickr, unmarshal results
-// into a FlickrResponse struct.
-func parseApiResponse(res *http.Response, r FlickrResponse) error {
+// Read the whole body of an http.Response retrieved from Flickr, decompressing
+// it if needed, and close it. If maxBytes is positive, bodies (decompressed) larger
+// than maxBytes are not read further and an error with ResponseTooLargeError code is returned.
+// During maintenance windows Flickr replies with HTTP 503 and an HTML page, in that
+// case an error with MaintenanceError code is returned without reading the body.
+func readResponseBody(res *http.Response, maxBytes int64) ([]byte, error) {
 	defer res.Body.Close()
-	responseBody, err := ioutil.ReadAll(res.Body)
+
+	body, err := responseReader(res)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	if maxBytes <= 0 {
+		return ioutil.ReadAll(body)
+	}
+
+	// read one byte more than allowed to tell a body exactly maxBytes long
+	// from a longer one
+	data, err := ioutil.ReadAll(io.LimitReader(body, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, flickErr.NewError(flickErr.ResponseTooLargeError, fmt.Sprintf("more than %d bytes", maxBytes))
+	}
+	return data, nil
+}
+
+// Return a reader of the body of an http.Response retrieved from Flickr, decompressing
+// it if needed, or an error with MaintenanceError code for HTTP 503 responses.
+// Closing the returned reader, which closes the response body too, is up to the caller.
+func responseReader(res *http.Response) (io.ReadCloser, error) {
+	if res.StatusCode == http.StatusServiceUnavailable {
+		msg := res.Status
+		if retryAfter := res.Header.Get("Retry-After"); retryAfter != "" {
+			msg += ", retry after " + retryAfter
+		}
+		return nil, flickErr.NewError(flickErr.MaintenanceError, msg)
+	}
+
+	if res.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return &gzipBody{Reader: gz, body: res.Body}, nil
+	}
+	return res.Body, nil
+}
+
+// Decompressed response body, closing both the gzip reader and the body
+type gzipBody struct {
+	*gzip.Reader
+	body io.Closer
+}
+
+func (b *gzipBody) Close() error {
+	err := b.Reader.Close()
+	if errBody := b.body.Close(); err == nil {
+		err = errBody
+	}
+	return err
+}
+
+// Number of leading bytes of a body kept in the message of AuthRejectedError errors
+const rejectedBodyBytes = 256
+
+// Tell whether Flickr refused the request at the HTTP level, because of the consumer
+// key or the OAuth signature, instead of replying with an API error
+func isAuthRejected(res *http.Response) bool {
+	return res.StatusCode == http.StatusUnauthorized || res.StatusCode == http.StatusForbidden
+}
+
+// Fill r with the raw body of a response refused at the HTTP level, as done for raw text
+// OAuth errors, and return an error with AuthRejectedError code holding the HTTP status
+// and the beginning of the body
+func authRejected(res *http.Response, body []byte, r FlickrResponse) error {
+	r.SetErrorStatus(true)
+	r.SetErrorCode(-1)
+	r.SetErrorMsg(string(body))
+
+	msg := res.Status
+	if len(body) > rejectedBodyBytes {
+		body = body[:rejectedBodyBytes]
+	}
+	if text := strings.TrimSpace(string(body)); text != "" {
+		msg += ", " + text
+	}
+	return flickErr.NewError(flickErr.AuthRejectedError, msg)
+}
+
+// Unmarshal in r the body read from res, see decodeApiBody for lenient. Responses with
+// HTTP 401 or 403 status are not decoded, an error with AuthRejectedError code is
+// returned instead: they are about the api key or the OAuth signature, and hold HTML or
+// raw text rather than an API response.
+func decodeResponse(res *http.Response, body []byte, r FlickrResponse, lenient bool) error {
+	if isAuthRejected(res) {
+		return authRejected(res, body, r)
+	}
+	return decodeApiBody(body, r, lenient)
+}
+
+// Given an http.Response retrieved from Flickr, unmarshal results
+// into a FlickrResponse struct. The body size is not limited. See decodeResponse
+// for lenient and HTTP authentication failures.
+func parseApiResponse(res *http.Response, r FlickrResponse, lenient bool) error {
+	responseBody, err := readResponseBody(res, 0)
 	if err != nil {
 		return err
 	}
 
-	err = xml.Unmarshal(responseBody, r)
+	return decodeResponse(res, responseBody, r, lenient)
+}
+
+// Unmarshal the body of a response retrieved from Flickr into a FlickrResponse struct.
+func parseApiBody(responseBody []byte, r FlickrResponse) error {
+	return decodeApiBody(responseBody, r, false)
+}
+
+// Replace invalid UTF-8 sequences and characters not allowed in XML with U+FFFD
+func sanitizeXML(body []byte) []byte {
+	return []byte(strings.Map(func(c rune) rune {
+		if c == '\t' || c == '\n' || c == '\r' || c >= 0x20 && c <= 0xD7FF ||
+			c >= 0xE000 && c <= 0xFFFD || c >= 0x10000 && c <= 0x10FFFF {
+			return c
+		}
+		return utf8.RuneError
+	}, strings.ToValidUTF8(string(body), string(utf8.RuneError))))
+}
+
+// Same as parseApiBody. If lenient is set, bytes that would make unmarshalling fail
+// (invalid UTF-8, control characters) are replaced with U+FFFD beforehand, and the
+// charset declared by the body is ignored, the sanitized body being UTF-8.
+func decodeApiBody(responseBody []byte, r FlickrResponse, lenient bool) error {
+	data := responseBody
+	if lenient {
+		data = sanitizeXML(responseBody)
+	}
+	d := xml.NewDecoder(bytes.NewReader(data))
+	if lenient {
+		d.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+			return input, nil
+		}
+	}
+
+	err := d.Decode(r)
 	if err != nil {
 		// In case of OAuth errors (signature, parameters, etc) Flicker does not
 		// return a REST response but raw text (!), so the unmarshalling could fail.
@@ -86,8 +243,21 @@ func parseApiResponse(res *http.Response, r FlickrResponse) error {
 	}
 
 	if r.HasErrors() {
-		return flickErr.NewError(flickErr.ApiError, r.ErrorMsg())
+		return statusError(r)
 	}
 
 	return nil
 }
+
+// Return the error of a response whose stat is not "ok": an error with ApiError code
+// holding the message sent by Flickr for "fail", one with UnexpectedStatusError code
+// holding the stat observed otherwise (missing stat included), so that an unknown
+// value can't be taken for a success nor for a regular failure
+func statusError(r FlickrResponse) error {
+	if a, ok := r.(interface{ Attributes() map[string]string }); ok {
+		if stat := a.Attributes()["stat"]; stat != "fail" {
+			return flickErr.NewError(flickErr.UnexpectedStatusError, fmt.Sprintf("stat=%q", stat))
+		}
+	}
+	return flickErr.NewError(flickErr.ApiError, r.ErrorMsg())
+}
@@ -1,8 +1,13 @@
 package flickr
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/xml"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 
 	flickErr "gopkg.in/masci/flickr.v2/error"
@@ -72,7 +77,7 @@ func TestParseResponse(t *testing.T) {
 	response := &http.Response{}
 	response.Body = NewFakeBody(bodyStr)
 
-	err := parseApiResponse(response, flickrResp)
+	err := parseApiResponse(response, flickrResp, false)
 
 	Expect(t, err, nil)
 	Expect(t, flickrResp.Foo, "Foo!")
@@ -80,19 +85,40 @@ func TestParseResponse(t *testing.T) {
 	response = &http.Response{}
 	response.Body = NewFakeBody("a_non_rest_format_error")
 
-	err = parseApiResponse(response, flickrResp)
+	err = parseApiResponse(response, flickrResp, false)
 	ferr, ok := err.(*flickErr.Error)
 	Expect(t, ok, true)
 	Expect(t, ferr.ErrorCode, 10)
 
 	response = &http.Response{}
 	response.Body = NewFakeBody(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"></rsp>`)
-	err = parseApiResponse(response, flickrResp)
+	err = parseApiResponse(response, flickrResp, false)
 	//ferr, ok := err.(*flickErr.Error)
 	//Expect(t, ok, true)
 	//Expect(t, ferr.ErrorCode, 10)
 }
 
+func TestRootAttributes(t *testing.T) {
+	bodyStr := `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok" served_by="api-42" new_feature="1">
+  <foo>Foo!</foo>
+</rsp>`
+
+	flickrResp := &FooResponse{}
+	err := parseApiBody([]byte(bodyStr), flickrResp)
+	Expect(t, err, nil)
+	Expect(t, flickrResp.Foo, "Foo!")
+	attrs := flickrResp.Attributes()
+	Expect(t, len(attrs), 3)
+	Expect(t, attrs["stat"], "ok")
+	Expect(t, attrs["served_by"], "api-42")
+	Expect(t, attrs["new_feature"], "1")
+
+	flickrResp = &FooResponse{}
+	parseApiBody([]byte(`<rsp stat="ok"></rsp>`), flickrResp)
+	Expect(t, len(flickrResp.Attributes()), 1)
+}
+
 func TestExtra(t *testing.T) {
 	bodyStr := `<?xml version="1.0" encoding="utf-8" ?>
 <rsp stat="ok">
@@ -111,8 +137,192 @@ func TestExtra(t *testing.T) {
 	response := &http.Response{}
 	response.Body = NewFakeBody(bodyStr)
 
-	err := parseApiResponse(response, flickrResp)
+	err := parseApiResponse(response, flickrResp, false)
 
 	Expect(t, err, nil)
 	Expect(t, flickrResp.Extra != "", true)
 }
+
+func TestParseResponseServiceUnavailable(t *testing.T) {
+	response := &http.Response{StatusCode: 503, Status: "503 Service Unavailable", Header: http.Header{}}
+	response.Header.Set("Retry-After", "120")
+	response.Body = NewFakeBody("<html><body>Flickr is having a massage</body></html>")
+
+	err := parseApiResponse(response, &FooResponse{}, false)
+	ferr, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.ErrorCode, flickErr.MaintenanceError)
+	Expect(t, ferr.Message, "Flickr API is currently unavailable: 503 Service Unavailable, retry after 120")
+
+	response = &http.Response{StatusCode: 503, Status: "503 Service Unavailable"}
+	response.Body = NewFakeBody("")
+	err = parseApiResponse(response, &FooResponse{}, false)
+	ferr, ok = err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.Message, "Flickr API is currently unavailable: 503 Service Unavailable")
+}
+
+func TestReadResponseBodyLimit(t *testing.T) {
+	bodyStr := `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`
+	response := &http.Response{StatusCode: 200, Header: http.Header{}}
+	response.Body = NewFakeBody(bodyStr)
+	body, err := readResponseBody(response, int64(len(bodyStr)))
+	Expect(t, err, nil)
+	Expect(t, string(body), bodyStr)
+
+	response.Body = NewFakeBody(bodyStr)
+	_, err = readResponseBody(response, 10)
+	ferr, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.ErrorCode, flickErr.ResponseTooLargeError)
+}
+
+func TestDecodeApiBodyLenient(t *testing.T) {
+	body := []byte("<?xml version=\"1.0\" encoding=\"utf-8\" ?>\n" +
+		"<rsp stat=\"ok\"><photo id=\"1\"><title>Caf\xe9 \x01 ok</title></photo></rsp>")
+	type photoResponse struct {
+		BasicResponse
+		Title string `xml:"photo>title"`
+	}
+
+	r := &photoResponse{}
+	err := decodeApiBody(body, r, false)
+	ferr, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.ErrorCode, flickErr.ApiError)
+	Expect(t, r.ErrorCode(), -1)
+
+	r = &photoResponse{}
+	err = decodeApiBody(body, r, true)
+	Expect(t, err, nil)
+	Expect(t, r.Title, "Caf\uFFFD \uFFFD ok")
+
+	// the declared charset is ignored
+	r = &photoResponse{}
+	err = decodeApiBody([]byte(`<?xml version="1.0" encoding="ISO-8859-1" ?><rsp stat="ok"><photo><title>Duomo</title></photo></rsp>`), r, true)
+	Expect(t, err, nil)
+	Expect(t, r.Title, "Duomo")
+}
+
+func TestLenientDecode(t *testing.T) {
+	fclient := GetTestClient()
+	server, client := FlickrMock(200, "<rsp stat=\"ok\"><photo id=\"1\"><title>Caf\xe9</title></photo></rsp>", "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	err := DoGet(fclient, &BasicResponse{})
+	_, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+
+	fclient.LenientDecode = true
+	r := &BasicResponse{}
+	err = DoGet(fclient, r)
+	Expect(t, err, nil)
+	Expect(t, r.Status, "ok")
+}
+
+func TestUnexpectedStatus(t *testing.T) {
+	for body, stat := range map[string]string{
+		`<rsp stat="error"><foo>Foo!</foo></rsp>`: `stat="error"`,
+		`<rsp><foo>Foo!</foo></rsp>`:              `stat=""`,
+	} {
+		resp := &FooResponse{}
+		err := parseApiBody([]byte(body), resp)
+		Expect(t, resp.HasErrors(), true)
+		ee, ok := err.(*flickErr.Error)
+		Expect(t, ok, true)
+		Expect(t, ee.ErrorCode, flickErr.UnexpectedStatusError)
+		Expect(t, ee.Message, "Flickr returned an unexpected status: "+stat)
+	}
+
+	// regular failures are unchanged
+	err := parseApiBody([]byte(`<rsp stat="fail"><err code="1" msg="not found" /></rsp>`), &FooResponse{})
+	ee, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ee.ErrorCode, flickErr.ApiError)
+
+	fclient := GetTestClient()
+	server, client := FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="error"><foo>Foo!</foo></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	err = DoGetStream(fclient, &BasicResponse{}, func(d *xml.Decoder, start xml.StartElement) error {
+		t.Error("elements of failed responses must not be decoded")
+		return d.Skip()
+	})
+	ee, ok = err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ee.ErrorCode, flickErr.UnexpectedStatusError)
+}
+
+func TestAuthRejected(t *testing.T) {
+	fclient := GetTestClient()
+	server, client := FlickrMock(http.StatusUnauthorized, "oauth_problem=consumer_key_unknown", "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	fclient.Args.Set("method", "flickr.test.login")
+
+	resp := &FooResponse{}
+	err := DoGet(fclient, resp)
+	ee, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ee.ErrorCode, flickErr.AuthRejectedError)
+	Expect(t, ee.Message, "Flickr rejected the credentials of the request: 401 Unauthorized, oauth_problem=consumer_key_unknown")
+	Expect(t, resp.HasErrors(), true)
+	Expect(t, resp.ErrorCode(), -1)
+
+	// HTML pages are not decoded, nor reported whole
+	page := "<html><body>" + strings.Repeat("Forbidden ", 100) + "</body></html>"
+	server, client = FlickrMock(http.StatusForbidden, page, "text/html")
+	defer server.Close()
+	fclient.HTTPClient = client
+	err = DoPost(fclient, &FooResponse{})
+	ee, ok = err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ee.ErrorCode, flickErr.AuthRejectedError)
+	Expect(t, strings.HasPrefix(ee.Message, "Flickr rejected the credentials of the request: 403 Forbidden, <html>"), true)
+	Expect(t, len(ee.Message) < 400, true)
+
+	err = DoGetStream(fclient, &BasicResponse{}, func(d *xml.Decoder, start xml.StartElement) error {
+		return d.Skip()
+	})
+	ee, ok = err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ee.ErrorCode, flickErr.AuthRejectedError)
+
+	// API errors sent along with 200 are unchanged
+	server, client = FlickrMock(http.StatusOK, `<rsp stat="fail"><err code="99" msg="Insufficient permissions" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	err = DoGet(fclient, &FooResponse{})
+	ee, ok = err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ee.ErrorCode, flickErr.ApiError)
+}
+
+// Body recording whether it was closed
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (b *closeRecorder) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestResponseReaderGzipClose(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	gz.Write([]byte(`<rsp stat="ok"></rsp>`))
+	gz.Close()
+
+	body := &closeRecorder{Reader: &buf}
+	response := &http.Response{StatusCode: 200, Header: http.Header{"Content-Encoding": {"gzip"}}, Body: body}
+	reader, err := responseReader(response)
+	Expect(t, err, nil)
+	data, err := ioutil.ReadAll(reader)
+	Expect(t, err, nil)
+	Expect(t, string(data), `<rsp stat="ok"></rsp>`)
+	Expect(t, reader.Close(), nil)
+	Expect(t, body.closed, true)
+}
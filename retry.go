@@ -0,0 +1,126 @@
+package flickr
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// Base of the exponential backoff used when FlickrClient.RetryBackoff is nil
+	DEFAULT_RETRY_DELAY = 500 * time.Millisecond
+	// Longest wait of the exponential backoff used when FlickrClient.RetryBackoff is nil
+	DEFAULT_RETRY_MAX_DELAY = 30 * time.Second
+)
+
+var defaultRetryBackoff = JitterBackoff(DEFAULT_RETRY_DELAY, DEFAULT_RETRY_MAX_DELAY, nil)
+
+// Return a RetryBackoff implementing exponential backoff with full jitter: the wait
+// before attempt is random between 0 and min(max, base*2^attempt), so that clients
+// failing together don't retry together. jitter returns a random number in [0, n),
+// rand.Int63n if nil; set it to a seeded source for reproducible waits.
+func JitterBackoff(base, max time.Duration, jitter func(n int64) int64) func(attempt int) time.Duration {
+	if jitter == nil {
+		jitter = rand.Int63n
+	}
+	return func(attempt int) time.Duration {
+		ceil := base
+		for i := 0; i < attempt && ceil < max; i++ {
+			ceil *= 2
+		}
+		if ceil > max {
+			ceil = max
+		}
+		if ceil <= 0 {
+			return 0
+		}
+		return time.Duration(jitter(int64(ceil)))
+	}
+}
+
+// Return how long to wait before the given retry attempt, starting from 1, after the
+// response res: as told by client.RetryBackoff, but no less than the Retry-After
+// header of 503 and 429 responses
+func (c *FlickrClient) retryDelay(attempt int, res *http.Response) time.Duration {
+	backoff := c.RetryBackoff
+	if backoff == nil {
+		backoff = defaultRetryBackoff
+	}
+	delay := backoff(attempt)
+	if after := retryAfter(res); after > delay {
+		delay = after
+	}
+	return delay
+}
+
+// Return the wait asked by the Retry-After header of a 503 or 429 response, in
+// seconds or as an HTTP date, zero if there's none
+func retryAfter(res *http.Response) time.Duration {
+	if res == nil || (res.StatusCode != http.StatusServiceUnavailable && res.StatusCode != http.StatusTooManyRequests) {
+		return 0
+	}
+	value := res.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
+
+// Tell whether a request sent with verb that ended with res and err is worth sending
+// again: the network failed, Flickr replied with a 5xx status or asked to slow down
+// with a 429. POST requests may change data and may have been applied even when Flickr
+// replied with an error status, so they are sent again only when no response was
+// received. Of the errors, only the transport ones returned by http.Client.Do, always
+// a *url.Error, are retried: the others (dry run, redirects refused) come before sending
+// anything or after a response.
+func shouldRetry(verb string, res *http.Response, err error) bool {
+	if err != nil {
+		var transportErr *url.Error
+		return errors.As(err, &transportErr)
+	}
+	if verb == "POST" {
+		return false
+	}
+	return res.StatusCode >= http.StatusInternalServerError || res.StatusCode == http.StatusTooManyRequests
+}
+
+// Send the request built by newRequest, retrying up to client.MaxRetries times as told
+// by shouldRetry. Before each retry the client is signed again for verb, so that every
+// attempt carries a fresh nonce and timestamp: Flickr refuses replayed signatures.
+// newRequest is called once per attempt, and once per redirect followed, and must
+// build the request from client Args.
+// The outcome of the last attempt is returned.
+func sendWithRetry(client *FlickrClient, verb string, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	var last *http.Response
+	// requests that can't be built are not retried, url.Parse errors are *url.Error too
+	built := true
+	build := func() (*http.Request, error) {
+		req, err := newRequest()
+		built = err == nil
+		return req, err
+	}
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			time.Sleep(client.retryDelay(attempt, last))
+			client.resign(verb)
+		}
+
+		res, err := sendFollowingRedirects(client, verb, build)
+		if attempt >= client.MaxRetries || !built || !shouldRetry(verb, res, err) {
+			return res, err
+		}
+		if res != nil {
+			res.Body.Close()
+		}
+		last = res
+	}
+}
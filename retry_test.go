@@ -0,0 +1,217 @@
+package flickr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// Return a client talking to a server failing the first `failures` requests with
+// status, and the nonces received
+func retryServer(failures, status int) (*httptest.Server, *FlickrClient, *[]string) {
+	nonces := []string{}
+	server, fclient := FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseMultipartForm(1 << 20)
+		nonces = append(nonces, r.FormValue("oauth_nonce"))
+		if len(nonces) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`)
+	}))
+	fclient.OAuthToken = "token"
+	fclient.OAuthTokenSecret = "secret"
+	fclient.RetryBackoff = func(int) time.Duration { return 0 }
+	fclient.Init()
+	fclient.Args.Set("method", "flickr.test.null")
+	fclient.OAuthSign()
+	return server, fclient, &nonces
+}
+
+func TestRetryResigns(t *testing.T) {
+	server, fclient, nonces := retryServer(1, http.StatusInternalServerError)
+	defer server.Close()
+	fclient.MaxRetries = 2
+
+	err := DoGet(fclient, &BasicResponse{})
+	Expect(t, err, nil)
+	Expect(t, len(*nonces), 2)
+	Expect(t, (*nonces)[0] != (*nonces)[1], true)
+	Expect(t, fclient.Args.Get("oauth_nonce"), (*nonces)[1])
+
+	// the write may have been applied, it is not sent again
+	server, fclient, nonces = retryServer(1, http.StatusBadGateway)
+	defer server.Close()
+	fclient.MaxRetries = 1
+
+	err = DoPost(fclient, &BasicResponse{})
+	Expect(t, err != nil, true)
+	Expect(t, len(*nonces), 1)
+}
+
+func TestRetryPostWithoutResponse(t *testing.T) {
+	nonces := []string{}
+	server, fclient := FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseMultipartForm(1 << 20)
+		nonces = append(nonces, r.FormValue("oauth_nonce"))
+		if len(nonces) == 1 {
+			// drop the connection without answering
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`)
+	}))
+	defer server.Close()
+	fclient.OAuthToken = "token"
+	fclient.OAuthTokenSecret = "secret"
+	fclient.RetryBackoff = func(int) time.Duration { return 0 }
+	fclient.MaxRetries = 1
+	fclient.Init()
+	fclient.Args.Set("method", "flickr.test.null")
+	fclient.OAuthSign()
+
+	err := DoPost(fclient, &BasicResponse{})
+	Expect(t, err, nil)
+	Expect(t, len(nonces), 2)
+	Expect(t, nonces[0] != "", true)
+	Expect(t, nonces[0] != nonces[1], true)
+}
+
+func TestRetryRedirect(t *testing.T) {
+	// a POST redirect fails after its response was received
+	server, fclient, signatures := redirectServer(http.StatusMovedPermanently, "https://www.flickr.com/new")
+	fclient.RetryBackoff = func(int) time.Duration { return 0 }
+	fclient.MaxRetries = 3
+	err := DoPost(fclient, &BasicResponse{})
+	ee, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ee.ErrorCode, flickErr.RedirectError)
+	Expect(t, len(signatures["old"]), 1)
+	server.Close()
+
+	// so does a GET redirected to another host
+	server, fclient, signatures = redirectServer(http.StatusFound, "https://example.org/new")
+	fclient.RetryBackoff = func(int) time.Duration { return 0 }
+	fclient.MaxRetries = 3
+	err = DoGet(fclient, &BasicResponse{})
+	Expect(t, err != nil, true)
+	Expect(t, len(signatures["old"]), 1)
+	server.Close()
+}
+
+func TestRetryInvalidRequest(t *testing.T) {
+	fclient := GetTestClient()
+	waits := 0
+	fclient.RetryBackoff = func(int) time.Duration {
+		waits++
+		return 0
+	}
+	fclient.MaxRetries = 3
+	fclient.Init()
+	fclient.EndpointUrl = "://invalid"
+	err := DoGet(fclient, &BasicResponse{})
+	Expect(t, err != nil, true)
+	Expect(t, waits, 0)
+}
+
+func TestRetryExhausted(t *testing.T) {
+	server, fclient, nonces := retryServer(5, http.StatusServiceUnavailable)
+	defer server.Close()
+	fclient.MaxRetries = 2
+	var attempts []int
+	fclient.RetryBackoff = func(attempt int) time.Duration {
+		attempts = append(attempts, attempt)
+		return 0
+	}
+
+	err := DoGet(fclient, &BasicResponse{})
+	ee, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ee.ErrorCode, flickErr.MaintenanceError)
+	Expect(t, len(*nonces), 3)
+	Expect(t, len(attempts), 2)
+	Expect(t, attempts[1], 2)
+}
+
+func TestNoRetry(t *testing.T) {
+	server, fclient, nonces := retryServer(1, http.StatusInternalServerError)
+	defer server.Close()
+
+	// disabled by default
+	DoGet(fclient, &BasicResponse{})
+	Expect(t, len(*nonces), 1)
+
+	// client errors are not retried
+	server, fclient, nonces = retryServer(1, http.StatusNotFound)
+	defer server.Close()
+	fclient.MaxRetries = 3
+	DoGet(fclient, &BasicResponse{})
+	Expect(t, len(*nonces), 1)
+
+	fclient.DryRun = true
+	err := DoGet(fclient, &BasicResponse{})
+	_, ok := err.(*DryRunError)
+	Expect(t, ok, true)
+	Expect(t, len(*nonces), 1)
+}
+
+func TestJitterBackoff(t *testing.T) {
+	var bounds []int64
+	backoff := JitterBackoff(100*time.Millisecond, time.Second, func(n int64) int64 {
+		bounds = append(bounds, n)
+		return n - 1
+	})
+	Expect(t, backoff(1), 200*time.Millisecond-1)
+	Expect(t, backoff(3), 800*time.Millisecond-1)
+	Expect(t, backoff(4), time.Second-1)
+	Expect(t, backoff(100), time.Second-1)
+	Expect(t, len(bounds), 4)
+	Expect(t, bounds[3], int64(time.Second))
+
+	backoff = JitterBackoff(100*time.Millisecond, time.Second, nil)
+	for attempt := 1; attempt < 10; attempt++ {
+		d := backoff(attempt)
+		Expect(t, d >= 0 && d < time.Second, true)
+	}
+	Expect(t, JitterBackoff(0, time.Second, nil)(1), time.Duration(0))
+}
+
+func TestRetryAfter(t *testing.T) {
+	fclient := GetTestClient()
+	fclient.RetryBackoff = func(int) time.Duration { return time.Second }
+	res := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+
+	Expect(t, fclient.retryDelay(1, nil), time.Second)
+	Expect(t, fclient.retryDelay(1, res), time.Second)
+	res.Header.Set("Retry-After", "120")
+	Expect(t, fclient.retryDelay(1, res), 120*time.Second)
+	res.StatusCode = http.StatusTooManyRequests
+	Expect(t, fclient.retryDelay(1, res), 120*time.Second)
+
+	// the backoff wins when longer
+	fclient.RetryBackoff = func(int) time.Duration { return time.Hour }
+	Expect(t, fclient.retryDelay(1, res), time.Hour)
+
+	fclient.RetryBackoff = func(int) time.Duration { return 0 }
+	res.Header.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	d := fclient.retryDelay(1, res)
+	Expect(t, d > 59*time.Minute && d <= time.Hour, true)
+	res.Header.Set("Retry-After", "soon")
+	Expect(t, fclient.retryDelay(1, res), time.Duration(0))
+
+	// only for 503 and 429
+	res.StatusCode = http.StatusInternalServerError
+	res.Header.Set("Retry-After", "120")
+	Expect(t, fclient.retryDelay(1, res), time.Duration(0))
+
+	server, fclient, nonces := retryServer(1, http.StatusTooManyRequests)
+	defer server.Close()
+	fclient.MaxRetries = 1
+	Expect(t, DoGet(fclient, &BasicResponse{}), nil)
+	Expect(t, len(*nonces), 2)
+}
@@ -0,0 +1,80 @@
+package flickr
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// OAuth signature methods, see FlickrClient.SignatureMethod
+const (
+	SIGNATURE_HMAC_SHA1 = "HMAC-SHA1"
+	SIGNATURE_PLAINTEXT = "PLAINTEXT"
+)
+
+// Compute request signatures from their base strings, so that the api secret can be
+// kept out of the process, in a KMS or behind a signing service for instance
+type Signer interface {
+	// Return the oauth_signature of an OAuth base string, as built by RFC 5849 from
+	// the HTTP verb, the endpoint and the args. tokenSecret is the secret of the
+	// token signing the request, empty when there's none yet.
+	SignOAuth(base, tokenSecret string) string
+	// Return the api_sig of the args, sorted by key and concatenated as key+value,
+	// the api secret not included
+	SignAPI(base string) string
+}
+
+// The Signer used when FlickrClient.Signer is nil: HMAC-SHA1 for OAuth signatures,
+// MD5 for api ones, both keyed with ApiSecret
+type SecretSigner struct {
+	ApiSecret string
+}
+
+func (s SecretSigner) SignOAuth(base, tokenSecret string) string {
+	key := fmt.Sprintf("%s&%s", url.QueryEscape(s.ApiSecret), url.QueryEscape(tokenSecret))
+	mac := hmac.New(sha1.New, []byte(key))
+	mac.Write([]byte(base))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s SecretSigner) SignAPI(base string) string {
+	return fmt.Sprintf("%x", md5.Sum([]byte(s.ApiSecret+base)))
+}
+
+// Sign the requests of the client with s instead of its ApiSecret, which can be left
+// empty
+func WithSigner(s Signer) ClientOption {
+	return func(c *FlickrClient) {
+		c.Signer = s
+	}
+}
+
+// Return the OAuth signature method of the client, SIGNATURE_HMAC_SHA1 unless
+// SignatureMethod is set
+func (c *FlickrClient) signatureMethod() string {
+	if c.SignatureMethod != "" {
+		return c.SignatureMethod
+	}
+	return SIGNATURE_HMAC_SHA1
+}
+
+// Return the PLAINTEXT signature of RFC 5849: both secrets percent encoded and
+// joined by "&"
+func plaintextSignature(apiSecret, tokenSecret string) string {
+	var encode = func(s string) string {
+		return strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+	}
+	return encode(apiSecret) + "&" + encode(tokenSecret)
+}
+
+// Return the Signer of the client, SecretSigner unless Signer is set
+func (c *FlickrClient) signer() Signer {
+	if c.Signer != nil {
+		return c.Signer
+	}
+	return SecretSigner{ApiSecret: c.ApiSecret}
+}
@@ -0,0 +1,78 @@
+package flickr
+
+import (
+	"testing"
+)
+
+// Signer recording the base strings it is asked to sign
+type recordingSigner struct {
+	oauthBase   string
+	tokenSecret string
+	apiBase     string
+}
+
+func (s *recordingSigner) SignOAuth(base, tokenSecret string) string {
+	s.oauthBase, s.tokenSecret = base, tokenSecret
+	return "remote-oauth"
+}
+
+func (s *recordingSigner) SignAPI(base string) string {
+	s.apiBase = base
+	return "remote-api"
+}
+
+func TestSecretSigner(t *testing.T) {
+	// same vectors as TestSign and TestApiSign
+	c := GetTestClient()
+	s := SecretSigner{ApiSecret: c.ApiSecret}
+	Expect(t, s.SignOAuth(c.getSigningBaseString(), "token12345secret"), "dXyfrCetFSTpzD3djSrkFhj0MIQ=")
+	Expect(t, s.SignOAuth(c.getSigningBaseString(), ""), "0fhNGlzpFNAsTme/hDfUb5HPB5U=")
+	Expect(t, SecretSigner{ApiSecret: "SECRET"}.SignAPI("api_key1234567890bar2baz3foo1"), "0a55ae496d1db08f39deb5d894ae3849")
+}
+
+func TestWithSigner(t *testing.T) {
+	s := &recordingSigner{}
+	client := NewFlickrClient("1234567890", "", WithSigner(s))
+	client.OAuthToken = "token"
+	client.OAuthTokenSecret = "tokensecret"
+	client.Args.Set("foo", "1")
+
+	client.OAuthSign()
+	Expect(t, client.Args.Get("oauth_signature"), "remote-oauth")
+	Expect(t, s.tokenSecret, "tokensecret")
+	client.Args.Del("oauth_signature")
+	Expect(t, s.oauthBase, client.getSigningBaseString())
+
+	client.ClearArgs()
+	client.Args.Set("foo", "1")
+	client.Args.Set("bar", "2")
+	client.ApiSign()
+	Expect(t, client.Args.Get("api_sig"), "remote-api")
+	Expect(t, s.apiBase, "api_key1234567890bar2foo1")
+
+	// no api secret needed
+	signed, err := client.BuildSignedURL("flickr.test.login", nil)
+	Expect(t, err, nil)
+	Expect(t, parseSignedURL(t, signed).Args.Get("oauth_signature"), "remote-oauth")
+}
+
+func TestPlaintextSignature(t *testing.T) {
+	// RFC 5849 section 3.4.4
+	Expect(t, plaintextSignature("ja893SD9", ""), "ja893SD9&")
+	Expect(t, plaintextSignature("ja893SD9", "dh893hdasih9"), "ja893SD9&dh893hdasih9")
+	Expect(t, plaintextSignature("a b&c", "d+e"), "a%20b%26c&d%2Be")
+
+	client := NewFlickrClient("1234567890", "ja893SD9", WithSigner(&recordingSigner{}))
+	client.SignatureMethod = SIGNATURE_PLAINTEXT
+	client.OAuthToken = "token"
+	client.OAuthTokenSecret = "dh893hdasih9"
+	client.OAuthSign()
+	Expect(t, client.Args.Get("oauth_signature_method"), "PLAINTEXT")
+	Expect(t, client.Args.Get("oauth_signature"), "ja893SD9&dh893hdasih9")
+
+	// HMAC-SHA1 by default
+	client.SignatureMethod = ""
+	client.OAuthSign()
+	Expect(t, client.Args.Get("oauth_signature_method"), "HMAC-SHA1")
+	Expect(t, client.Args.Get("oauth_signature"), "remote-oauth")
+}
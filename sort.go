@@ -0,0 +1,51 @@
+package flickr
+
+import (
+	"fmt"
+	"strings"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// Order of the results of list methods accepting a "sort" argument
+type SortOrder string
+
+const (
+	// Let Flickr use its default, the argument is not sent
+	NoSortSpecified         SortOrder = ""
+	SortDatePostedAsc       SortOrder = "date-posted-asc"
+	SortDatePostedDesc      SortOrder = "date-posted-desc"
+	SortDateTakenAsc        SortOrder = "date-taken-asc"
+	SortDateTakenDesc       SortOrder = "date-taken-desc"
+	SortInterestingnessAsc  SortOrder = "interestingness-asc"
+	SortInterestingnessDesc SortOrder = "interestingness-desc"
+	SortRelevance           SortOrder = "relevance"
+)
+
+// All the sort orders accepted by Flickr
+var SortOrders = []SortOrder{
+	SortDatePostedAsc,
+	SortDatePostedDesc,
+	SortDateTakenAsc,
+	SortDateTakenDesc,
+	SortInterestingnessAsc,
+	SortInterestingnessDesc,
+	SortRelevance,
+}
+
+// Return an ArgumentError listing the allowed values if s is not a valid sort order,
+// NoSortSpecified is valid.
+func (s SortOrder) Validate() error {
+	if s == NoSortSpecified {
+		return nil
+	}
+	allowed := make([]string, len(SortOrders))
+	for i, o := range SortOrders {
+		if s == o {
+			return nil
+		}
+		allowed[i] = string(o)
+	}
+	return flickErr.NewError(flickErr.ArgumentError,
+		fmt.Sprintf("unknown sort order %q, allowed values are: %s", string(s), strings.Join(allowed, ", ")))
+}
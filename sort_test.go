@@ -0,0 +1,23 @@
+package flickr
+
+import (
+	"strings"
+	"testing"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestSortOrderValidate(t *testing.T) {
+	Expect(t, NoSortSpecified.Validate(), nil)
+	for _, s := range SortOrders {
+		Expect(t, s.Validate(), nil)
+	}
+	// plain strings can be converted
+	Expect(t, SortOrder("date-taken-desc").Validate(), nil)
+
+	err := SortOrder("date-taken-descending").Validate()
+	ferr, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	Expect(t, strings.Contains(ferr.Error(), "interestingness-asc"), true)
+}
@@ -0,0 +1,160 @@
+// Package implementing methods: flickr.stats.*
+// Stats are only available to Flickr Pro accounts.
+package stats
+
+import (
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+type PhotoStatsResponse struct {
+	flickr.BasicResponse
+	Stats struct {
+		Views     int `xml:"views,attr"`
+		Comments  int `xml:"comments,attr"`
+		Favorites int `xml:"favorites,attr"`
+	} `xml:"stats"`
+}
+
+// View count for a single source
+type Views struct {
+	Views int `xml:"views,attr"`
+}
+
+type TotalViewsResponse struct {
+	flickr.BasicResponse
+	Stats struct {
+		Total       Views `xml:"total"`
+		Photos      Views `xml:"photos"`
+		Photostream Views `xml:"photostream"`
+		Sets        Views `xml:"sets"`
+		Collections Views `xml:"collections"`
+		Galleries   Views `xml:"galleries"`
+	} `xml:"stats"`
+}
+
+// Get the number of views, comments and favorites on a photo for a given date.
+// date is either a unix timestamp or a "YYYY-MM-DD" string, pass "" to get
+// stats for the latest available day.
+// This method requires authentication with 'read' permission.
+func GetPhotoStats(client *flickr.FlickrClient, date, photoId string) (*PhotoStatsResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.stats.getPhotoStats")
+	client.Args.Set("photo_id", photoId)
+	if date != "" {
+		client.Args.Set("date", date)
+	}
+	client.OAuthSign()
+
+	response := &PhotoStatsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Get the overall view counts for an account, split by source.
+// date is either a unix timestamp or a "YYYY-MM-DD" string, pass "" to get
+// all time view counts.
+// This method requires authentication with 'read' permission.
+func GetTotalViews(client *flickr.FlickrClient, date string) (*TotalViewsResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.stats.getTotalViews")
+	if date != "" {
+		client.Args.Set("date", date)
+	}
+	client.OAuthSign()
+
+	response := &TotalViewsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Sort orders accepted by GetPopularPhotos
+const (
+	SortViews     = "views"
+	SortComments  = "comments"
+	SortFavorites = "favorites"
+)
+
+// A photo along with its stats
+type PopularPhoto struct {
+	flickr.Photo
+	Stats struct {
+		Views     int `xml:"views,attr"`
+		Comments  int `xml:"comments,attr"`
+		Favorites int `xml:"favorites,attr"`
+	} `xml:"stats"`
+}
+
+type PopularPhotosResponse struct {
+	flickr.BasicResponse
+	Photos struct {
+		Page    int            `xml:"page,attr"`
+		Pages   int            `xml:"pages,attr"`
+		PerPage int            `xml:"perpage,attr"`
+		Total   int            `xml:"total,attr"`
+		Photos  []PopularPhoto `xml:"photo"`
+	} `xml:"photos"`
+}
+
+// Number of the page held by the response, implements flickr.Pager
+func (r *PopularPhotosResponse) Page() int {
+	return r.Photos.Page
+}
+
+// Total number of pages, implements flickr.Pager
+func (r *PopularPhotosResponse) Pages() int {
+	return r.Photos.Pages
+}
+
+type CollectionStatsResponse struct {
+	flickr.BasicResponse
+	Stats Views `xml:"stats"`
+}
+
+// List the calling user's photos with the most views, comments or favorites.
+// date is either a unix timestamp or a "YYYY-MM-DD" string, pass "" to get all
+// time stats. sort is one of SortViews (the default if empty), SortComments or
+// SortFavorites, any other value makes the call fail with an ArgumentError before
+// the request is performed. page is ignored if 0, perPage defaults to
+// flickr.DEFAULT_PER_PAGE.
+// This method requires authentication with 'read' permission.
+func GetPopularPhotos(client *flickr.FlickrClient, date, sort string, page, perPage int) (*PopularPhotosResponse, error) {
+	switch sort {
+	case "", SortViews, SortComments, SortFavorites:
+	default:
+		return nil, flickErr.NewError(flickErr.ArgumentError, "invalid sort order "+sort)
+	}
+
+	client.Init()
+	client.Args.Set("method", "flickr.stats.getPopularPhotos")
+	if date != "" {
+		client.Args.Set("date", date)
+	}
+	if sort != "" {
+		client.Args.Set("sort", sort)
+	}
+	flickr.SetPageArgs(client, page, perPage)
+	client.OAuthSign()
+
+	response := &PopularPhotosResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Get the number of views on a collection for a given date.
+// date is either a unix timestamp or a "YYYY-MM-DD" string, pass "" to get
+// stats for the latest available day.
+// This method requires authentication with 'read' permission.
+func GetCollectionStats(client *flickr.FlickrClient, date, collectionId string) (*CollectionStatsResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.stats.getCollectionStats")
+	client.Args.Set("collection_id", collectionId)
+	if date != "" {
+		client.Args.Set("date", date)
+	}
+	client.OAuthSign()
+
+	response := &CollectionStatsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
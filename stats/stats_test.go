@@ -0,0 +1,118 @@
+package stats
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestGetPhotoStats(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <stats views="24" comments="4" favorites="1" />
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPhotoStats(fclient, "2016-07-01", "123456")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("date"), "2016-07-01")
+	flickr.Expect(t, resp.Stats.Views, 24)
+	flickr.Expect(t, resp.Stats.Comments, 4)
+	flickr.Expect(t, resp.Stats.Favorites, 1)
+
+	_, err = GetPhotoStats(fclient, "", "123456")
+	flickr.Expect(t, err, nil)
+	_, found := fclient.Args["date"]
+	flickr.Expect(t, found, false)
+}
+
+func TestGetTotalViews(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <stats>
+    <total views="469" />
+    <photos views="386" />
+    <photostream views="72" />
+    <sets views="11" />
+    <collections views="0" />
+  </stats>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetTotalViews(fclient, "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Stats.Total.Views, 469)
+	flickr.Expect(t, resp.Stats.Photos.Views, 386)
+	flickr.Expect(t, resp.Stats.Photostream.Views, 72)
+	flickr.Expect(t, resp.Stats.Sets.Views, 11)
+	flickr.Expect(t, resp.Stats.Collections.Views, 0)
+}
+
+func TestGetTotalViewsKo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="fail">
+  <err code="1" msg="User does not have stats" />
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetTotalViews(fclient, "")
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.ErrorCode(), 1)
+}
+
+func TestGetPopularPhotos(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photos page="2" pages="89" perpage="10" total="881">
+    <photo id="2636" owner="47058503995@N01" secret="a123456" server="2" title="test_04" ispublic="1" isfriend="0" isfamily="0">
+      <stats views="941" comments="18" favorites="2" />
+    </photo>
+  </photos>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPopularPhotos(fclient, "", SortComments, 2, 10)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.stats.getPopularPhotos")
+	flickr.Expect(t, fclient.Args.Get("sort"), "comments")
+	flickr.Expect(t, fclient.Args.Get("page"), "2")
+	_, found := fclient.Args["date"]
+	flickr.Expect(t, found, false)
+	flickr.Expect(t, resp.Pages(), 89)
+	flickr.Expect(t, resp.Photos.Photos[0].Id, "2636")
+	flickr.Expect(t, resp.Photos.Photos[0].Stats.Views, 941)
+	flickr.Expect(t, resp.Photos.Photos[0].Stats.Comments, 18)
+
+	resp, err = GetPopularPhotos(fclient, "", "faves", 0, 0)
+	flickr.Expect(t, resp == nil, true)
+	ferr, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+}
+
+func TestGetCollectionStats(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <stats views="24" />
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetCollectionStats(fclient, "2016-07-01", "12-72157594586579649")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.stats.getCollectionStats")
+	flickr.Expect(t, fclient.Args.Get("collection_id"), "12-72157594586579649")
+	flickr.Expect(t, fclient.Args.Get("date"), "2016-07-01")
+	flickr.Expect(t, resp.Stats.Views, 24)
+}
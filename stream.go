@@ -0,0 +1,135 @@
+package flickr
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// Number of leading bytes of a streamed body kept to report responses that are not XML
+const streamHeadBytes = 4096
+
+// Called by DoGetStream for each element right under rsp, which it must consume
+// entirely, with d.DecodeElement or d.Skip for instance
+type ElementDecoder func(d *xml.Decoder, start xml.StartElement) error
+
+// Keep the first streamHeadBytes bytes read from r
+type headRecorder struct {
+	r    io.Reader
+	head bytes.Buffer
+}
+
+func (h *headRecorder) Read(p []byte) (int, error) {
+	n, err := h.r.Read(p)
+	if room := streamHeadBytes - h.head.Len(); room > 0 {
+		if room > n {
+			room = n
+		}
+		h.head.Write(p[:room])
+	}
+	return n, err
+}
+
+// Perform a GET request as DoGet does, but decode the response while it is read instead
+// of buffering it, so that memory doesn't grow with the size of the response: decode is
+// called for each element right under rsp, but err. The first error returned by decode
+// aborts decoding and is returned. r only gets the rsp attributes and, on failure, the
+// error details. Neither the cache nor MaxResponseBytes apply to streamed responses,
+// and client.ForcePost is ignored.
+func DoGetStream(client *FlickrClient, r *BasicResponse, decode ElementDecoder) (err error) {
+	defer client.observeCall(r)(&err)
+
+	if err := validateArgs(client); err != nil {
+		return err
+	}
+	client.resign("GET")
+
+	res, err := sendWithRetry(client, "GET", func() (*http.Request, error) {
+		return http.NewRequest("GET", client.GetUrl(), nil)
+	})
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := responseReader(res)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	if isAuthRejected(res) {
+		head, _ := ioutil.ReadAll(io.LimitReader(body, streamHeadBytes))
+		return client.checkClockSkew(r, authRejected(res, head, r))
+	}
+	head := &headRecorder{r: body}
+	d := xml.NewDecoder(head)
+
+	if err := decodeRoot(d, r); err != nil {
+		// same as parseApiBody for the raw text sent on OAuth errors
+		r.SetErrorStatus(true)
+		r.SetErrorCode(-1)
+		r.SetErrorMsg(head.head.String())
+		return client.checkClockSkew(r, flickErr.NewError(flickErr.ApiError, r.ErrorMsg()))
+	}
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "err" {
+				if err := d.DecodeElement(&r.Error, &t); err != nil {
+					return err
+				}
+				continue
+			}
+			if r.HasErrors() {
+				if err := d.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := decode(d, t); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			// end of rsp
+			if r.HasErrors() {
+				return client.checkClockSkew(r, statusError(r))
+			}
+			return nil
+		}
+	}
+}
+
+// Read the tokens up to the rsp element and set r from its attributes
+func decodeRoot(d *xml.Decoder, r *BasicResponse) error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if start.Name.Local != "rsp" {
+			return flickErr.NewError(flickErr.ApiError, "unexpected root element "+start.Name.Local)
+		}
+		r.XMLName = start.Name
+		for _, attr := range start.Attr {
+			if attr.Name.Local == "stat" {
+				r.Status = attr.Value
+			} else {
+				r.RootAttrs = append(r.RootAttrs, attr)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,80 @@
+package flickr
+
+import (
+	"encoding/xml"
+	"errors"
+	"testing"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestDoGetStream(t *testing.T) {
+	fclient := GetTestClient()
+	server, client := FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok" extra="1">
+  <photos page="1"><photo id="1" /><photo id="2" /></photos>
+  <total>2</total>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	var names []string
+	r := &BasicResponse{}
+	err := DoGetStream(fclient, r, func(d *xml.Decoder, start xml.StartElement) error {
+		names = append(names, start.Name.Local)
+		return d.Skip()
+	})
+	Expect(t, err, nil)
+	Expect(t, r.Status, "ok")
+	Expect(t, r.Attributes()["extra"], "1")
+	Expect(t, len(names), 2)
+	Expect(t, names[0], "photos")
+	Expect(t, names[1], "total")
+
+	abort := errors.New("enough")
+	err = DoGetStream(fclient, &BasicResponse{}, func(d *xml.Decoder, start xml.StartElement) error {
+		return abort
+	})
+	Expect(t, err, abort)
+}
+
+func TestDoGetStreamKo(t *testing.T) {
+	fclient := GetTestClient()
+	server, client := FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="fail"><err code="1" msg="User not found" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	called := false
+	r := &BasicResponse{}
+	err := DoGetStream(fclient, r, func(d *xml.Decoder, start xml.StartElement) error {
+		called = true
+		return d.Skip()
+	})
+	ee, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ee.ErrorCode, flickErr.ApiError)
+	Expect(t, called, false)
+	Expect(t, r.ErrorCode(), 1)
+	Expect(t, r.ErrorMsg(), "User not found")
+
+	server, client = FlickrMock(200, "oauth_problem=signature_invalid", "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	r = &BasicResponse{}
+	err = DoGetStream(fclient, r, nil)
+	_, ok = err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, r.ErrorCode(), -1)
+	Expect(t, r.ErrorMsg(), "oauth_problem=signature_invalid\n")
+
+	// truncated body
+	server, client = FlickrMock(200, `<rsp stat="ok"><photos>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	err = DoGetStream(fclient, &BasicResponse{}, func(d *xml.Decoder, start xml.StartElement) error {
+		return d.Skip()
+	})
+	Expect(t, err != nil, true)
+}
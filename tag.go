@@ -0,0 +1,38 @@
+package flickr
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Return the normalized form Flickr stores along with a raw tag: letters lowercased,
+// everything but letters and digits (spaces, punctuation, symbols) removed, so
+// that "New York" and "new-york" are both "newyork". Letters and digits outside
+// the ASCII range are kept. Machine tags (namespace:predicate=value) are not
+// handled, Flickr normalizes them with different rules.
+func NormalizeTag(raw string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, raw)
+}
+
+// Format a list of tags the way methods accepting many of them expect: space
+// separated, tags containing spaces quoted so that they are kept as a single tag.
+// Double quotes can't be part of a tag and are removed.
+func JoinTags(tags []string) string {
+	quoted := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(strings.Replace(tag, `"`, "", -1))
+		if tag == "" {
+			continue
+		}
+		if strings.ContainsAny(tag, " \t") {
+			tag = `"` + tag + `"`
+		}
+		quoted = append(quoted, tag)
+	}
+	return strings.Join(quoted, " ")
+}
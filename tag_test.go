@@ -0,0 +1,31 @@
+package flickr
+
+import (
+	"testing"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	for _, c := range []struct{ raw, normalized string }{
+		{"newyork", "newyork"},
+		{"New York", "newyork"},
+		{"new-york", "newyork"},
+		{"St. Paul's", "stpauls"},
+		{"rock'n'roll", "rocknroll"},
+		{"Boulder, CO", "boulderco"},
+		{"2016", "2016"},
+		{"Café Müller", "cafémüller"},
+		{"東京 タワー", "東京タワー"},
+		{"ÉCOLE", "école"},
+		{"#nofilter", "nofilter"},
+		{"  ", ""},
+	} {
+		Expect(t, NormalizeTag(c.raw), c.normalized)
+	}
+}
+
+func TestJoinTags(t *testing.T) {
+	Expect(t, JoinTags([]string{"milan", "duomo di milano"}), `milan "duomo di milano"`)
+	Expect(t, JoinTags([]string{`"duomo"`, " spaced ", "", `say "cheese"`}), `duomo spaced "say cheese"`)
+	Expect(t, JoinTags([]string{"St. Paul's"}), `"St. Paul's"`)
+	Expect(t, JoinTags(nil), "")
+}
@@ -0,0 +1,106 @@
+package tags
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+	"gopkg.in/masci/flickr.v2/photos"
+)
+
+// Requests Rename performs for each photo: getListPhoto, addTags and removeTag
+const renameCallsPerPhoto = 3
+
+// Errors of the photos Rename couldn't retag, by photo id
+type RenameErrors map[string]error
+
+func (e RenameErrors) Error() string {
+	ids := make([]string, 0, len(e))
+	for id := range e {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return fmt.Sprintf("tag of %d photos could not be renamed: %s", len(e), strings.Join(ids, ", "))
+}
+
+// Replace oldTag with newTag on the photos of the user with userId, "me" for the calling
+// user, and return the number of photos changed. Photos are found with photos.search,
+// newTag is added to each one before the old tag is removed by its id, so a failure
+// never leaves a photo without either. Photos already renamed are not found again,
+// which makes Rename safe to run again after a failure.
+// A failure on a photo doesn't stop the renaming: the count is returned along with a
+// RenameErrors holding the error of each photo left out, nil if none. Once the rate
+// limit quota is exhausted, remaining photos are left out with a *flickr.RateLimitError. Tags with the same normalized form, e.g. differing in case only, are
+// refused with an ArgumentError: Flickr sees them as the same tag.
+// This method requires authentication with 'write' permission.
+func Rename(client *flickr.FlickrClient, userId, oldTag, newTag string) (renamed int, err error) {
+	oldNorm := flickr.NormalizeTag(oldTag)
+	if oldNorm == "" || flickr.NormalizeTag(newTag) == "" {
+		return 0, flickErr.NewError(flickErr.ArgumentError, "empty tag")
+	}
+	if oldNorm == flickr.NormalizeTag(newTag) {
+		return 0, flickErr.NewError(flickErr.ArgumentError, fmt.Sprintf("%q and %q are the same tag", oldTag, newTag))
+	}
+
+	found, _, err := photos.SearchAll(client, true, &photos.SearchParams{
+		UserId:  userId,
+		Tags:    []string{oldTag},
+		PerPage: 500,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	errs := RenameErrors{}
+	for i, photo := range found {
+		if err := client.CheckRateLimit(renameCallsPerPhoto); err != nil {
+			for _, p := range found[i:] {
+				errs[p.Id] = err
+			}
+			break
+		}
+		changed, err := renamePhotoTag(client, photo.Id, oldNorm, newTag)
+		if err != nil {
+			errs[photo.Id] = err
+			continue
+		}
+		if changed {
+			renamed++
+		}
+	}
+
+	if len(errs) > 0 {
+		return renamed, errs
+	}
+	return renamed, nil
+}
+
+// Replace the tags of a photo normalized as oldNorm with newTag, telling whether the
+// photo had any
+func renamePhotoTag(client *flickr.FlickrClient, photoId, oldNorm, newTag string) (bool, error) {
+	list, err := GetListPhoto(client, true, photoId)
+	if err != nil {
+		return false, err
+	}
+	var ids []string
+	for _, tag := range list.Photo.Tags {
+		if flickr.NormalizeTag(tag.Tag) == oldNorm {
+			ids = append(ids, tag.Id)
+		}
+	}
+	if len(ids) == 0 {
+		return false, nil
+	}
+
+	if _, err := photos.AddTags(client, photoId, []string{newTag}); err != nil {
+		return false, err
+	}
+	for _, id := range ids {
+		if _, err := photos.RemoveTag(client, id); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
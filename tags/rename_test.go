@@ -0,0 +1,120 @@
+package tags
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// Fake Flickr holding the tags of a few photos, failing addTags on photo "3"
+type fakeTags struct {
+	photos    map[string][]string
+	remaining string
+}
+
+func (f *fakeTags) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.ParseMultipartForm(1 << 20)
+	if f.remaining != "" {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", f.remaining)
+	}
+	id := r.FormValue("photo_id")
+	switch r.FormValue("method") {
+	case "flickr.photos.search":
+		fmt.Fprint(w, `<rsp stat="ok"><photos page="1" pages="1" perpage="500">`)
+		for _, id := range []string{"1", "2", "3", "4"} {
+			for _, tag := range f.photos[id] {
+				if tag == r.FormValue("tags") {
+					fmt.Fprintf(w, `<photo id="%s" />`, id)
+					break
+				}
+			}
+		}
+		fmt.Fprint(w, `</photos></rsp>`)
+	case "flickr.tags.getListPhoto":
+		fmt.Fprintf(w, `<rsp stat="ok"><photo id="%s"><tags>`, id)
+		for _, tag := range f.photos[id] {
+			fmt.Fprintf(w, `<tag id="%s-%s" author="12037949754@N01" raw="%s">%s</tag>`, id, tag, tag, tag)
+		}
+		fmt.Fprint(w, `</tags></photo></rsp>`)
+	case "flickr.photos.addTags":
+		if id == "3" {
+			fmt.Fprint(w, `<rsp stat="fail"><err code="2" msg="Maximum number of tags reached" /></rsp>`)
+			return
+		}
+		f.photos[id] = append(f.photos[id], r.FormValue("tags"))
+		fmt.Fprint(w, `<rsp stat="ok"></rsp>`)
+	case "flickr.photos.removeTag":
+		parts := strings.SplitN(r.FormValue("tag_id"), "-", 2)
+		tags := f.photos[parts[0]][:0]
+		for _, tag := range f.photos[parts[0]] {
+			if tag != parts[1] {
+				tags = append(tags, tag)
+			}
+		}
+		f.photos[parts[0]] = tags
+		fmt.Fprint(w, `<rsp stat="ok"></rsp>`)
+	}
+}
+
+func renameClient(f *fakeTags) (*httptest.Server, *flickr.FlickrClient) {
+	server, fclient := flickr.FlickrMockHandler(f)
+	fclient.OAuthToken = "token"
+	fclient.OAuthTokenSecret = "secret"
+	return server, fclient
+}
+
+func TestRename(t *testing.T) {
+	f := &fakeTags{photos: map[string][]string{
+		"1": {"milano", "duomo"},
+		"2": {"milano", "milan"},
+		"3": {"milano"},
+		"4": {"duomo"},
+	}}
+	server, fclient := renameClient(f)
+	defer server.Close()
+
+	renamed, err := Rename(fclient, "me", "milano", "milan")
+	flickr.Expect(t, renamed, 2)
+	renameErrs, ok := err.(RenameErrors)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, len(renameErrs), 1)
+	flickr.Expect(t, renameErrs["3"] != nil, true)
+	flickr.Expect(t, strings.Join(f.photos["1"], ","), "duomo,milan")
+	flickr.Expect(t, strings.Join(f.photos["2"], ","), "milan,milan")
+	flickr.Expect(t, strings.Join(f.photos["3"], ","), "milano")
+
+	// running again only retries the photos left out
+	renamed, err = Rename(fclient, "me", "milano", "milan")
+	flickr.Expect(t, renamed, 0)
+	flickr.Expect(t, len(err.(RenameErrors)), 1)
+	delete(f.photos, "3")
+	renamed, err = Rename(fclient, "me", "milano", "milan")
+	flickr.Expect(t, renamed, 0)
+	flickr.Expect(t, err, nil)
+}
+
+func TestRenameRateLimit(t *testing.T) {
+	f := &fakeTags{photos: map[string][]string{"1": {"milano"}, "2": {"milano"}}, remaining: "2"}
+	server, fclient := renameClient(f)
+	defer server.Close()
+
+	renamed, err := Rename(fclient, "me", "milano", "milan")
+	flickr.Expect(t, renamed, 0)
+	flickr.Expect(t, len(err.(RenameErrors)), 2)
+	flickr.Expect(t, f.photos["1"][0], "milano")
+}
+
+func TestRenameInvalid(t *testing.T) {
+	for _, tags := range [][2]string{{"Milano", "milano"}, {"", "milan"}, {"milano", " "}} {
+		_, err := Rename(flickr.GetTestClient(), "me", tags[0], tags[1])
+		ferr, ok := err.(*flickErr.Error)
+		flickr.Expect(t, ok, true)
+		flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	}
+}
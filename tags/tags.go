@@ -0,0 +1,136 @@
+// Package implementing methods: flickr.tags.*
+package tags
+
+import (
+	"strconv"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// A group of tags often used together with the queried tag
+type Cluster struct {
+	Total int      `xml:"total,attr"`
+	Tags  []string `xml:"tag"`
+}
+
+type ClustersResponse struct {
+	flickr.BasicResponse
+	Clusters struct {
+		Source   string    `xml:"source,attr"`
+		Total    int       `xml:"total,attr"`
+		Clusters []Cluster `xml:"cluster"`
+	} `xml:"clusters"`
+}
+
+type HotTag struct {
+	Score int    `xml:"score,attr"`
+	Name  string `xml:",chardata"`
+}
+
+type HotTagsResponse struct {
+	flickr.BasicResponse
+	HotTags struct {
+		Period string   `xml:"period,attr"`
+		Count  int      `xml:"count,attr"`
+		Tags   []HotTag `xml:"tag"`
+	} `xml:"hottags"`
+}
+
+// Gives you a list of tag clusters for the given tag, each cluster holding
+// the tags most often used together.
+// This method does not require authentication.
+func GetClusters(client *flickr.FlickrClient, tag string) (*ClustersResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.tags.getClusters")
+	client.Args.Set("tag", tag)
+	client.ApiSign()
+
+	response := &ClustersResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Returns a list of hot tags for the given period, either "day" or "week".
+// An empty period or a count equal to 0 let Flickr use its defaults.
+// This method does not require authentication.
+func GetHotList(client *flickr.FlickrClient, period string, count int) (*HotTagsResponse, error) {
+	if period != "" && period != "day" && period != "week" {
+		return nil, flickErr.NewError(flickErr.ArgumentError, "period must be day or week")
+	}
+
+	client.Init()
+	client.Args.Set("method", "flickr.tags.getHotList")
+	if period != "" {
+		client.Args.Set("period", period)
+	}
+	if count > 0 {
+		client.Args.Set("count", strconv.Itoa(count))
+	}
+	client.ApiSign()
+
+	response := &HotTagsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+type UserTagsResponse struct {
+	flickr.BasicResponse
+	Who struct {
+		Id   string   `xml:"id,attr"`
+		Tags []string `xml:"tags>tag"`
+	} `xml:"who"`
+}
+
+// Get the tag list of a given user. When userId is empty the tags of the
+// calling user are returned and the call is authenticated.
+// This method does not require authentication.
+func GetListUser(client *flickr.FlickrClient, userId string) (*UserTagsResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.tags.getListUser")
+	if userId != "" {
+		client.Args.Set("user_id", userId)
+		client.ApiSign()
+	} else {
+		client.OAuthSign()
+	}
+
+	response := &UserTagsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// A tag of a photo; Id identifies the tag on that photo only, Tag is its normalized form
+type PhotoTag struct {
+	Id         string `xml:"id,attr"`
+	Author     string `xml:"author,attr"`
+	AuthorName string `xml:"authorname,attr"`
+	Raw        string `xml:"raw,attr"`
+	MachineTag bool   `xml:"machine_tag,attr"`
+	Tag        string `xml:",chardata"`
+}
+
+type PhotoTagsResponse struct {
+	flickr.BasicResponse
+	Photo struct {
+		Id   string     `xml:"id,attr"`
+		Tags []PhotoTag `xml:"tags>tag"`
+	} `xml:"photo"`
+}
+
+// Get the tags of a photo along with their ids.
+// This method requires authentication to retrieve the tags of private photos
+func GetListPhoto(client *flickr.FlickrClient, authenticate bool, photoId string) (*PhotoTagsResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.tags.getListPhoto")
+	client.Args.Set("photo_id", photoId)
+	if authenticate {
+		client.OAuthSign()
+	} else {
+		client.ApiSign()
+	}
+
+	response := &PhotoTagsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
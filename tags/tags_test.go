@@ -0,0 +1,129 @@
+package tags
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestGetClusters(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <clusters source="cows" total="2">
+    <cluster total="3">
+      <tag>farm</tag>
+      <tag>animals</tag>
+      <tag>cattle</tag>
+    </cluster>
+    <cluster total="2">
+      <tag>green</tag>
+      <tag>field</tag>
+    </cluster>
+  </clusters>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetClusters(fclient, "cows")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.tags.getClusters")
+	flickr.Expect(t, fclient.Args.Get("tag"), "cows")
+	flickr.Expect(t, resp.Clusters.Source, "cows")
+	flickr.Expect(t, len(resp.Clusters.Clusters), 2)
+	flickr.Expect(t, resp.Clusters.Clusters[0].Total, 3)
+	flickr.Expect(t, resp.Clusters.Clusters[0].Tags[2], "cattle")
+	flickr.Expect(t, resp.Clusters.Clusters[1].Tags[0], "green")
+}
+
+func TestGetHotList(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <hottags period="week" count="2">
+    <tag score="20">northerncalifornia</tag>
+    <tag score="18">top20</tag>
+  </hottags>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetHotList(fclient, "week", 2)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("period"), "week")
+	flickr.Expect(t, fclient.Args.Get("count"), "2")
+	flickr.Expect(t, resp.HotTags.Period, "week")
+	flickr.Expect(t, len(resp.HotTags.Tags), 2)
+	flickr.Expect(t, resp.HotTags.Tags[0].Name, "northerncalifornia")
+	flickr.Expect(t, resp.HotTags.Tags[0].Score, 20)
+
+	_, err = GetHotList(fclient, "", 0)
+	flickr.Expect(t, err, nil)
+	_, found := fclient.Args["period"]
+	flickr.Expect(t, found, false)
+	_, found = fclient.Args["count"]
+	flickr.Expect(t, found, false)
+}
+
+func TestGetHotListInvalidPeriod(t *testing.T) {
+	_, err := GetHotList(flickr.GetTestClient(), "month", 10)
+	ferr, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+}
+
+func TestGetListUser(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <who id="12037949754@N01">
+    <tags>
+      <tag>gull</tag>
+      <tag>tag1</tag>
+      <tag>tag2</tag>
+    </tags>
+  </who>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetListUser(fclient, "12037949754@N01")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.tags.getListUser")
+	flickr.Expect(t, fclient.Args.Get("user_id"), "12037949754@N01")
+	flickr.Expect(t, resp.Who.Id, "12037949754@N01")
+	flickr.Expect(t, len(resp.Who.Tags), 3)
+	flickr.Expect(t, resp.Who.Tags[0], "gull")
+
+	_, err = GetListUser(fclient, "")
+	flickr.Expect(t, err, nil)
+	_, found := fclient.Args["user_id"]
+	flickr.Expect(t, found, false)
+	_, found = fclient.Args["oauth_signature"]
+	flickr.Expect(t, found, true)
+}
+
+func TestGetListPhoto(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photo id="2619">
+    <tags>
+      <tag id="156" author="12037949754@N01" authorname="Bees" raw="New York" machine_tag="0">newyork</tag>
+      <tag id="157" author="12037949754@N01" authorname="Bees" raw="geo:lat=40.7" machine_tag="1">geo:lat=407</tag>
+    </tags>
+  </photo>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetListPhoto(fclient, false, "2619")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.tags.getListPhoto")
+	flickr.Expect(t, fclient.Args.Get("photo_id"), "2619")
+	flickr.Expect(t, resp.Photo.Id, "2619")
+	flickr.Expect(t, len(resp.Photo.Tags), 2)
+	flickr.Expect(t, resp.Photo.Tags[0], PhotoTag{"156", "12037949754@N01", "Bees", "New York", false, "newyork"})
+	flickr.Expect(t, resp.Photo.Tags[1].MachineTag, true)
+}
@@ -71,6 +71,15 @@ func FlickrMock(code int, body string, contentType string) (*httptest.Server, *h
 	return server, &http.Client{Transport: RewriteTransport{URL: u}}
 }
 
+// Start a server answering with handler and return it along with a client whose
+// requests, uploads included, all go to the server
+func FlickrMockHandler(handler http.Handler) (*httptest.Server, *FlickrClient) {
+	server := httptest.NewServer(handler)
+	u, _ := url.Parse(server.URL)
+
+	return server, NewFlickrClient("apikey", "apisecret", WithTransport(RewriteTransport{URL: u}))
+}
+
 // A ReaderCloser to fake http.Response Body field
 type FakeBody struct {
 	content *bytes.Buffer
@@ -0,0 +1,96 @@
+package flickr
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// Idle connections kept per host by the transport of NewTransport. The default of
+	// net/http is 2, far below what concurrent calls need against the API host.
+	DEFAULT_MAX_IDLE_CONNS_PER_HOST = 32
+	// How long the transport of NewTransport keeps an idle connection open
+	DEFAULT_IDLE_CONN_TIMEOUT = 60 * time.Second
+)
+
+// Transport shared by the clients of NewFlickrClient, so that they reuse connections,
+// built on first use by sharedTransport
+var (
+	defaultTransport     *http.Transport
+	defaultTransportOnce sync.Once
+)
+
+// Return the transport shared by the clients of NewFlickrClient
+func sharedTransport() *http.Transport {
+	defaultTransportOnce.Do(func() {
+		defaultTransport = NewTransport()
+	})
+	return defaultTransport
+}
+
+// Return a copy of http.DefaultTransport tuned to talk to a few hosts only, keeping up
+// to DEFAULT_MAX_IDLE_CONNS_PER_HOST idle connections per host for
+// DEFAULT_IDLE_CONN_TIMEOUT. When http.DefaultTransport was replaced by something else
+// than an *http.Transport, a new one with the same defaults is tuned instead.
+// NewFlickrClient uses one; to change the settings, adjust the fields of the transport
+// returned and pass it to WithTransport.
+func NewTransport() *http.Transport {
+	tr, ok := http.DefaultTransport.(*http.Transport)
+	if ok {
+		tr = tr.Clone()
+	} else {
+		tr = &http.Transport{
+			Proxy:                 http.ProxyFromEnvironment,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		}
+	}
+	tr.MaxIdleConnsPerHost = DEFAULT_MAX_IDLE_CONNS_PER_HOST
+	tr.IdleConnTimeout = DEFAULT_IDLE_CONN_TIMEOUT
+	return tr
+}
+
+// Option to customize a FlickrClient on creation, see NewFlickrClient
+type ClientOption func(*FlickrClient)
+
+// Send the requests of the client with hc instead of the default http.Client.
+// Uploads go through hc too when it has a Transport set.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *FlickrClient) {
+		c.HTTPClient = hc
+	}
+}
+
+// Route all the HTTP traffic of the client, uploads included, through rt.
+// Useful to add tracing, metrics or custom headers, see also ChainRoundTrippers.
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(c *FlickrClient) {
+		c.HTTPClient = &http.Client{Transport: rt}
+	}
+}
+
+// Adapter to use ordinary functions as http.RoundTripper
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// A middleware wraps a RoundTripper, doing its job before and/or after calling next
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// Compose middlewares around base, http.DefaultTransport if nil. The first middleware
+// is the outermost one: it sees requests first and responses last.
+func ChainRoundTrippers(base http.RoundTripper, middlewares ...Middleware) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	rt := base
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		rt = middlewares[i](rt)
+	}
+	return rt
+}
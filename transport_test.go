@@ -0,0 +1,76 @@
+package flickr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestWithTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><photoid>1234</photoid></rsp>`))
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+
+	calls := []string{}
+	var record = func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" "+req.Method)
+				req.Header.Set("X-"+name, "1")
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	rt := ChainRoundTrippers(RewriteTransport{URL: u}, record("outer"), record("inner"))
+
+	fclient := NewFlickrClient("apikey", "apisecret", WithTransport(rt))
+	fclient.Init()
+	fclient.Args.Set("method", "flickr.test.null")
+	fclient.OAuthSign()
+	err := DoGet(fclient, &BasicResponse{})
+	Expect(t, err, nil)
+	err = DoPost(fclient, &BasicResponse{})
+	Expect(t, err, nil)
+	resp, err := UploadReader(fclient, strings.NewReader("photo"), "gopher.jpg", nil)
+	Expect(t, err, nil)
+	Expect(t, resp.ID, "1234")
+
+	Expect(t, strings.Join(calls, ","), "outer GET,inner GET,outer POST,inner POST,outer POST,inner POST")
+}
+
+func TestChainRoundTrippersDefault(t *testing.T) {
+	Expect(t, ChainRoundTrippers(nil), http.DefaultTransport)
+}
+
+func TestDefaultTransport(t *testing.T) {
+	fclient := NewFlickrClient("apikey", "apisecret")
+	tr, ok := fclient.HTTPClient.Transport.(*http.Transport)
+	Expect(t, ok, true)
+	Expect(t, tr.MaxIdleConnsPerHost, DEFAULT_MAX_IDLE_CONNS_PER_HOST)
+	Expect(t, tr.IdleConnTimeout, DEFAULT_IDLE_CONN_TIMEOUT)
+	// shared by clients, so that they reuse connections
+	Expect(t, NewFlickrClient("apikey", "apisecret").HTTPClient.Transport, fclient.HTTPClient.Transport)
+
+	tuned := NewTransport()
+	Expect(t, tuned != tr, true)
+	tuned.MaxIdleConnsPerHost = 100
+	Expect(t, tr.MaxIdleConnsPerHost, DEFAULT_MAX_IDLE_CONNS_PER_HOST)
+	fclient = NewFlickrClient("apikey", "apisecret", WithTransport(tuned))
+	Expect(t, fclient.HTTPClient.Transport, http.RoundTripper(tuned))
+
+	hc := &http.Client{}
+	fclient = NewFlickrClient("apikey", "apisecret", WithHTTPClient(hc))
+	Expect(t, fclient.HTTPClient, hc)
+
+	// http.DefaultTransport replaced by a wrapper
+	saved := http.DefaultTransport
+	http.DefaultTransport = RoundTripperFunc(saved.RoundTrip)
+	defer func() { http.DefaultTransport = saved }()
+	tuned = NewTransport()
+	Expect(t, tuned.MaxIdleConnsPerHost, DEFAULT_MAX_IDLE_CONNS_PER_HOST)
+	Expect(t, tuned.Proxy != nil, true)
+}
@@ -1,15 +1,18 @@
 package flickr
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/tls"
+	"encoding/xml"
 	"fmt"
 	"io"
-	"log"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -26,38 +29,69 @@ func randomBoundary() string {
 }
 
 // Encode the file and request parameters in a multipart body.
-// File contents are streamed into the request using an io.Pipe in a separated goroutine
+// File contents are streamed into the request using an io.Pipe in a separated goroutine.
+// Errors close the pipe, failing the request: this is also how the goroutine ends
+// when the request is aborted and the pipe closed on the reading side.
 func streamUploadBody(client *FlickrClient, photo io.Reader, body *io.PipeWriter, fileName string, boundary string) {
+	var err error
+	defer func() {
+		body.CloseWithError(err)
+	}()
+
 	// multipart writer to fill the body
-	defer body.Close()
 	writer := multipart.NewWriter(body)
 	writer.SetBoundary(boundary)
 
 	// create the "photo" field
 	part, err := writer.CreateFormFile("photo", filepath.Base(fileName))
 	if err != nil {
-		log.Fatal(err)
 		return
 	}
 
 	// fill the photo field
 	_, err = io.Copy(part, photo)
 	if err != nil {
-		log.Fatal(err)
 		return
 	}
 
-	// dump other params
-	for key, val := range client.Args {
-		_ = writer.WriteField(key, val[0])
+	// dump other params with their raw values, in the same order and with the same
+	// values as they were signed: the photo field is not part of the signature
+	for _, key := range sortedArgKeys(client.Args) {
+		for _, val := range client.Args[key] {
+			if err = writer.WriteField(key, val); err != nil {
+				return
+			}
+		}
 	}
 
 	// close the form writer
 	err = writer.Close()
-	if err != nil {
-		log.Fatal(err)
-		return
+}
+
+// Return the keys of args sorted as url.Values.Encode does when building the base string
+func sortedArgKeys(args url.Values) []string {
+	keys := make([]string, 0, len(args))
+	for k := range args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Reader calling progress with the number of bytes read so far
+type progressReader struct {
+	io.Reader
+	read     int64
+	progress func(sent int64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 {
+		r.read += int64(n)
+		r.progress(r.read)
 	}
+	return n, err
 }
 
 // UploadParams is a convenience struct wrapping all optional upload parameters
@@ -65,24 +99,71 @@ type UploadParams struct {
 	Title, Description           string
 	Tags                         []string
 	IsPublic, IsFamily, IsFriend bool
-	ContentType                  int
+	ContentType                  ContentType
 	Hidden                       int
-	SafetyLevel                  int
+	SafetyLevel                  SafetyLevel
+	// Process the upload asynchronously, Flickr returns a ticket id instead of the photo id.
+	// Videos are always uploaded asynchronously.
+	Async bool
+	// Called, if set, each time a chunk of the file is sent with the number of bytes
+	// sent so far. It runs in the goroutine streaming the request body.
+	Progress func(sent int64)
 }
 
 // NewUploadParams provides meaningful default values
 func NewUploadParams() *UploadParams {
 	ret := &UploadParams{}
-	ret.ContentType = 1 // photo
-	ret.Hidden = 2      // hidden from public searchesi
-	ret.SafetyLevel = 1 // safe
+	ret.ContentType = ContentTypePhoto
+	ret.Hidden = 2 // hidden from public searchesi
+	ret.SafetyLevel = SafetyLevelSafe
 	return ret
 }
 
 // UploadResponse is a type representing a successful upload response from the api
 type UploadResponse struct {
 	BasicResponse
-	ID string `xml:"photoid"`
+	ID string
+	// Secrets of the uploaded photo, when returned by Flickr they save a getInfo
+	// call to build the photo URLs
+	Secret         string
+	OriginalSecret string
+	// Only set for async uploads, check it with flickr.photos.upload.checkTickets
+	TicketID string
+}
+
+// Flickr returns the photo id as text of the photoid element and the secrets as its
+// attributes, so UploadResponse is decoded through an intermediate struct.
+func (r *UploadResponse) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	raw := struct {
+		BasicResponse
+		PhotoID struct {
+			ID             string `xml:",chardata"`
+			Secret         string `xml:"secret,attr"`
+			OriginalSecret string `xml:"originalsecret,attr"`
+		} `xml:"photoid"`
+		TicketID string `xml:"ticketid"`
+	}{}
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+
+	r.BasicResponse = raw.BasicResponse
+	r.ID = raw.PhotoID.ID
+	r.Secret = raw.PhotoID.Secret
+	r.OriginalSecret = raw.PhotoID.OriginalSecret
+	r.TicketID = raw.TicketID
+	return nil
+}
+
+// File extensions of the video formats supported by Flickr
+var videoExtensions = map[string]bool{
+	".3gp": true, ".avi": true, ".m2ts": true, ".m4v": true, ".mov": true, ".mp4": true,
+	".mpeg": true, ".mpg": true, ".mts": true, ".ogg": true, ".ogv": true, ".wmv": true,
+}
+
+// Whether the file name refers to a video
+func isVideo(fileName string) bool {
+	return videoExtensions[strings.ToLower(filepath.Ext(fileName))]
 }
 
 // Set client query arguments based on the contents of the UploadParams struct
@@ -109,16 +190,20 @@ func fillArgsWithParams(client *FlickrClient, params *UploadParams) {
 	client.Args.Set("is_friend", boolString(params.IsFriend))
 	client.Args.Set("is_family", boolString(params.IsFamily))
 
-	if params.ContentType >= 1 && params.ContentType <= 3 {
-		client.Args.Set("content_type", strconv.Itoa(params.ContentType))
+	if params.ContentType >= ContentTypePhoto && params.ContentType <= ContentTypeOther {
+		client.Args.Set("content_type", strconv.Itoa(int(params.ContentType)))
 	}
 
 	if params.Hidden >= 1 && params.Hidden <= 2 {
 		client.Args.Set("hidden", strconv.Itoa(params.Hidden))
 	}
 
-	if params.SafetyLevel >= 1 && params.SafetyLevel <= 3 {
-		client.Args.Set("safety_level", strconv.Itoa(params.SafetyLevel))
+	if params.SafetyLevel >= SafetyLevelSafe && params.SafetyLevel <= SafetyLevelRestricted {
+		client.Args.Set("safety_level", strconv.Itoa(int(params.SafetyLevel)))
+	}
+
+	if params.Async {
+		client.Args.Set("async", "1")
 	}
 }
 
@@ -141,8 +226,32 @@ func UploadReader(client *FlickrClient, photoReader io.Reader, name string, opti
 	return UploadReaderWithClient(client, photoReader, name, optionalParams, nil)
 }
 
-// UploadReaderWithClient does same as UploadReader but allows passing a custom httpClient
+// UploadReaderWithClient does same as UploadReader but allows passing a custom httpClient.
+// When httpClient is nil, client.HTTPClient is used if it has a custom Transport.
+// Video files (recognized by the name extension) are uploaded asynchronously: large videos
+// take a long time to be processed, TicketID must be checked to know the outcome.
+// An ArgumentError is returned, before sending anything, when optionalParams holds an
+// invalid ContentType or SafetyLevel.
 func UploadReaderWithClient(client *FlickrClient, photoReader io.Reader, name string, optionalParams *UploadParams, httpClient *http.Client) (*UploadResponse, error) {
+	return uploadReader(context.Background(), client, photoReader, name, optionalParams, httpClient)
+}
+
+// UploadReaderContext does same as UploadReader, the upload is aborted as soon as ctx
+// is done. In that case the returned error wraps ctx.Err(), check it with errors.Is.
+func UploadReaderContext(ctx context.Context, client *FlickrClient, photoReader io.Reader, name string, optionalParams *UploadParams) (*UploadResponse, error) {
+	return uploadReader(ctx, client, photoReader, name, optionalParams, nil)
+}
+
+func uploadReader(ctx context.Context, client *FlickrClient, photoReader io.Reader, name string, optionalParams *UploadParams, httpClient *http.Client) (*UploadResponse, error) {
+	if optionalParams != nil {
+		if err := optionalParams.ContentType.Validate(); err != nil {
+			return nil, err
+		}
+		if err := optionalParams.SafetyLevel.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	client.Init()
 	client.EndpointUrl = UPLOAD_ENDPOINT
 	client.HTTPVerb = "POST"
@@ -151,24 +260,40 @@ func UploadReaderWithClient(client *FlickrClient, photoReader io.Reader, name st
 		fillArgsWithParams(client, optionalParams)
 	}
 
+	if isVideo(name) {
+		client.Args.Set("async", "1")
+	}
+
 	client.OAuthSign()
 
+	if optionalParams != nil && optionalParams.Progress != nil {
+		photoReader = &progressReader{Reader: photoReader, progress: optionalParams.Progress}
+	}
+
 	// write request body in a Pipe
 	boundary := randomBoundary()
 	r, w := io.Pipe()
 	go streamUploadBody(client, photoReader, w, name, boundary)
 
 	// create an HTTP Request
-	req, err := http.NewRequest("POST", client.EndpointUrl, r)
+	req, err := http.NewRequestWithContext(ctx, "POST", client.EndpointUrl, r)
 	if err != nil {
+		r.Close()
 		return nil, err
 	}
 
 	// set content-type
 	req.Header.Set("content-type", "multipart/form-data; boundary="+boundary)
 	req.ContentLength = -1 // unknown
+	req.Header.Set("User-Agent", client.getUserAgent())
 
-	if (httpClient == nil) {
+	// a custom transport set on the client is honored, so that it sees uploads too
+	if httpClient == nil && client.HTTPClient != nil && client.HTTPClient.Transport != nil &&
+		client.HTTPClient.Transport != sharedTransport() {
+		httpClient = client.HTTPClient
+	}
+
+	if httpClient == nil {
 		// Create a Transport to explicitly use the http1.1 client
 		// TODO: for some reason, when we use the http2 client flickr API responds
 		// with HTTP: 411 (No Content Length : POST) whereas it should be ok to
@@ -183,13 +308,23 @@ func UploadReaderWithClient(client *FlickrClient, photoReader io.Reader, name st
 		httpClient = &http.Client{Transport: tr}
 	}
 
+	// the body stays unread until the caller reads or closes it, which also
+	// ends the streaming goroutine
+	if client.DryRun {
+		return nil, &DryRunError{Request: req}
+	}
+
 	// perform upload request streaming the file
 	resp, err := httpClient.Do(req)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("upload of %s aborted: %w", name, ctxErr)
+		}
 		return nil, err
 	}
+	client.rateLimit.record(resp.Header)
 
 	apiResp := &UploadResponse{}
-	err = parseApiResponse(resp, apiResp)
+	err = parseApiResponse(resp, apiResp, client.LenientDecode)
 	return apiResp, err
 }
@@ -1,9 +1,18 @@
 package flickr
 
 import (
+	"context"
+	"errors"
 	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	flickErr "gopkg.in/masci/flickr.v2/error"
 )
@@ -16,9 +25,9 @@ func TestNewUploadParams(t *testing.T) {
 	Expect(t, params.IsPublic, false)
 	Expect(t, params.IsFamily, false)
 	Expect(t, params.IsFriend, false)
-	Expect(t, params.ContentType, 1)
+	Expect(t, params.ContentType, ContentTypePhoto)
 	Expect(t, params.Hidden, 2)
-	Expect(t, params.SafetyLevel, 1)
+	Expect(t, params.SafetyLevel, SafetyLevelSafe)
 }
 
 func TestFillArgsWithParams(t *testing.T) {
@@ -35,6 +44,7 @@ func TestFillArgsWithParams(t *testing.T) {
 	Expect(t, client.Args.Get("content_type"), "1")
 	Expect(t, client.Args.Get("hidden"), "2")
 	Expect(t, client.Args.Get("safety_level"), "1")
+	Expect(t, client.Args.Get("async"), "")
 
 	params.Title = "foo"
 	params.Description = "a long description"
@@ -45,6 +55,7 @@ func TestFillArgsWithParams(t *testing.T) {
 	params.ContentType = 100
 	params.Hidden = 100
 	params.SafetyLevel = 100
+	params.Async = true
 	client.ClearArgs()
 	fillArgsWithParams(client, params)
 	Expect(t, client.Args.Get("title"), "foo")
@@ -56,6 +67,7 @@ func TestFillArgsWithParams(t *testing.T) {
 	Expect(t, client.Args.Get("content_type"), "")
 	Expect(t, client.Args.Get("hidden"), "")
 	Expect(t, client.Args.Get("safety_level"), "")
+	Expect(t, client.Args.Get("async"), "1")
 }
 
 func TestUploadFile(t *testing.T) {
@@ -84,3 +96,212 @@ func TestUploadFileKo(t *testing.T) {
 	Expect(t, ok, true)
 	Expect(t, resp.HasErrors(), true)
 }
+
+func TestIsVideo(t *testing.T) {
+	Expect(t, isVideo("movie.mp4"), true)
+	Expect(t, isVideo("/path/to/MOVIE.MOV"), true)
+	Expect(t, isVideo("clip.avi"), true)
+	Expect(t, isVideo("gopher.jpg"), false)
+	Expect(t, isVideo("mp4"), false)
+}
+
+func TestUploadVideo(t *testing.T) {
+	var async string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseMultipartForm(1024)
+		async = r.FormValue("async")
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><ticketid>1234-5678</ticketid></rsp>`))
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	httpClient := &http.Client{Transport: RewriteTransport{URL: u}}
+
+	fclient := GetTestClient()
+	resp, err := UploadReaderWithClient(fclient, strings.NewReader("video"), "movie.mp4", nil, httpClient)
+	Expect(t, err, nil)
+	Expect(t, async, "1")
+	Expect(t, resp.TicketID, "1234-5678")
+	Expect(t, resp.ID, "")
+
+	resp, err = UploadReaderWithClient(fclient, strings.NewReader("photo"), "gopher.jpg", nil, httpClient)
+	Expect(t, err, nil)
+	Expect(t, async, "")
+}
+
+func TestUploadInvalidParams(t *testing.T) {
+	fclient := GetTestClient()
+	params := NewUploadParams()
+	params.SafetyLevel = 4
+	resp, err := UploadReader(fclient, strings.NewReader("photo"), "gopher.jpg", params)
+	Expect(t, resp == nil, true)
+	ferr, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+
+	params = NewUploadParams()
+	params.ContentType = 7
+	_, err = UploadReader(fclient, strings.NewReader("photo"), "gopher.jpg", params)
+	ferr, ok = err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+}
+
+func TestUploadResponse(t *testing.T) {
+	resp := &UploadResponse{}
+	err := parseApiBody([]byte(`<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photoid secret="abcdef" originalsecret="123456">1234</photoid>
+</rsp>`), resp)
+	Expect(t, err, nil)
+	Expect(t, resp.ID, "1234")
+	Expect(t, resp.Secret, "abcdef")
+	Expect(t, resp.OriginalSecret, "123456")
+	Expect(t, resp.TicketID, "")
+
+	resp = &UploadResponse{}
+	err = parseApiBody([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><ticketid>1234-5678</ticketid></rsp>`), resp)
+	Expect(t, err, nil)
+	Expect(t, resp.ID, "")
+	Expect(t, resp.TicketID, "1234-5678")
+
+	resp = &UploadResponse{}
+	err = parseApiBody([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"><err code="5" msg="Filetype was not recognised" /></rsp>`), resp)
+	_, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, resp.ErrorCode(), 5)
+	Expect(t, resp.ErrorMsg(), "Filetype was not recognised")
+}
+
+// Reader yielding a chunk every 10ms, for ever
+type slowReader struct{}
+
+func (slowReader) Read(p []byte) (int, error) {
+	time.Sleep(10 * time.Millisecond)
+	n := copy(p, strings.Repeat("x", 64))
+	return n, nil
+}
+
+func TestUploadReaderContextCancel(t *testing.T) {
+	server, fclient := FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><photoid>1234</photoid></rsp>`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	params := &UploadParams{Progress: func(sent int64) {
+		if sent >= 256 {
+			cancel()
+		}
+	}}
+
+	done := make(chan error)
+	go func() {
+		_, err := UploadReaderContext(ctx, fclient, slowReader{}, "gopher.jpg", params)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		Expect(t, errors.Is(err, context.Canceled), true)
+	case <-time.After(5 * time.Second):
+		t.Fatal("upload was not aborted")
+	}
+}
+
+func TestUploadReaderContextProgress(t *testing.T) {
+	server, fclient := FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><photoid>1234</photoid></rsp>`))
+	}))
+	defer server.Close()
+
+	var sent int64
+	params := &UploadParams{Progress: func(n int64) { sent = n }}
+	resp, err := UploadReaderContext(context.Background(), fclient, strings.NewReader("photo"), "gopher.jpg", params)
+	Expect(t, err, nil)
+	Expect(t, resp.ID, "1234")
+	Expect(t, sent, int64(5))
+}
+
+// Upload params holding spaces, accents and reserved chars
+func accentedUploadParams() *UploadParams {
+	return &UploadParams{
+		Title:       "Vacances à Noël",
+		Description: "Café & crème, 100% *bio*",
+		Tags:        []string{"été", `"mer bleue"`},
+		IsPublic:    true,
+	}
+}
+
+// Client ready to sign an upload of accentedUploadParams
+func uploadSigningClient() *FlickrClient {
+	fclient := GetTestClient()
+	fclient.OAuthToken = "72157626737672178-022bbd2f4c2f3432"
+	fclient.OAuthTokenSecret = "tokensecret"
+	fclient.ApiKey = "768fe946d252b119746fda82e1599980"
+	fclient.Init()
+	fclient.EndpointUrl = UPLOAD_ENDPOINT
+	fclient.HTTPVerb = "POST"
+	return fclient
+}
+
+func TestUploadSignature(t *testing.T) {
+	fclient := uploadSigningClient()
+	fillArgsWithParams(fclient, accentedUploadParams())
+	fclient.Args.Set("oauth_nonce", "C2F26CD5C075BA9050AD8EE90644CF29")
+	fclient.Args.Set("oauth_timestamp", "1316657628")
+	fclient.Args.Set("oauth_consumer_key", fclient.ApiKey)
+	fclient.Args.Set("oauth_signature_method", "HMAC-SHA1")
+	fclient.Args.Set("oauth_version", "1.0")
+	fclient.Args.Set("oauth_token", fclient.OAuthToken)
+	fclient.Args.Set("api_key", fclient.ApiKey)
+	Expect(t, len(fclient.Args), 13)
+
+	// vector computed following RFC 5849 section 3.4.1
+	fclient.Sign(fclient.OAuthTokenSecret)
+	Expect(t, fclient.Args.Get("oauth_signature"), "8HdtLWkWUDLX80IjnmiV5/jGACE=")
+}
+
+func TestUploadSignedFields(t *testing.T) {
+	var keys []string
+	fields := url.Values{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		mr := multipart.NewReader(r.Body, params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err != nil {
+				break
+			}
+			value, _ := ioutil.ReadAll(part)
+			if part.FormName() != "photo" {
+				keys = append(keys, part.FormName())
+				fields.Add(part.FormName(), string(value))
+			}
+		}
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><photoid>1234</photoid></rsp>`))
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+
+	fclient := uploadSigningClient()
+	fclient.HTTPClient = &http.Client{Transport: RewriteTransport{URL: u}}
+	_, err := UploadReader(fclient, strings.NewReader("photo"), "gopher.jpg", accentedUploadParams())
+	Expect(t, err, nil)
+
+	// raw values, sorted as in the base string
+	Expect(t, fields.Get("title"), "Vacances à Noël")
+	Expect(t, fields.Get("tags"), `été "mer bleue"`)
+	Expect(t, strings.Join(keys, ","), strings.Join(sortedArgKeys(fields), ","))
+
+	// the signature matches the fields received
+	check := uploadSigningClient()
+	for k, v := range fields {
+		if k != "oauth_signature" {
+			check.Args[k] = v
+		}
+	}
+	check.Sign(check.OAuthTokenSecret)
+	Expect(t, fields.Get("oauth_signature"), check.Args.Get("oauth_signature"))
+}
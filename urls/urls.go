@@ -0,0 +1,91 @@
+// Package implementing methods: flickr.urls.*
+package urls
+
+import (
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Response type used by LookupUser function
+type LookupUserResponse struct {
+	flickr.BasicResponse
+	User struct {
+		// Flickr ID
+		ID string `xml:"id,attr"`
+		// Flickr Username
+		Username string `xml:"username"`
+	} `xml:"user"`
+}
+
+// Response type used by LookupGroup function
+type LookupGroupResponse struct {
+	flickr.BasicResponse
+	Group struct {
+		// Flickr group ID
+		ID string `xml:"id,attr"`
+		// Name of the group
+		GroupName string `xml:"groupname"`
+	} `xml:"group"`
+}
+
+// Response type used by GetUserPhotos and GetUserProfile functions
+type UserUrlResponse struct {
+	flickr.BasicResponse
+	User struct {
+		// Flickr ID
+		ID string `xml:"nsid,attr"`
+		// The requested url
+		Url string `xml:"url,attr"`
+	} `xml:"user"`
+}
+
+// Returns a user NSID, given the url to a user's photos or profile.
+// This method does not require authentication.
+func LookupUser(client *flickr.FlickrClient, url string) (*LookupUserResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.urls.lookupUser")
+	client.Args.Set("url", url)
+	client.ApiSign()
+
+	response := &LookupUserResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Returns a group NSID, given the url to a group's page or photo pool.
+// This method does not require authentication.
+func LookupGroup(client *flickr.FlickrClient, url string) (*LookupGroupResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.urls.lookupGroup")
+	client.Args.Set("url", url)
+	client.ApiSign()
+
+	response := &LookupGroupResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Returns the url to a user's photos.
+// This method does not require authentication.
+func GetUserPhotos(client *flickr.FlickrClient, userId string) (*UserUrlResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.urls.getUserPhotos")
+	client.Args.Set("user_id", userId)
+	client.ApiSign()
+
+	response := &UserUrlResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Returns the url to a user's profile.
+// This method does not require authentication.
+func GetUserProfile(client *flickr.FlickrClient, userId string) (*UserUrlResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.urls.getUserProfile")
+	client.Args.Set("user_id", userId)
+	client.ApiSign()
+
+	response := &UserUrlResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
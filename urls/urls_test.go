@@ -0,0 +1,89 @@
+package urls
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestLookupUser(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <user id="12037949632@N01">
+    <username>Stewart</username>
+  </user>
+</rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := LookupUser(fclient, "https://www.flickr.com/photos/stewart/")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.User.ID, "12037949632@N01")
+	flickr.Expect(t, resp.User.Username, "Stewart")
+	flickr.Expect(t, fclient.Args.Get("url"), "https://www.flickr.com/photos/stewart/")
+}
+
+func TestLookupUserKo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="fail">
+  <err code="1" msg="User not found" />
+</rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := LookupUser(fclient, "https://www.flickr.com/photos/nobody/")
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.ErrorCode(), 1)
+}
+
+func TestLookupGroup(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <group id="34427469792@N01">
+    <groupname>FlickrCentral</groupname>
+  </group>
+</rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := LookupGroup(fclient, "https://www.flickr.com/groups/central/")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Group.ID, "34427469792@N01")
+	flickr.Expect(t, resp.Group.GroupName, "FlickrCentral")
+}
+
+func TestGetUserPhotos(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <user nsid="12037949754@N01" url="https://www.flickr.com/photos/bees/" />
+</rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetUserPhotos(fclient, "12037949754@N01")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.User.ID, "12037949754@N01")
+	flickr.Expect(t, resp.User.Url, "https://www.flickr.com/photos/bees/")
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.urls.getUserPhotos")
+}
+
+func TestGetUserProfile(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <user nsid="12037949754@N01" url="https://www.flickr.com/people/bees/" />
+</rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetUserProfile(fclient, "12037949754@N01")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.User.Url, "https://www.flickr.com/people/bees/")
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.urls.getUserProfile")
+}
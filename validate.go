@@ -0,0 +1,78 @@
+package flickr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// Args every request carries, set by the signing process
+var signingArgs = map[string]bool{
+	"api_key": true,
+}
+
+// Arguments of a method as described by flickr.reflection.getMethodInfo
+type methodInfoResponse struct {
+	BasicResponse
+	Arguments []struct {
+		Name     string `xml:"name,attr"`
+		Optional bool   `xml:"optional,attr"`
+	} `xml:"arguments>argument"`
+}
+
+// Return the required arguments of method, asking Flickr the first time
+func getRequiredArgs(client *FlickrClient, method string) ([]string, error) {
+	args, err := client.Lazy("flickr.reflection.getMethodInfo:"+method, func() (interface{}, error) {
+		c := client.copy()
+		c.Validate = false
+		c.Init()
+		c.Args.Set("method", "flickr.reflection.getMethodInfo")
+		c.Args.Set("method_name", method)
+		c.ApiSign()
+		response := &methodInfoResponse{}
+		if err := DoGet(c, response); err != nil {
+			return nil, err
+		}
+
+		args := []string{}
+		for _, arg := range response.Arguments {
+			if !arg.Optional && !signingArgs[arg.Name] {
+				args = append(args, arg.Name)
+			}
+		}
+		return args, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return args.([]string), nil
+}
+
+// When client.Validate is set, check the client Args contain all the arguments required
+// by the method being called, returning an ArgumentError listing the missing ones.
+func validateArgs(client *FlickrClient) error {
+	method := client.Args.Get("method")
+	if !client.Validate || method == "" {
+		return nil
+	}
+
+	required, err := getRequiredArgs(client, method)
+	if err != nil {
+		return err
+	}
+
+	missing := []string{}
+	for _, arg := range required {
+		if _, found := client.Args[arg]; !found {
+			missing = append(missing, arg)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return flickErr.NewError(flickErr.ArgumentError,
+			fmt.Sprintf("%s requires missing arguments: %s", method, strings.Join(missing, ", ")))
+	}
+	return nil
+}
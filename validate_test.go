@@ -0,0 +1,67 @@
+package flickr
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+func TestValidate(t *testing.T) {
+	var mu sync.Mutex
+	reflectionCalls := 0
+	server, fclient := FlickrMockHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseMultipartForm(1024)
+		if r.FormValue("method") == "flickr.reflection.getMethodInfo" {
+			mu.Lock()
+			reflectionCalls++
+			mu.Unlock()
+			w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <method name="flickr.validate.test" />
+  <arguments>
+    <argument name="api_key" optional="0">Your API application key.</argument>
+    <argument name="photo_id" optional="0">The id of the photo.</argument>
+    <argument name="tags" optional="0">The tags.</argument>
+    <argument name="secret" optional="1">The secret for the photo.</argument>
+  </arguments>
+</rsp>`))
+			return
+		}
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`))
+	}))
+	defer server.Close()
+
+	fclient.Validate = true
+	fclient.Init()
+	fclient.Args.Set("method", "flickr.validate.test")
+	fclient.ApiSign()
+
+	err := DoGet(fclient, &BasicResponse{})
+	ferr, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.ErrorCode, flickErr.ArgumentError)
+	Expect(t, strings.Contains(ferr.Error(), "photo_id, tags"), true)
+
+	fclient.Args.Set("photo_id", "123")
+	err = DoPost(fclient, &BasicResponse{})
+	ferr, ok = err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, strings.HasSuffix(ferr.Error(), ": tags"), true)
+
+	fclient.Args.Set("tags", "foo")
+	err = DoGet(fclient, &BasicResponse{})
+	Expect(t, err, nil)
+
+	// method info was asked only once
+	Expect(t, reflectionCalls, 1)
+
+	// no checks when Validate is not set
+	fclient.Validate = false
+	fclient.Args.Set("method", "flickr.validate.other")
+	err = DoGet(fclient, &BasicResponse{})
+	Expect(t, err, nil)
+	Expect(t, reflectionCalls, 1)
+}
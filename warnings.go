@@ -0,0 +1,113 @@
+package flickr
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var basicResponseType = reflect.TypeOf(BasicResponse{})
+
+// Report to client.OnWarning, if set, the anomalies found in a response that was
+// unmarshalled in r from body
+func (c *FlickrClient) reportWarnings(body []byte, r FlickrResponse) {
+	if c.OnWarning == nil {
+		return
+	}
+	method := c.Args.Get("method")
+	for _, warning := range responseWarnings(body, r) {
+		c.OnWarning(method, warning)
+	}
+}
+
+// Return the anomalies of a response that don't make the call fail: unknown stat
+// values or, in successful responses, pages beyond the last one and elements expected
+// by r and missing from body
+func responseWarnings(body []byte, r FlickrResponse) []string {
+	var warnings []string
+
+	if a, ok := r.(interface{ Attributes() map[string]string }); ok {
+		if stat := a.Attributes()["stat"]; stat != "ok" && stat != "fail" {
+			warnings = append(warnings, fmt.Sprintf("unknown stat value %q", stat))
+		}
+	}
+	if r.HasErrors() {
+		return warnings
+	}
+
+	if p, ok := r.(Pager); ok && p.Pages() > 0 && p.Page() > p.Pages() {
+		warnings = append(warnings, fmt.Sprintf("page %d beyond last page %d", p.Page(), p.Pages()))
+	}
+
+	// types unmarshalling themselves know better what to expect
+	if _, ok := r.(xml.Unmarshaler); ok {
+		return warnings
+	}
+	children := rootChildren(body)
+	for _, name := range expectedElements(reflect.TypeOf(r)) {
+		if !children[name] {
+			warnings = append(warnings, fmt.Sprintf("missing element <%s>", name))
+		}
+	}
+	return warnings
+}
+
+// Return the names of the elements directly under the root element of body
+func rootChildren(body []byte) map[string]bool {
+	children := map[string]bool{}
+	d := xml.NewDecoder(bytes.NewReader(body))
+	depth := 0
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return children
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if depth == 1 {
+				children[t.Name.Local] = true
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
+
+// Return the names of the elements right under the root that t fields are mapped to,
+// fields of the embedded BasicResponse and untagged fields excluded
+func expectedElements(t reflect.Type) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == basicResponseType {
+		return nil
+	}
+
+	var names []string
+	seen := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("xml")
+		if f.Anonymous && tag == "" {
+			names = append(names, expectedElements(f.Type)...)
+			continue
+		}
+		if tag == "" || tag == "-" || f.PkgPath != "" || f.Name == "XMLName" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		if parts[0] == "" || len(parts) > 1 {
+			// attributes, character data, optional and other special fields
+			continue
+		}
+		name := strings.Split(parts[0], ">")[0]
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,73 @@
+package flickr
+
+import (
+	"testing"
+)
+
+type warnedResponse struct {
+	BasicResponse
+	Photos struct {
+		Page  int `xml:"page,attr"`
+		Pages int `xml:"pages,attr"`
+	} `xml:"photos"`
+	Total int    `xml:"total,attr"`
+	Blog  string `xml:"blog,omitempty"`
+}
+
+func (r *warnedResponse) Page() int  { return r.Photos.Page }
+func (r *warnedResponse) Pages() int { return r.Photos.Pages }
+
+func TestResponseWarnings(t *testing.T) {
+	r := &warnedResponse{}
+	r.Status = "ok"
+	r.Photos.Page = 3
+	r.Photos.Pages = 2
+	w := responseWarnings([]byte(`<rsp stat="ok"><photos page="3" pages="2" /></rsp>`), r)
+	Expect(t, len(w), 1)
+	Expect(t, w[0], "page 3 beyond last page 2")
+
+	r.Photos.Page = 0
+	w = responseWarnings([]byte(`<rsp stat="ok"></rsp>`), r)
+	Expect(t, len(w), 1)
+	Expect(t, w[0], "missing element <photos>")
+
+	r.Status = "maybe"
+	w = responseWarnings([]byte(`<rsp stat="maybe"></rsp>`), r)
+	Expect(t, len(w), 1)
+	Expect(t, w[0], `unknown stat value "maybe"`)
+
+	// errors don't come with the expected elements
+	r.Status = "fail"
+	w = responseWarnings([]byte(`<rsp stat="fail"><err code="1" msg="not found" /></rsp>`), r)
+	Expect(t, len(w), 0)
+
+	w = responseWarnings([]byte(`<rsp stat="ok"></rsp>`), &BasicResponse{Status: "ok"})
+	Expect(t, len(w), 0)
+}
+
+func TestOnWarning(t *testing.T) {
+	fclient := GetTestClient()
+	server, client := FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok"><photos page="1" pages="1" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	// no-op when unset
+	fclient.Args.Set("method", "flickr.photos.search")
+	Expect(t, DoGet(fclient, &warnedResponse{}), nil)
+
+	var warnings []string
+	fclient.OnWarning = func(method string, warning string) {
+		warnings = append(warnings, method+": "+warning)
+	}
+	Expect(t, DoGet(fclient, &warnedResponse{}), nil)
+	Expect(t, len(warnings), 0)
+
+	server, client = FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok"><photo id="1" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	Expect(t, DoPost(fclient, &warnedResponse{}), nil)
+	Expect(t, len(warnings), 1)
+	Expect(t, warnings[0], "flickr.photos.search: missing element <photos>")
+}